@@ -46,6 +46,7 @@ const (
 
 	URITypeFavorites = "Favorites"
 	URITypeSuggestions = "Suggestions"
+	URITypeForYou = "ForYou"
 
 	URITypeDirectMedia = "DirectMedia"
 
@@ -54,6 +54,11 @@ type QueueInfo struct {
 
 	Locale string `json:"locale"`
 
+	SkipVotes       int `json:"skip_votes"`
+	SkipVotesNeeded int `json:"skip_votes_needed"` // 0 when no vote is in progress
+
+	VoiceCommands bool `json:"voice_commands"` // shows the push-to-talk button on the now playing message
+
 }
 
 type SongInternal struct {
@@ -182,6 +187,19 @@ func InfoToSong(Info Info) Song {
 
 }
 
+// URL returns a shareable link to the song: its Tidal track page, or the original link for direct media
+func (S *Song) URL() string {
+
+	if S.Internal.DirectURL != "" {
+
+		return S.Internal.DirectURL
+
+	}
+
+	return fmt.Sprintf("https://tidal.com/browse/track/%d", S.TidalID)
+
+}
+
 func ReplaceHyphens(s string) string {
 
 	result := ""
@@ -298,6 +316,12 @@ func (S *Song) Embed(State QueueInfo) discord.Embed {
 	Embed.AddField(Localizations.Get("Embeds.NowPlaying.FieldDuration", Locale), Localizations.GetFormat("Embeds.NowPlaying.DurationFormat", Locale, S.Duration.Formatted), true)
 	Embed.AddField(AddedState, S.Internal.Requestor, true)
 
+	if State.SkipVotesNeeded > 0 {
+
+		Embed.AddField(Localizations.Get("Embeds.NowPlaying.FieldSkipVotes", Locale), Localizations.GetFormat("Embeds.NowPlaying.SkipVotesFormat", Locale, State.SkipVotes, State.SkipVotesNeeded), true)
+
+	}
+
 	if S.Cover != "" {
 
 		DominantColor, ColorFetchError := Utils.GetDominantColorHex(S.Cover)
@@ -407,3 +431,66 @@ func (S *Song) Buttons(State QueueInfo) []discord.InteractiveComponent {
 	return Buttons
 
 }
+
+// FavoriteButton adds or removes the song from the clicking user's favorites
+func (S *Song) FavoriteButton(Locale string) discord.InteractiveComponent {
+
+	return discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Buttons.Favorite", Locale), fmt.Sprintf("Favorite:%d", S.TidalID), "", 0).WithEmoji(discord.ComponentEmoji{
+
+		ID: snowflake.MustParse(Icons.GetID(Icons.Star)),
+
+	})
+
+}
+
+// TalkButton opens a push-to-talk voice command capture for the clicking user
+func TalkButton(Locale string) discord.InteractiveComponent {
+
+	return discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Buttons.Talk", Locale), "Talk", "", 0).WithEmoji(discord.ComponentEmoji{
+
+		ID: snowflake.MustParse(Icons.GetID(Icons.Call)),
+
+	})
+
+}
+
+// Components lays out the song's buttons; the now playing row is full, so the favorite and talk buttons get a row of their own there.
+// Favorites are stored by Tidal ID, so songs from elsewhere (direct links) go without that button
+func (S *Song) Components(State QueueInfo) []discord.LayoutComponent {
+
+	if S == nil {
+
+		return nil
+
+	}
+
+	Buttons := S.Buttons(State)
+	Extra := []discord.InteractiveComponent{}
+
+	if S.TidalID != 0 {
+
+		Extra = append(Extra, S.FavoriteButton(State.Locale))
+
+	}
+
+	if State.SongPosition != 0 {
+
+		return []discord.LayoutComponent{discord.NewActionRow(append(Buttons, Extra...)...)}
+
+	}
+
+	if State.VoiceCommands {
+
+		Extra = append(Extra, TalkButton(State.Locale))
+
+	}
+
+	if len(Extra) == 0 {
+
+		return []discord.LayoutComponent{discord.NewActionRow(Buttons...)}
+
+	}
+
+	return []discord.LayoutComponent{discord.NewActionRow(Buttons...), discord.NewActionRow(Extra...)}
+
+}
@@ -6,6 +6,7 @@ package Audio
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"os"
 	"sync"
 	"time"
@@ -18,6 +19,9 @@ const (
 
 	CueCaptureStart CueKind = iota
 	CueCaptureEnd
+	CueConfirmPrompt
+	CueConfirmAccept
+	CueConfirmCancel
 )
 
 const (
@@ -27,6 +31,8 @@ const (
 
 	playbackDuckGain = 0.15 // music at 15% while capture active
 
+	confirmToneGain = 0.25
+
 )
 
 // PlaybackDuckGain is the music volume multiplier while voice command capture is active.
@@ -58,6 +64,10 @@ var (
 	cueLoadOnce sync.Once
 
 	cueLoadErr error
+
+	cueFramesConfirmPrompt = toneFrames(880, 120)
+	cueFramesConfirmAccept = append(toneFrames(660, 90), toneFrames(990, 120)...)
+	cueFramesConfirmCancel = append(toneFrames(660, 90), toneFrames(440, 140)...)
 )
 
 func loadVoiceCues() {
@@ -119,8 +129,77 @@ func loadPCMFrames(path string) ([][]int16, error) {
 
 }
 
+// toneFrames synthesizes a short sine beep as 48kHz stereo frames, with a fade in and out to avoid clicks.
+func toneFrames(Frequency float64, Milliseconds int) [][]int16 {
+
+	Total := SampleRate * Milliseconds / 1000
+	Fade := SampleRate / 200 // 5ms
+
+	FrameCount := (Total + FrameSize - 1) / FrameSize
+	Frames := make([][]int16, FrameCount)
+
+	for I := range FrameCount {
+
+		Frame := make([]int16, FrameSize*Channels)
+
+		for J := range FrameSize {
+
+			N := I*FrameSize + J
+
+			if N >= Total {
+
+				break
+
+			}
+
+			Envelope := 1.0
+
+			if N < Fade {
+
+				Envelope = float64(N) / float64(Fade)
+
+			} else if Total-N < Fade {
+
+				Envelope = float64(Total-N) / float64(Fade)
+
+			}
+
+			Sample := int16(math.Sin(2*math.Pi*Frequency*float64(N)/float64(SampleRate)) * Envelope * confirmToneGain * 32767)
+
+			for Ch := range Channels {
+
+				Frame[J*Channels+Ch] = Sample
+
+			}
+
+		}
+
+		Frames[I] = Frame
+
+	}
+
+	return Frames
+
+}
+
 func cueFrames(kind CueKind) [][]int16 {
 
+	switch kind {
+
+	case CueConfirmPrompt:
+
+		return cueFramesConfirmPrompt
+
+	case CueConfirmAccept:
+
+		return cueFramesConfirmAccept
+
+	case CueConfirmCancel:
+
+		return cueFramesConfirmCancel
+
+	}
+
 	loadVoiceCues()
 
 	if cueLoadErr != nil {
@@ -47,6 +47,9 @@ type MixerProvider struct {
 	captureDuckActive atomic.Bool
 	captureDuckEpoch atomic.Uint64
 
+	talkOverActive atomic.Bool
+	talkOver talkOverEnvelope
+
 	encoder *gopus.Encoder
 
 	work []float32
@@ -75,6 +78,15 @@ func NewMixerProvider() (*MixerProvider, error) {
 		work: make([]float32, FrameSize*Channels),
 		pcmOut: make([]int16, FrameSize*Channels),
 
+		talkOver: talkOverEnvelope{
+
+			depth: DefaultTalkOverDepthPercent / 100.0,
+			attack: DefaultTalkOverAttack,
+			release: DefaultTalkOverRelease,
+
+			gain: 1,
+
+		},
 
 	}, nil
 
@@ -250,6 +262,7 @@ func (mixer *MixerProvider) ProvideOpusFrame() ([]byte, error) {
 	hasMusic := mixer.readMusicFrameLocked(speed)
 
 	overlayPCM, hasOverlay := mixer.nextOverlayFrame()
+	talkOverFrom, talkOverTo := mixer.stepTalkOverLocked()
 
 	if !hasMusic && !hasOverlay {
 
@@ -293,6 +306,14 @@ func (mixer *MixerProvider) ProvideOpusFrame() ([]byte, error) {
 
 		}
 
+		// The capture duck already holds music lower than talk-over would, so the two never stack
+
+		if !mixer.captureDuckActive.Load() {
+
+			mixer.applyTalkOverLocked(talkOverFrom, talkOverTo)
+
+		}
+
 	}
 
 	if hasOverlay {
@@ -475,6 +496,7 @@ func (mixer *MixerProvider) Close() {
 	mixer.overlayActive.Store(false)
 	mixer.ttsActive.Store(false)
 	mixer.captureDuckActive.Store(false)
+	mixer.talkOverActive.Store(false)
 
 	mixer.cueMu.Lock()
 
@@ -29,6 +29,8 @@ type MP4Streamer struct {
 	BytesStreamed int64
 	FramesEmitted int64
 
+	SkipMs int64 // audio still to drop for a forward seek
+
 	PCMFrameChan chan []int16 // PCMFrameChan carries raw 20ms stereo PCM frames
 
 	CancelFunc context.CancelFunc
@@ -85,26 +87,51 @@ func (S *MP4Streamer) NextPCMFrame() ([]int16, error) {
 
 	}
 
-	select {
+	for {
 
-	case Frame, OK := <-S.PCMFrameChan:
+		select {
 
-		if !OK {
+		case Frame, OK := <-S.PCMFrameChan:
 
-			return nil, io.EOF
+			if !OK {
 
-		}
+				return nil, io.EOF
 
-		return Frame, nil
+			}
 
-	default:
+			if atomic.LoadInt64(&S.SkipMs) > 0 {
 
-		return nil, nil
+				atomic.AddInt64(&S.SkipMs, -20) // dropped as fast as frames decode
+				continue
+
+			}
+
+			return Frame, nil
+
+		default:
+
+			return nil, nil
+
+		}
 
 	}
 
 }
 
+// SkipAhead drops the next Ms of audio, seeking forward without refetching the stream
+func (S *MP4Streamer) SkipAhead(Ms int64) {
+
+	atomic.AddInt64(&S.SkipMs, Ms)
+
+}
+
+// Position is how far into the song listeners are, in milliseconds: audio decoded, less what is still buffered, plus any skip underway
+func (S *MP4Streamer) Position() int64 {
+
+	return atomic.LoadInt64(&S.Progress) - int64(len(S.PCMFrameChan))*20 + atomic.LoadInt64(&S.SkipMs)
+
+}
+
 func (S *MP4Streamer) Stop() {
 
 	if S.Stopped.Swap(true) {
@@ -4,56 +4,78 @@
 package Audio
 
 import (
-	"bytes"
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"Synthara-Redux/Globals/Localizations"
 )
 
 const (
 
-	ttsAPIEndpoint = "https://api.x.ai/v1/tts"
-
 	ttsCacheDir = "./Cache/TTS"
 
-	ttsModel = "grok-voice-latest"
-	ttsVoice = "eve"
-	ttsLanguage = "en"
+	ttsProvidersEnv = "VOICE_TTS_PROVIDERS" // comma separated chain, tried in order
+	defaultTTSProviders = "xai,piper,espeak"
 
 	ttsHTTPTimeout = 15 * time.Second
 	ttsMaxChars = 200
 
 )
 
-type ttsOutputFormat struct {
+// TTSProvider synthesizes speech as raw 48kHz mono int16 LE PCM.
+type TTSProvider interface {
+
+	Name() string
+
+	Voice(Locale string) string // voice for a Discord locale code, "" if the provider has none
+	Synthesize(Text string, Voice string, Language string) ([]byte, error)
 
-	Codec string `json:"codec"`
-	SampleRate int `json:"sample_rate"`
+}
+
+var ttsProviders = map[string]TTSProvider{
+
+	"xai": xaiTTSProvider{},
+	"piper": piperTTSProvider{},
+	"espeak": espeakTTSProvider{},
 
 }
 
-type ttsRequest struct {
+// TTSProviderChain returns the configured providers in fallback order, skipping unknown names.
+func TTSProviderChain() []TTSProvider {
+
+	Names := os.Getenv(ttsProvidersEnv)
 
-	Text string `json:"text"`
-	Model string `json:"model"`
+	if strings.TrimSpace(Names) == "" {
 
-	VoiceID string `json:"voice_id"`
-	Language string `json:"language"`
+		Names = defaultTTSProviders
 
-	OutputFormat ttsOutputFormat `json:"output_format"`
+	}
+
+	Chain := []TTSProvider{}
+
+	for _, Name := range strings.Split(Names, ",") {
+
+		if Provider, Exists := ttsProviders[strings.ToLower(strings.TrimSpace(Name))]; Exists {
+
+			Chain = append(Chain, Provider)
+
+		}
+
+	}
+
+	return Chain
 
 }
 
-// GenerateTTS returns 48kHz stereo PCM frames for text, loading from disk cache when available.
-func GenerateTTS(text string) ([][]int16, error) {
+// GenerateTTS returns 48kHz stereo PCM frames for text in the voice for Locale, walking the provider chain until one succeeds.
+func GenerateTTS(text string, Locale string) ([][]int16, error) {
 
 	text = sanitizeTTSInput(text)
 
@@ -63,25 +85,112 @@ func GenerateTTS(text string) ([][]int16, error) {
 
 	}
 
-	CachePath := filepath.Join(ttsCacheDir, ttsCacheKey(text)+".pcm")
+	Language := Localizations.Language(Locale)
+	Failures := []error{}
+
+	for _, Provider := range TTSProviderChain() {
+
+		Voice := Provider.Voice(Locale)
+
+		if Voice == "" {
+
+			continue
+
+		}
+
+		CachePath := filepath.Join(ttsCacheDir, Provider.Name(), ttsPathSegment(Voice), ttsCacheKey(Language+"\x00"+text)+".pcm")
+
+		if Cached, Err := loadTTSCache(CachePath); Err == nil {
+
+			return Cached, nil
+
+		}
+
+		Raw, Err := Provider.Synthesize(text, Voice, Language)
+
+		if Err == nil && len(Raw) < FrameSize*2 {
+
+			Err = fmt.Errorf("no audio returned")
+
+		}
+
+		if Err != nil {
+
+			Failures = append(Failures, fmt.Errorf("%s: %w", Provider.Name(), Err))
+			continue
+
+		}
+
+		saveTTSCache(CachePath, Raw)
+
+		return monoToStereoFrames(Raw), nil
+
+	}
+
+	if len(Failures) == 0 {
 
-	if Cached, Err := loadTTSCache(CachePath); Err == nil {
+		return nil, fmt.Errorf("no TTS provider configured for locale %q", Locale)
 
-		return Cached, nil
+	}
+
+	return nil, errors.Join(Failures...)
+
+}
+
+// ttsVoiceFor picks the voice for Locale from an env override ("en=voice,es=voice"), then Defaults, then the "en" default.
+func ttsVoiceFor(Env string, Defaults map[string]string, Locale string) string {
+
+	Language := Localizations.Language(Locale)
+	Voices := map[string]string{}
+
+	for Key, Value := range Defaults {
+
+		Voices[Key] = Value
 
 	}
 
-	Raw, Err := callTTSAPI(text)
+	for _, Pair := range strings.Split(os.Getenv(Env), ",") {
 
-	if Err != nil {
+		if Key, Value, Found := strings.Cut(Pair, "="); Found {
 
-		return nil, Err
+			Voices[strings.ToLower(strings.TrimSpace(Key))] = strings.TrimSpace(Value)
+
+		}
+
+	}
+
+	if Voice, Exists := Voices[strings.ToLower(Locale)]; Exists {
+
+		return Voice
+
+	}
+
+	if Voice, Exists := Voices[Language]; Exists {
+
+		return Voice
 
 	}
 
-	saveTTSCache(CachePath, Raw)
+	return Voices["*"]
+
+}
+
+// ttsPathSegment keeps a voice name (which may be a model path) usable as a single cache directory.
+func ttsPathSegment(Voice string) string {
+
+	Voice = strings.TrimSuffix(filepath.Base(Voice), ".onnx")
+
+	return strings.Map(func(R rune) rune {
+
+		if R == '/' || R == '\\' || R == ':' || R == ' ' {
+
+			return '_'
+
+		}
+
+		return R
 
-	return monoToStereoFrames(Raw), nil
+	}, Voice)
 
 }
 
@@ -172,74 +281,6 @@ func saveTTSCache(path string, raw []byte) {
 
 }
 
-func callTTSAPI(text string) ([]byte, error) {
-
-	APIKey := os.Getenv("XAI_API_KEY")
-
-	if APIKey == "" {
-
-		return nil, fmt.Errorf("XAI_API_KEY not set")
-
-	}
-
-	Body, Err := json.Marshal(ttsRequest{
-
-		Text: text,
-		Model: ttsModel,
-
-		VoiceID: ttsVoice,
-		Language: ttsLanguage,
-
-		OutputFormat: ttsOutputFormat{
-
-			Codec: "pcm",
-			SampleRate: SampleRate,
-
-		},
-
-	})
-
-	if Err != nil {
-
-		return nil, Err
-
-	}
-
-	Req, Err := http.NewRequest(http.MethodPost, ttsAPIEndpoint, bytes.NewReader(Body))
-
-	if Err != nil {
-
-		return nil, Err
-
-	}
-
-	Req.Header.Set("Authorization", "Bearer "+APIKey)
-	Req.Header.Set("Content-Type", "application/json")
-
-	Client := &http.Client{Timeout: ttsHTTPTimeout}
-
-	Resp, Err := Client.Do(Req)
-
-	if Err != nil {
-
-		return nil, fmt.Errorf("TTS request: %w", Err)
-
-	}
-
-	defer Resp.Body.Close()
-
-	if Resp.StatusCode != http.StatusOK {
-
-		ErrBody, _ := io.ReadAll(Resp.Body)
-
-		return nil, fmt.Errorf("TTS API %d: %s", Resp.StatusCode, string(ErrBody))
-
-	}
-
-	return io.ReadAll(Resp.Body)
-
-}
-
 // monoToStereoFrames converts raw 48kHz mono int16 LE PCM bytes into 20ms stereo frames.
 func monoToStereoFrames(raw []byte) [][]int16 {
 
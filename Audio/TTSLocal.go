@@ -0,0 +1,252 @@
+//go:build linux || darwin || windows
+// +build linux darwin windows
+
+package Audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+
+	ttsPiperCmdEnv = "VOICE_TTS_PIPER_CMD"
+	ttsPiperDirEnv = "VOICE_TTS_PIPER_DIR" // directory holding <voice>.onnx and <voice>.onnx.json
+	ttsPiperVoicesEnv = "VOICE_TTS_PIPER_VOICES"
+
+	ttsEspeakCmdEnv = "VOICE_TTS_ESPEAK_CMD"
+	ttsEspeakVoicesEnv = "VOICE_TTS_ESPEAK_VOICES"
+
+	defaultTTSPiperCmd = "piper"
+	defaultTTSPiperDir = "./Modules/tts"
+	defaultTTSEspeakCmd = "espeak-ng"
+
+)
+
+// piperTTSVoices are Piper voice models per language; Japanese has no Piper voice and falls through to the next provider.
+var piperTTSVoices = map[string]string{
+
+	"en": "en_US-lessac-medium",
+	"es": "es_ES-davefx-medium",
+	"zh": "zh_CN-huayan-medium",
+	"fr": "fr_FR-siwis-medium",
+	"it": "it_IT-riccardo-x_low",
+	"de": "de_DE-thorsten-medium",
+	"pl": "pl_PL-darkman-medium",
+	"ru": "ru_RU-irina-medium",
+
+}
+
+// espeakTTSVoices are espeak-ng voice names per language.
+var espeakTTSVoices = map[string]string{
+
+	"en": "en-us",
+	"es": "es",
+	"zh": "cmn",
+	"fr": "fr-fr",
+	"it": "it",
+	"de": "de",
+	"pl": "pl",
+	"ru": "ru",
+	"ja": "ja",
+
+}
+
+// piperTTSProvider runs the Piper neural TTS binary locally.
+type piperTTSProvider struct{}
+
+func (piperTTSProvider) Name() string {
+
+	return "piper"
+
+}
+
+func (piperTTSProvider) Voice(Locale string) string {
+
+	return ttsVoiceFor(ttsPiperVoicesEnv, piperTTSVoices, Locale)
+
+}
+
+func (piperTTSProvider) Synthesize(text string, Voice string, Language string) ([]byte, error) {
+
+	Model := Voice
+
+	if !strings.HasSuffix(Model, ".onnx") {
+
+		Dir := os.Getenv(ttsPiperDirEnv)
+
+		if Dir == "" {
+
+			Dir = defaultTTSPiperDir
+
+		}
+
+		Model = filepath.Join(Dir, Voice+".onnx")
+
+	}
+
+	if _, Err := os.Stat(Model); Err != nil {
+
+		return nil, fmt.Errorf("piper model: %w", Err)
+
+	}
+
+	return runLocalTTS(ttsPiperCmdEnv, defaultTTSPiperCmd, text, "--model", Model, "--output_file", "-")
+
+}
+
+// espeakTTSProvider runs espeak-ng, which is robotic but available for nearly every language.
+type espeakTTSProvider struct{}
+
+func (espeakTTSProvider) Name() string {
+
+	return "espeak"
+
+}
+
+func (espeakTTSProvider) Voice(Locale string) string {
+
+	return ttsVoiceFor(ttsEspeakVoicesEnv, espeakTTSVoices, Locale)
+
+}
+
+func (espeakTTSProvider) Synthesize(text string, Voice string, Language string) ([]byte, error) {
+
+	return runLocalTTS(ttsEspeakCmdEnv, defaultTTSEspeakCmd, text, "--stdout", "-v", Voice)
+
+}
+
+// runLocalTTS feeds text to a TTS command on stdin and converts the WAV it writes to stdout into 48kHz mono PCM bytes.
+func runLocalTTS(CmdEnv string, DefaultCmd string, text string, Args ...string) ([]byte, error) {
+
+	CommandLine := os.Getenv(CmdEnv)
+
+	if strings.TrimSpace(CommandLine) == "" {
+
+		CommandLine = DefaultCmd
+
+	}
+
+	Fields := strings.Fields(CommandLine)
+
+	Ctx, Cancel := context.WithTimeout(context.Background(), ttsHTTPTimeout)
+	defer Cancel()
+
+	var Stdout, Stderr bytes.Buffer
+
+	Cmd := exec.CommandContext(Ctx, Fields[0], append(Fields[1:], Args...)...)
+	Cmd.Stdin = strings.NewReader(text + "\n")
+	Cmd.Stdout = &Stdout
+	Cmd.Stderr = &Stderr
+
+	if Err := Cmd.Run(); Err != nil {
+
+		return nil, fmt.Errorf("%s: %w: %s", Fields[0], Err, strings.TrimSpace(Stderr.String()))
+
+	}
+
+	return wavToMonoPCM(Stdout.Bytes())
+
+}
+
+// wavToMonoPCM decodes a 16-bit WAV held in memory to 48kHz mono PCM bytes.
+// Tools writing to a pipe cannot seek back to patch the data size, so a bogus size means "until the end".
+func wavToMonoPCM(Data []byte) ([]byte, error) {
+
+	if len(Data) < 12 || string(Data[0:4]) != "RIFF" || string(Data[8:12]) != "WAVE" {
+
+		return nil, fmt.Errorf("not a RIFF WAVE file")
+
+	}
+
+	var Format wavFormat
+	var PCM []byte
+
+	for Offset := 12; Offset+8 <= len(Data) && PCM == nil; {
+
+		ChunkID := string(Data[Offset : Offset+4])
+		ChunkSize := int(binary.LittleEndian.Uint32(Data[Offset+4 : Offset+8]))
+		Body := Data[Offset+8:]
+
+		if ChunkSize > len(Body) || (ChunkID == "data" && ChunkSize == 0) {
+
+			ChunkSize = len(Body)
+
+		}
+
+		switch ChunkID {
+
+			case "fmt ":
+
+				if ChunkSize < 16 {
+
+					return nil, fmt.Errorf("WAV fmt chunk too small")
+
+				}
+
+				Format.Channels = int(binary.LittleEndian.Uint16(Body[2:4]))
+				Format.SampleRate = int(binary.LittleEndian.Uint32(Body[4:8]))
+				Format.BitsPerSample = int(binary.LittleEndian.Uint16(Body[14:16]))
+
+			case "data":
+
+				PCM = Body[:ChunkSize]
+
+		}
+
+		Offset += 8 + ChunkSize + ChunkSize%2
+
+	}
+
+	if Format.SampleRate == 0 || Format.Channels == 0 || PCM == nil {
+
+		return nil, fmt.Errorf("WAV missing fmt or data chunk")
+
+	}
+
+	if Format.BitsPerSample != 16 {
+
+		return nil, fmt.Errorf("unsupported WAV bit depth %d", Format.BitsPerSample)
+
+	}
+
+	Frames := len(PCM) / (2 * Format.Channels)
+	Mono := make([]int16, Frames)
+
+	for I := range Frames {
+
+		Sum := 0
+
+		for Ch := range Format.Channels {
+
+			Sum += int(int16(binary.LittleEndian.Uint16(PCM[(I*Format.Channels+Ch)*2:])))
+
+		}
+
+		Mono[I] = int16(Sum / Format.Channels)
+
+	}
+
+	if Frames >= 2 {
+
+		Mono = ResamplePCM(Mono, Format.SampleRate, SampleRate, 1)
+
+	}
+
+	Out := make([]byte, len(Mono)*2)
+
+	for I, S := range Mono {
+
+		binary.LittleEndian.PutUint16(Out[I*2:], uint16(S))
+
+	}
+
+	return Out, nil
+
+}
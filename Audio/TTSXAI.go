@@ -0,0 +1,132 @@
+//go:build linux || darwin || windows
+// +build linux darwin windows
+
+package Audio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+const (
+
+	ttsAPIEndpoint = "https://api.x.ai/v1/tts"
+
+	ttsModel = "grok-voice-latest"
+
+	ttsXAIVoicesEnv = "VOICE_TTS_XAI_VOICES"
+
+)
+
+// xaiTTSVoices are the default voices per language; xAI voices are multilingual so one voice covers every locale.
+var xaiTTSVoices = map[string]string{
+
+	"*": "eve",
+
+}
+
+type ttsOutputFormat struct {
+
+	Codec string `json:"codec"`
+	SampleRate int `json:"sample_rate"`
+
+}
+
+type ttsRequest struct {
+
+	Text string `json:"text"`
+	Model string `json:"model"`
+
+	VoiceID string `json:"voice_id"`
+	Language string `json:"language"`
+
+	OutputFormat ttsOutputFormat `json:"output_format"`
+
+}
+
+// xaiTTSProvider calls the xAI text-to-speech API.
+type xaiTTSProvider struct{}
+
+func (xaiTTSProvider) Name() string {
+
+	return "xai"
+
+}
+
+func (xaiTTSProvider) Voice(Locale string) string {
+
+	return ttsVoiceFor(ttsXAIVoicesEnv, xaiTTSVoices, Locale)
+
+}
+
+func (xaiTTSProvider) Synthesize(text string, Voice string, Language string) ([]byte, error) {
+
+	APIKey := os.Getenv("XAI_API_KEY")
+
+	if APIKey == "" {
+
+		return nil, fmt.Errorf("XAI_API_KEY not set")
+
+	}
+
+	Body, Err := json.Marshal(ttsRequest{
+
+		Text: text,
+		Model: ttsModel,
+
+		VoiceID: Voice,
+		Language: Language,
+
+		OutputFormat: ttsOutputFormat{
+
+			Codec: "pcm",
+			SampleRate: SampleRate,
+
+		},
+
+	})
+
+	if Err != nil {
+
+		return nil, Err
+
+	}
+
+	Req, Err := http.NewRequest(http.MethodPost, ttsAPIEndpoint, bytes.NewReader(Body))
+
+	if Err != nil {
+
+		return nil, Err
+
+	}
+
+	Req.Header.Set("Authorization", "Bearer "+APIKey)
+	Req.Header.Set("Content-Type", "application/json")
+
+	Client := &http.Client{Timeout: ttsHTTPTimeout}
+
+	Resp, Err := Client.Do(Req)
+
+	if Err != nil {
+
+		return nil, fmt.Errorf("TTS request: %w", Err)
+
+	}
+
+	defer Resp.Body.Close()
+
+	if Resp.StatusCode != http.StatusOK {
+
+		ErrBody, _ := io.ReadAll(Resp.Body)
+
+		return nil, fmt.Errorf("TTS API %d: %s", Resp.StatusCode, string(ErrBody))
+
+	}
+
+	return io.ReadAll(Resp.Body)
+
+}
@@ -0,0 +1,126 @@
+//go:build linux || darwin || windows
+// +build linux darwin windows
+
+package Audio
+
+import (
+	"math"
+	"time"
+)
+
+const (
+
+	DefaultTalkOverDepthPercent = 70 // how much of the music's level is removed while people talk
+	DefaultTalkOverAttack = 50 * time.Millisecond
+	DefaultTalkOverRelease = 800 * time.Millisecond
+
+	frameDuration = 20 * time.Millisecond
+
+)
+
+// talkOverEnvelope is the smoothed gain applied to music while talk-over ducking is active.
+type talkOverEnvelope struct {
+
+	depth float32
+	attack time.Duration
+	release time.Duration
+
+	gain float32
+
+}
+
+// SetTalkOverShape configures how far music ducks while people talk (0 to 1) and how quickly it falls and recovers.
+func (mixer *MixerProvider) SetTalkOverShape(depth float32, attack time.Duration, release time.Duration) {
+
+	if mixer == nil {
+
+		return
+
+	}
+
+	mixer.mu.Lock()
+
+	mixer.talkOver.depth = min(max(depth, 0), 1)
+	mixer.talkOver.attack = attack
+	mixer.talkOver.release = release
+
+	mixer.mu.Unlock()
+
+}
+
+// SetTalkOver starts ducking music when someone in the channel talks, or releases it once they stop.
+func (mixer *MixerProvider) SetTalkOver(active bool) {
+
+	if mixer == nil {
+
+		return
+
+	}
+
+	mixer.talkOverActive.Store(active)
+
+}
+
+// stepTalkOverLocked advances the envelope by one frame and returns its gain at the start and end of the frame.
+func (mixer *MixerProvider) stepTalkOverLocked() (float32, float32) {
+
+	env := &mixer.talkOver
+	from := env.gain
+
+	target := float32(1)
+	timeConstant := env.release
+
+	if mixer.talkOverActive.Load() {
+
+		target = 1 - env.depth
+		timeConstant = env.attack
+
+	}
+
+	if timeConstant <= 0 {
+
+		env.gain = target
+		return from, target
+
+	}
+
+	// the attack and release times are when the gain is 95% of the way there (three time constants)
+
+	coeff := float32(math.Exp(-3 * float64(frameDuration) / float64(timeConstant)))
+
+	env.gain = target + (env.gain-target)*coeff
+
+	if math.Abs(float64(env.gain-target)) < 0.001 {
+
+		env.gain = target
+
+	}
+
+	return from, env.gain
+
+}
+
+// applyTalkOverLocked ramps the frame from one envelope gain to the next so the duck has no steps.
+func (mixer *MixerProvider) applyTalkOverLocked(from float32, to float32) {
+
+	if from == 1 && to == 1 {
+
+		return
+
+	}
+
+	step := (to - from) / FrameSize
+
+	for frame := 0; frame < FrameSize; frame++ {
+
+		gain := from + step*float32(frame+1)
+
+		for ch := 0; ch < Channels; ch++ {
+
+			mixer.work[frame*Channels+ch] *= gain
+
+		}
+
+	}
+
+}
@@ -90,6 +90,41 @@ func Get(Path string, Locale string) string {
 
 }
 
+// Lookup retrieves the string for exactly this locale, without falling back to the default.
+func Lookup(Path string, Locale string) (string, bool) {
+
+	Current := Manifest
+
+	Keys := strings.Split(Path, ".")
+
+	for _, Key := range Keys[:len(Keys)-1] {
+
+		Next, Valid := Current[Key].(map[string]interface{})
+
+		if !Valid {
+
+			return "", false
+
+		}
+
+		Current = Next
+
+	}
+
+	LocaleMap, Valid := Current[Keys[len(Keys)-1]].(map[string]interface{})
+
+	if !Valid {
+
+		return "", false
+
+	}
+
+	Value, Exists := LocaleMap[Locale].(string)
+
+	return Value, Exists
+
+}
+
 // GetLocalizedFormat retrieves a localized format string and applies the provided arguments.
 func GetFormat(Path string, Locale string, Args ...interface{}) string {
 
@@ -116,4 +151,18 @@ func Pluralize(Word string, Count int, Locale string) string {
 
 	return Get(fmt.Sprintf("Common.%ss", Word), Locale)
 
-}
\ No newline at end of file
+}
+// Language reduces a Discord locale code ("en-US", "zh-CN", "ja") to its language ("en", "zh", "ja"); an empty locale means the default's.
+func Language(Locale string) string {
+
+	Code, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(Locale)), "-")
+
+	if Code == "" {
+
+		return Language(Default)
+
+	}
+
+	return Code
+
+}
@@ -0,0 +1,28 @@
+package Autocomplete
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Structs"
+	"fmt"
+)
+
+// queueChoiceName labels an upcoming song; in fair-share mode the requestor is shown so the turn order is visible
+func queueChoiceName(Guild *Structs.Guild, PositionLabel string, Index int, Song *Tidal.Song) string {
+
+	Name := fmt.Sprintf("%s %d • %s", PositionLabel, Index+1, Song.Title)
+
+	if Guild.Settings.Queue.FairShare {
+
+		Name = fmt.Sprintf("%s • %s", Name, Guild.RequestorName(Song))
+
+	}
+
+	if len([]rune(Name)) > 100 {
+
+		Name = string([]rune(Name)[:99]) + "…"
+
+	}
+
+	return Name
+
+}
@@ -0,0 +1,77 @@
+package Autocomplete
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// FavoritesAutocomplete suggests the user's liked songs for /favorites remove
+func FavoritesAutocomplete(Event *events.AutocompleteInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	None := []discord.AutocompleteChoice{
+
+		discord.AutocompleteChoiceString{
+
+			Name:  Localizations.Get("Autocomplete.Favorites.None", Locale),
+			Value: "none",
+
+		},
+
+	}
+
+	User, Error := Structs.GetUser(Event.User().ID.String())
+
+	if Error != nil || len(User.Liked) == 0 {
+
+		Event.AutocompleteResult(None)
+		return
+
+	}
+
+	Focused := strings.ToLower(Event.Data.String("song"))
+	Choices := []discord.AutocompleteChoice{}
+
+	for _, Song := range User.LikedSongs() {
+
+		Label := fmt.Sprintf("%s • %s", Song.Title, strings.Join(Song.Artists, ", "))
+
+		if Focused != "" && !strings.Contains(strings.ToLower(Label), Focused) {
+
+			continue
+
+		}
+
+		Choices = append(Choices, discord.AutocompleteChoiceString{
+
+			Name:  Utils.Truncate(Label, 100),
+			Value: strconv.FormatInt(Song.TidalID, 10),
+
+		})
+
+		if len(Choices) >= 25 {
+
+			break
+
+		}
+
+	}
+
+	if len(Choices) == 0 {
+
+		Event.AutocompleteResult(None)
+		return
+
+	}
+
+	Event.AutocompleteResult(Choices)
+
+}
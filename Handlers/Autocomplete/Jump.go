@@ -3,7 +3,6 @@ package Autocomplete
 import (
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Structs"
-	"fmt"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
@@ -64,7 +63,7 @@ func JumpAutocomplete(Event *events.AutocompleteInteractionCreate) {
 
 		Choices = append(Choices, discord.AutocompleteChoiceInt{
 
-			Name:  fmt.Sprintf("%s %d • %s", PositionLabel, Index+1, Song.Title),
+			Name:  queueChoiceName(Guild, PositionLabel, Index, Song),
 			Value: Index,
 
 		})
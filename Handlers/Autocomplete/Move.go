@@ -50,6 +50,23 @@ func MoveAutocomplete(Event *events.AutocompleteInteractionCreate) {
 
 	}
 
+	if !Guild.Allowed(Event.User().ID, Structs.CapabilityMove) {
+
+		Event.AutocompleteResult([]discord.AutocompleteChoice{
+
+			discord.AutocompleteChoiceInt{
+
+				Name:  Localizations.Get("Autocomplete.NoPermission", Locale),
+				Value: 0,
+
+			},
+
+		})
+
+		return
+
+	}
+
 	Data := Event.Data
 	FocusedOption := Data.Focused().Name
 
@@ -74,7 +91,7 @@ func MoveAutocomplete(Event *events.AutocompleteInteractionCreate) {
 
 				Choices = append(Choices, discord.AutocompleteChoiceInt{
 
-					Name:  fmt.Sprintf("%s %d • %s", PositionLabel, Index+1, Song.Title),
+					Name:  queueChoiceName(Guild, PositionLabel, Index, Song),
 					Value: Index,
 
 				})
@@ -102,7 +119,7 @@ func MoveAutocomplete(Event *events.AutocompleteInteractionCreate) {
 
 				Choices = append(Choices, discord.AutocompleteChoiceInt{
 
-					Name:  fmt.Sprintf("%s %d • %s", PositionLabel, Index+1, Song.Title),
+					Name:  queueChoiceName(Guild, PositionLabel, Index, Song),
 					Value: Index,
 
 				})
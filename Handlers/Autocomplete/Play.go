@@ -66,7 +66,7 @@ func PlayAutocomplete(Event *events.AutocompleteInteractionCreate) {
 
 			// Add Play Favorites
 
-			if len(User.Favorites) > 0 {
+			if len(User.Favorites) > 0 || len(User.Liked) > 0 {
 
 				RecentlyPlayedChoices = append([]discord.AutocompleteChoice{discord.AutocompleteChoiceString{
 
@@ -77,6 +77,19 @@ func PlayAutocomplete(Event *events.AutocompleteInteractionCreate) {
 
 			}
 
+			// Add For You Mix
+
+			if len(User.Liked) > 0 {
+
+				RecentlyPlayedChoices = append([]discord.AutocompleteChoice{discord.AutocompleteChoiceString{
+
+					Name:  Localizations.Get("Autocomplete.Play.ForYou", Locale),
+					Value: "Synthara-Redux:ForYou:" + User.DiscordID,
+
+				}}, RecentlyPlayedChoices...)
+
+			}
+
 			// Add Play Suggestions
 
 			if User.MostRecentMix != "" {
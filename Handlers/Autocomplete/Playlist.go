@@ -0,0 +1,138 @@
+package Autocomplete
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// PlaylistAutocomplete suggests the user's playlists for "name" and Tidal search results for "song"
+func PlaylistAutocomplete(Event *events.AutocompleteInteractionCreate) {
+
+	if Event.Data.Focused().Name == "song" {
+
+		playlistSongAutocomplete(Event)
+		return
+
+	}
+
+	Locale := Event.Locale().Code()
+
+	None := []discord.AutocompleteChoice{
+
+		discord.AutocompleteChoiceString{
+
+			Name:  Localizations.Get("Autocomplete.Playlist.None", Locale),
+			Value: "none",
+
+		},
+
+	}
+
+	Playlists, Error := Structs.ListPlaylists(Event.User().ID.String())
+
+	if Error != nil || len(Playlists) == 0 {
+
+		Event.AutocompleteResult(None)
+		return
+
+	}
+
+	Focused := strings.ToLower(Event.Data.String("name"))
+	Choices := []discord.AutocompleteChoice{}
+
+	for _, Playlist := range Playlists {
+
+		if Focused != "" && !strings.Contains(strings.ToLower(Playlist.Name), Focused) {
+
+			continue
+
+		}
+
+		Choices = append(Choices, discord.AutocompleteChoiceString{
+
+			Name:  fmt.Sprintf("%s • %d %s", Playlist.Name, len(Playlist.Songs), Localizations.Pluralize("Song", len(Playlist.Songs), Locale)),
+			Value: Playlist.Name,
+
+		})
+
+		if len(Choices) >= 25 {
+
+			break
+
+		}
+
+	}
+
+	if len(Choices) == 0 {
+
+		Event.AutocompleteResult(None)
+		return
+
+	}
+
+	Event.AutocompleteResult(Choices)
+
+}
+
+// playlistSongAutocomplete searches Tidal; leaving the option empty adds the current song
+func playlistSongAutocomplete(Event *events.AutocompleteInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Input := strings.TrimSpace(Event.Data.String("song"))
+
+	if len(Input) < 3 {
+
+		Event.AutocompleteResult([]discord.AutocompleteChoice{
+
+			discord.AutocompleteChoiceString{
+
+				Name:  Localizations.Get("Autocomplete.Playlist.Song", Locale),
+				Value: "current",
+
+			},
+
+		})
+
+		return
+
+	}
+
+	Songs, Error := Tidal.SearchSongs(Input)
+
+	if Error != nil || len(Songs) == 0 {
+
+		Event.AutocompleteResult([]discord.AutocompleteChoice{})
+		return
+
+	}
+
+	Choices := []discord.AutocompleteChoice{}
+
+	for _, Song := range Songs {
+
+		Choices = append(Choices, discord.AutocompleteChoiceString{
+
+			Name:  Utils.Truncate(fmt.Sprintf("%s • %s", Song.Title, strings.Join(Song.Artists, ", ")), 100),
+			Value: strconv.FormatInt(Song.TidalID, 10),
+
+		})
+
+		if len(Choices) >= 25 {
+
+			break
+
+		}
+
+	}
+
+	Event.AutocompleteResult(Choices)
+
+}
@@ -32,6 +32,23 @@ func SavedQueueAutocomplete(Event *events.AutocompleteInteractionCreate) {
 
 	}
 
+	if !Structs.GuildSettingsFor(*GuildID).Permissions.Allowed(*GuildID, Event.User().ID, Structs.CapabilitySaveLoad) {
+
+		Event.AutocompleteResult([]discord.AutocompleteChoice{
+
+			discord.AutocompleteChoiceString{
+
+				Name:  Localizations.Get("Autocomplete.NoPermission", Locale),
+				Value: "none",
+
+			},
+
+		})
+
+		return
+
+	}
+
 	Names, Error := Structs.ListSavedQueueNames(GuildID.String())
 
 	if Error != nil || len(Names) == 0 {
@@ -0,0 +1,93 @@
+package Autocomplete
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// ScheduleAutocomplete suggests saved queues and the user's playlists for "source" and existing jobs for "id"
+func ScheduleAutocomplete(Event *events.AutocompleteInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := Event.GuildID()
+
+	None := []discord.AutocompleteChoice{
+
+		discord.AutocompleteChoiceString{
+
+			Name:  Localizations.Get("Autocomplete.Schedule.None", Locale),
+			Value: "none",
+
+		},
+
+	}
+
+	if GuildID == nil {
+
+		Event.AutocompleteResult(None)
+		return
+
+	}
+
+	Focused := Event.Data.Focused().Name
+	Input := strings.ToLower(strings.TrimSpace(Event.Data.String(Focused)))
+	Choices := []discord.AutocompleteChoice{}
+
+	Add := func(Name string, Value string) {
+
+		if len(Choices) < 25 && (Input == "" || strings.Contains(strings.ToLower(Name), Input)) {
+
+			Choices = append(Choices, discord.AutocompleteChoiceString{Name: Utils.Truncate(Name, 100), Value: Value})
+
+		}
+
+	}
+
+	if Focused == "id" {
+
+		Jobs, _ := Structs.ListSchedules(GuildID.String())
+
+		for _, Job := range Jobs {
+
+			Add(fmt.Sprintf("%s • %s • %s", Job.ID, Job.SourceName, Job.NextRun.UTC().Format(Structs.ScheduleTimeLayout)+" UTC"), Job.ID)
+
+		}
+
+	} else {
+
+		Names, _ := Structs.ListSavedQueueNames(GuildID.String())
+		sort.Strings(Names)
+
+		for _, Name := range Names {
+
+			Add(Localizations.GetFormat("Autocomplete.Schedule.Queue", Locale, Name), Structs.ScheduleSourceQueue+":"+Name)
+
+		}
+
+		Playlists, _ := Structs.ListPlaylists(Event.User().ID.String())
+
+		for _, Playlist := range Playlists {
+
+			Add(Localizations.GetFormat("Autocomplete.Schedule.Playlist", Locale, Playlist.Name), Structs.ScheduleSourcePlaylist+":"+Playlist.ID)
+
+		}
+
+	}
+
+	if len(Choices) == 0 {
+
+		Event.AutocompleteResult(None)
+		return
+
+	}
+
+	Event.AutocompleteResult(Choices)
+
+}
@@ -152,7 +152,7 @@ func Album(Event *events.ApplicationCommandInteractionCreate) {
 
 	// Build buttons - use int64 AlbumID
 
-	EnqueueButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Embeds.Album.EnqueueAll", Locale), fmt.Sprintf("AlbumEnqueue:%d", CurrentSong.AlbumID), "", 0).WithEmoji(discord.ComponentEmoji{
+	EnqueueButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Embeds.Album.EnqueueAll", Locale), fmt.Sprintf("AlbumEnqueue:%d:%s", CurrentSong.AlbumID, insertPosition(Event.SlashCommandInteractionData()).String()), "", 0).WithEmoji(discord.ComponentEmoji{
 		ID: snowflake.MustParse(Icons.GetID(Icons.Albums)),
 	})
 
@@ -153,7 +153,7 @@ func Artist(Event *events.ApplicationCommandInteractionCreate) {
 
 	// Build buttons
 
-	EnqueueButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Embeds.Artist.EnqueueAll", Locale), fmt.Sprintf("ArtistEnqueue:%d", CurrentSong.ArtistID), "", 0).WithEmoji(discord.ComponentEmoji{
+	EnqueueButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Embeds.Artist.EnqueueAll", Locale), fmt.Sprintf("ArtistEnqueue:%d:%s", CurrentSong.ArtistID, insertPosition(Event.SlashCommandInteractionData()).String()), "", 0).WithEmoji(discord.ComponentEmoji{
 
 		ID: snowflake.MustParse(Icons.GetID(Icons.Sparkles)),
 
@@ -14,7 +14,7 @@ import (
 
 func Speed(event *events.ApplicationCommandInteractionCreate) {
 
-	runPlaybackIntSetting(event, "Commands.Speed.Title",
+	runPlaybackIntSetting(event, "Commands.Speed.Title", Structs.CapabilityEffects,
 
 		func(guild *Structs.Guild, locale string) string {
 
@@ -30,7 +30,7 @@ func Speed(event *events.ApplicationCommandInteractionCreate) {
 
 func Reverb(event *events.ApplicationCommandInteractionCreate) {
 
-	runPlaybackIntSetting(event, "Commands.Reverb.Title",
+	runPlaybackIntSetting(event, "Commands.Reverb.Title", Structs.CapabilityEffects,
 
 		func(guild *Structs.Guild, locale string) string {
 
@@ -44,7 +44,7 @@ func Reverb(event *events.ApplicationCommandInteractionCreate) {
 
 }
 
-func runPlaybackIntSetting(event *events.ApplicationCommandInteractionCreate, titleKey string, describe func(*Structs.Guild, string) string, apply func(*Structs.Guild, int) int) {
+func runPlaybackIntSetting(event *events.ApplicationCommandInteractionCreate, titleKey string, capability string, describe func(*Structs.Guild, string) string, apply func(*Structs.Guild, int) int) {
 
 	locale := event.Locale().Code()
 	guildID := *event.GuildID()
@@ -77,6 +77,19 @@ func runPlaybackIntSetting(event *events.ApplicationCommandInteractionCreate, ti
 
 	}
 
+	if capabilityErr := Validation.CapabilityError(guildID, event.User().ID, capability, locale); capabilityErr != nil {
+
+		event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{*capabilityErr},
+			Flags:  discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
 	value := int(event.SlashCommandInteractionData().Int("value"))
 	guild.ResetInactivityTimer()
 
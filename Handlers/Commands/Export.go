@@ -0,0 +1,130 @@
+package Commands
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+var exportFileNameRegex = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// exportFileName turns a queue name into a safe attachment name
+func exportFileName(Name string, Extension string) string {
+
+	Base := strings.Trim(exportFileNameRegex.ReplaceAllString(Name, "-"), "-")
+
+	if Base == "" {
+
+		Base = "queue"
+
+	}
+
+	return Base + "." + Extension
+
+}
+
+func Export(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Data := Event.SlashCommandInteractionData()
+	Format := Data.String("format")
+	Name := strings.TrimSpace(Data.String("name"))
+
+	var Songs []*Tidal.Song
+
+	if Name == "" {
+
+		Guild := Structs.GetGuild(GuildID, false)
+
+		if Guild == nil {
+
+			ErrorEmbed := Validation.GuildSessionError(Locale)
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if Guild.Queue.Current != nil {
+
+			Songs = append(Songs, Guild.Queue.Current)
+
+		}
+
+		Songs = append(Songs, Guild.Queue.Upcoming...)
+		Name = Localizations.Get("Commands.Export.QueueName", Locale)
+
+	} else {
+
+		if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilitySaveLoad, Locale); ErrorEmbed != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		Snapshot, Error := Structs.GetSavedQueue(GuildID.String(), Name)
+
+		if Error != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{buildSavedQueueLoadError(Locale, Error)}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		Songs = append(Songs, Snapshot.Previous...)
+
+		if Snapshot.Current != nil {
+
+			Songs = append(Songs, Snapshot.Current)
+
+		}
+
+		Songs = append(Songs, Snapshot.Upcoming...)
+
+	}
+
+	Contents, Extension, Error := Structs.ExportSongs(Songs, Name, Format)
+
+	if Error != nil {
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Export.Error.Empty.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+				Description: Localizations.Get("Commands.Export.Error.Empty.Description", Locale),
+				Color:       Utils.ERROR,
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	Event.CreateMessage(discord.NewMessageCreate().
+		AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Export.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Localizations.GetFormat("Commands.Export.Description", Locale, Name, len(Songs), Localizations.Pluralize("Song", len(Songs), Locale), strings.ToUpper(Extension)),
+
+		})).
+		AddFile(exportFileName(Name, Extension), fmt.Sprintf("%s (%s)", Name, strings.ToUpper(Extension)), bytes.NewReader(Contents)))
+
+}
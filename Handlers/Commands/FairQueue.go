@@ -0,0 +1,77 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func FairQueue(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Guild := Structs.GetGuild(GuildID, false) // settings can be changed without an active session
+
+	Policy := Structs.GuildSettingsFor(GuildID).Queue
+	Policy.FairShare = Event.SlashCommandInteractionData().Bool("enabled")
+
+	if SaveError := Structs.SaveQueuePolicy(GuildID.String(), Policy); SaveError != nil {
+
+		Utils.Logger.Error("FairQueue", fmt.Sprintf("Failed to save queue policy for guild %s: %s", GuildID.String(), SaveError.Error()))
+
+		ErrorEmbed := Validation.SettingsSaveError(Locale)
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if Guild != nil {
+
+		Guild.Settings.Queue = Policy
+
+		if Policy.FairShare {
+
+			Guild.Queue.Rebalance() // interleave what is already queued
+
+		} else {
+
+			Guild.Queue.Functions.Updated(&Guild.Queue)
+
+		}
+
+	}
+
+	Description := Localizations.Get("Commands.FairQueue.Disabled", Locale)
+
+	if Policy.FairShare {
+
+		Description = Localizations.Get("Commands.FairQueue.Enabled", Locale)
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.FairQueue.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+			Description: Description,
+
+		})},
+
+	})
+
+}
@@ -0,0 +1,378 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+type FavoritesResponse struct {
+	Embeds     []discord.Embed
+	Components []discord.LayoutComponent
+}
+
+func favoritesError(TitleKey string, DescriptionKey string, Locale string) discord.MessageCreate {
+
+	return discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get(TitleKey, Locale),
+			Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+			Description: Localizations.Get(DescriptionKey, Locale),
+			Color:       Utils.ERROR,
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	}
+
+}
+
+// BuildFavoritesResponse renders one page of a user's liked songs with paging, play and remove controls
+func BuildFavoritesResponse(User *Structs.User, Page int, Locale string) FavoritesResponse {
+
+	Songs := User.LikedSongs()
+
+	Pages := max((len(Songs)+Structs.FavoritesPageSize-1)/Structs.FavoritesPageSize, 1)
+	Page = min(max(Page, 0), Pages-1)
+
+	Start := Page * Structs.FavoritesPageSize
+	End := min(Start+Structs.FavoritesPageSize, len(Songs))
+
+	var Body strings.Builder
+
+	Body.WriteString(Localizations.GetFormat("Commands.Favorites.Stats", Locale, len(Songs), Localizations.Pluralize("Song", len(Songs), Locale), Page+1, Pages))
+	Body.WriteString("\n\n")
+
+	if len(Songs) == 0 {
+
+		Body.WriteString(Localizations.Get("Commands.Favorites.Empty.Description", Locale))
+
+	}
+
+	Options := []discord.StringSelectMenuOption{}
+
+	for Index, Song := range Songs[Start:End] {
+
+		Number := Start + Index + 1
+
+		Body.WriteString(fmt.Sprintf("%d. **%s** • %s\n", Number, Song.Title, strings.Join(Song.Artists, ", ")))
+
+		Options = append(Options, discord.NewStringSelectMenuOption(Utils.Truncate(fmt.Sprintf("%d. %s", Number, Song.Title), 100), strconv.FormatInt(Song.TidalID, 10)).
+			WithDescription(Utils.Truncate(strings.Join(Song.Artists, ", "), 100)))
+
+	}
+
+	UserID := User.DiscordID
+
+	PreviousButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Common.Previous", Locale), fmt.Sprintf("FavoritesPage:%s:%d", UserID, Page-1), "", 0).WithDisabled(Page == 0)
+	NextButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Common.Next", Locale), fmt.Sprintf("FavoritesPage:%s:%d", UserID, Page+1), "", 0).WithDisabled(Page+1 >= Pages)
+	PlayButton := discord.NewButton(discord.ButtonStylePrimary, Localizations.Get("Commands.Favorites.PlayAll", Locale), fmt.Sprintf("FavoritesPlay:%s:0", UserID), "", 0).WithDisabled(len(Songs) == 0)
+	ShuffleButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Commands.Favorites.Shuffle", Locale), fmt.Sprintf("FavoritesPlay:%s:1", UserID), "", 0).WithDisabled(len(Songs) == 0)
+
+	Components := []discord.LayoutComponent{discord.NewActionRow(PreviousButton, NextButton, PlayButton, ShuffleButton)}
+
+	if len(Options) > 0 {
+
+		Components = append(Components, discord.NewActionRow(discord.NewStringSelectMenu(fmt.Sprintf("FavoritesRemove:%s:%d", UserID, Page), Localizations.Get("Commands.Favorites.RemovePick", Locale), Options...)))
+
+	}
+
+	return FavoritesResponse{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Favorites.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Body.String(),
+
+		})},
+
+		Components: Components,
+
+	}
+
+}
+
+// PlayFavorites queues all of a user's liked songs in the guild's session, optionally shuffled
+func PlayFavorites(GuildID snowflake.ID, UserID snowflake.ID, Shuffle bool, Locale string) discord.MessageCreate {
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		return discord.MessageCreate{Embeds: []discord.Embed{Validation.GuildSessionError(Locale)}, Flags: discord.MessageFlagEphemeral}
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, UserID, Locale); ErrorEmbed != nil {
+
+		return discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral}
+
+	}
+
+	User, ErrorFetching := Structs.GetUser(UserID.String())
+
+	if ErrorFetching != nil {
+
+		Utils.Logger.Error("Favorites", fmt.Sprintf("Error fetching user %s: %s", UserID.String(), ErrorFetching.Error()))
+		return favoritesError("Commands.Favorites.Error.Title", "Commands.Favorites.Error.Description", Locale)
+
+	}
+
+	Songs := User.LikedSongs()
+
+	if len(Songs) == 0 {
+
+		return favoritesError("Commands.Favorites.Empty.Title", "Commands.Favorites.Empty.Description", Locale)
+
+	}
+
+	if Shuffle {
+
+		rand.Shuffle(len(Songs), func(i, j int) { Songs[i], Songs[j] = Songs[j], Songs[i] })
+
+	}
+
+	Added, Skipped := Guild.Queue.AddAll(Songs, fmt.Sprintf("<@%s>", UserID))
+
+	if Added > 0 && Guild.Queue.State == Structs.StateIdle && Guild.Queue.Current != nil {
+
+		Guild.Queue.Play() // the queue was empty
+
+	}
+
+	TitleKey := "Commands.Favorites.Queued.Title"
+
+	if Shuffle {
+
+		TitleKey = "Commands.Favorites.Queued.ShuffledTitle"
+
+	}
+
+	return discord.NewMessageCreate().
+		AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get(TitleKey, Locale),
+			Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+			Description: Skipped.AppendTo(Localizations.GetFormat("Commands.Favorites.Queued.Description", Locale, Added, Localizations.Pluralize("Song", Added, Locale)), Locale),
+
+		})).
+		AddActionRow(Guild.Queue.UndoButton(Locale))
+
+}
+
+func Favorites(Event *events.ApplicationCommandInteractionCreate) {
+
+	Data := Event.SlashCommandInteractionData()
+
+	Subcommand := "list"
+
+	if Data.SubCommandName != nil {
+
+		Subcommand = *Data.SubCommandName
+
+	}
+
+	switch Subcommand {
+
+	case "play":
+
+		Event.CreateMessage(PlayFavorites(*Event.GuildID(), Event.User().ID, Data.Bool("shuffle"), Event.Locale().Code()))
+
+	case "remove":
+
+		favoritesRemove(Event)
+
+	case "foryou":
+
+		favoritesForYou(Event)
+
+	default:
+
+		favoritesList(Event)
+
+	}
+
+}
+
+func favoritesList(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	User, ErrorFetching := Structs.GetUser(Event.User().ID.String())
+
+	if ErrorFetching != nil {
+
+		Utils.Logger.Error("Favorites", fmt.Sprintf("Error fetching user %s: %s", Event.User().ID.String(), ErrorFetching.Error()))
+		Event.CreateMessage(favoritesError("Commands.Favorites.Error.Title", "Commands.Favorites.Error.Description", Locale))
+		return
+
+	}
+
+	Response := BuildFavoritesResponse(User, 0, Locale)
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds:     Response.Embeds,
+		Components: Response.Components,
+		Flags:      discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+func favoritesRemove(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	TidalID, ErrorParsing := strconv.ParseInt(Event.SlashCommandInteractionData().String("song"), 10, 64)
+
+	User, ErrorFetching := Structs.GetUser(Event.User().ID.String())
+
+	if ErrorFetching != nil {
+
+		Event.CreateMessage(favoritesError("Commands.Favorites.Error.Title", "Commands.Favorites.Error.Description", Locale))
+		return
+
+	}
+
+	if ErrorParsing != nil || !User.IsLiked(TidalID) {
+
+		Event.CreateMessage(favoritesError("Commands.Favorites.NotFound.Title", "Commands.Favorites.NotFound.Description", Locale))
+		return
+
+	}
+
+	Title := ""
+
+	for _, Liked := range User.Liked {
+
+		if Liked.Song.TidalID == TidalID {
+
+			Title = Liked.Song.Title
+
+		}
+
+	}
+
+	if ErrorRemoving := User.Unlike(TidalID); ErrorRemoving != nil {
+
+		Utils.Logger.Error("Favorites", fmt.Sprintf("Error removing favorite for user %s: %s", User.DiscordID, ErrorRemoving.Error()))
+		Event.CreateMessage(favoritesError("Commands.Favorites.Error.Title", "Commands.Favorites.Error.Description", Locale))
+		return
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Favorites.Removed.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+			Description: Localizations.GetFormat("Commands.Favorites.Removed.Description", Locale, Title),
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+// favoritesForYou builds a For You mix from the user's liked songs and queues it
+func favoritesForYou(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{Validation.GuildSessionError(Locale)}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, Event.User().ID, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	DeferDone := make(chan struct{})
+
+	go func() {
+
+		Event.DeferCreateMessage(false)
+		close(DeferDone)
+
+	}()
+
+	URI := "Synthara-Redux:ForYou:" + Event.User().ID.String()
+
+	SongFound, _, ErrorHandling := Guild.HandleURI(URI, Event.User().Mention())
+
+	if ErrorEmbed := Validation.QuotaError(ErrorHandling, Locale); ErrorEmbed != nil {
+
+		Utils.WaitFor(DeferDone)
+		Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{Embeds: &[]discord.Embed{*ErrorEmbed}})
+
+		return
+
+	}
+
+	if ErrorHandling != nil || SongFound == nil {
+
+		DescriptionKey := "Commands.Favorites.ForYou.Empty.Description"
+
+		if ErrorHandling == Structs.ErrNoLikedSongs {
+
+			DescriptionKey = "Commands.Favorites.Empty.Description"
+
+		}
+
+		Utils.WaitFor(DeferDone)
+		Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{
+
+			Embeds: &[]discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Favorites.ForYou.Empty.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+				Description: Localizations.Get(DescriptionKey, Locale),
+				Color:       Utils.ERROR,
+
+			})},
+
+		})
+
+		return
+
+	}
+
+	Utils.WaitFor(DeferDone)
+	Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.NewMessageUpdate().
+		AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Favorites.ForYou.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Localizations.GetFormat("Commands.Favorites.ForYou.Description", Locale, SongFound.Internal.Playlist.Total, Localizations.Pluralize("Song", SongFound.Internal.Playlist.Total, Locale)),
+			Color:       Utils.PRIMARY,
+
+		})).
+		AddActionRow(Guild.Queue.UndoButton(Locale)))
+
+}
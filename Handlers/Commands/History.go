@@ -0,0 +1,218 @@
+package Commands
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const HistoryViewTTL = 30 * time.Minute
+
+type HistoryResponse struct {
+	Embeds     []discord.Embed
+	Components []discord.LayoutComponent
+}
+
+// HistoryView returns the entries shown on a page of a /history view, or false once the view has expired
+func HistoryView(Token string, Page int) ([]Structs.PlayLogEntry, bool) {
+
+	Cached, Exists := Globals.GetOrCreateCache("HistoryViews").Get(fmt.Sprintf("%s:%d", Token, Page))
+
+	if !Exists {
+
+		return nil, false
+
+	}
+
+	Entries, Ok := Cached.([]Structs.PlayLogEntry)
+
+	return Entries, Ok
+
+}
+
+// BuildHistoryResponse renders one page of a guild's play log; the query is kept under Token so the buttons can page through it
+func BuildHistoryResponse(GuildID snowflake.ID, Token string, Page int, Locale string) (*HistoryResponse, error) {
+
+	Cache := Globals.GetOrCreateCache("HistoryViews")
+
+	Cached, Exists := Cache.Get(Token)
+
+	if !Exists {
+
+		return nil, fmt.Errorf("history view expired")
+
+	}
+
+	Query := Cached.(Structs.PlayLogQuery)
+
+	Entries, Total, ErrorSearching := Structs.SearchPlayLog(GuildID.String(), Query, Page)
+
+	if ErrorSearching != nil {
+
+		return nil, ErrorSearching
+
+	}
+
+	Cache.Set(fmt.Sprintf("%s:%d", Token, Page), Entries, HistoryViewTTL)
+
+	Pages := max((Total+Structs.PlayLogPageSize-1)/Structs.PlayLogPageSize, 1)
+
+	var Body strings.Builder
+
+	Body.WriteString(Localizations.GetFormat("Commands.History.Stats", Locale, Total, Localizations.Pluralize("Song", Total, Locale), Page+1, Pages))
+	Body.WriteString("\n\n")
+
+	if len(Entries) == 0 {
+
+		Body.WriteString(Localizations.Get("Commands.History.Empty", Locale))
+
+	}
+
+	Options := []discord.StringSelectMenuOption{}
+
+	for Index, Entry := range Entries {
+
+		Requestor := Entry.Requestor
+
+		if Entry.RequestorID != "" {
+
+			Requestor = fmt.Sprintf("<@%s>", Entry.RequestorID)
+
+		}
+
+		Played := Localizations.GetFormat("Commands.History.Played", Locale, Tidal.FormatDuration(Entry.PlayedSeconds))
+
+		if Entry.Skipped {
+
+			Played = Localizations.GetFormat("Commands.History.Skipped", Locale, Tidal.FormatDuration(Entry.PlayedSeconds))
+
+		}
+
+		Number := Page*Structs.PlayLogPageSize + Index + 1
+
+		Body.WriteString(fmt.Sprintf("%d. **%s** • %s\n-# %s • <t:%d:R> • %s\n", Number, Entry.Song.Title, strings.Join(Entry.Song.Artists, ", "), Requestor, Entry.StartedAt.Unix(), Played))
+
+		Options = append(Options, discord.NewStringSelectMenuOption(Utils.Truncate(fmt.Sprintf("%d. %s", Number, Entry.Song.Title), 100), fmt.Sprintf("%d", Index)).
+			WithDescription(Utils.Truncate(strings.Join(Entry.Song.Artists, ", "), 100)))
+
+	}
+
+	PreviousButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Common.Previous", Locale), fmt.Sprintf("HistoryPage:%s:%d", Token, Page-1), "", 0).WithDisabled(Page == 0)
+	NextButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Common.Next", Locale), fmt.Sprintf("HistoryPage:%s:%d", Token, Page+1), "", 0).WithDisabled(Page+1 >= Pages)
+	RequeueButton := discord.NewButton(discord.ButtonStylePrimary, Localizations.Get("Commands.History.RequeuePage", Locale), fmt.Sprintf("HistoryRequeue:%s:%d", Token, Page), "", 0).WithDisabled(len(Entries) == 0)
+
+	Components := []discord.LayoutComponent{discord.NewActionRow(PreviousButton, NextButton, RequeueButton)}
+
+	if len(Options) > 0 {
+
+		Components = append(Components, discord.NewActionRow(discord.NewStringSelectMenu(fmt.Sprintf("HistoryPick:%s:%d", Token, Page), Localizations.Get("Commands.History.Pick", Locale), Options...)))
+
+	}
+
+	return &HistoryResponse{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.History.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Body.String(),
+
+		})},
+
+		Components: Components,
+
+	}, nil
+
+}
+
+func History(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Data := Event.SlashCommandInteractionData()
+
+	Query := Structs.PlayLogQuery{Text: Data.String("search")}
+
+	if User, HasUser := Data.OptUser("user"); HasUser {
+
+		Query.UserID = User.ID.String()
+
+	}
+
+	if Date, HasDate := Data.OptString("date"); HasDate {
+
+		Day, ErrorParsing := Structs.ParsePlayLogDay(Date)
+
+		if ErrorParsing != nil {
+
+			Event.CreateMessage(discord.MessageCreate{
+
+				Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+					Title:       Localizations.Get("Commands.History.InvalidDate.Title", Locale),
+					Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+					Description: Localizations.Get("Commands.History.InvalidDate.Description", Locale),
+					Color:       Utils.ERROR,
+
+				})},
+
+				Flags: discord.MessageFlagEphemeral,
+
+			})
+
+			return
+
+		}
+
+		Query.Day = Day
+
+	}
+
+	Token := Event.ID().String()
+
+	Globals.GetOrCreateCache("HistoryViews").Set(Token, Query, HistoryViewTTL)
+
+	Response, ErrorBuilding := BuildHistoryResponse(GuildID, Token, 0, Locale)
+
+	if ErrorBuilding != nil {
+
+		Utils.Logger.Error("History", fmt.Sprintf("Error searching play log for guild %s: %s", GuildID.String(), ErrorBuilding.Error()))
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.History.Error.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+				Description: Localizations.Get("Commands.History.Error.Description", Locale),
+				Color:       Utils.ERROR,
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds:     Response.Embeds,
+		Components: Response.Components,
+
+	})
+
+}
@@ -0,0 +1,236 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+const importUnmatchedShown = 10
+
+var errImportTooLarge = errors.New("import file is too large")
+
+var importHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// downloadImport fetches an uploaded attachment, refusing anything past Structs.MaxImportBytes
+func downloadImport(Attachment discord.Attachment) ([]byte, error) {
+
+	if Attachment.Size > Structs.MaxImportBytes {
+
+		return nil, errImportTooLarge
+
+	}
+
+	Response, Error := importHTTPClient.Get(Attachment.URL)
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	defer Response.Body.Close()
+
+	if Response.StatusCode != http.StatusOK {
+
+		return nil, fmt.Errorf("unexpected status %d", Response.StatusCode)
+
+	}
+
+	Data, Error := io.ReadAll(io.LimitReader(Response.Body, Structs.MaxImportBytes+1))
+
+	if Error == nil && len(Data) > Structs.MaxImportBytes {
+
+		return nil, errImportTooLarge
+
+	}
+
+	return Data, Error
+
+}
+
+// importSummary lists the entries that matched nothing, capped so the embed stays readable
+func importSummary(Unmatched []string, Locale string) string {
+
+	if len(Unmatched) == 0 {
+
+		return ""
+
+	}
+
+	Shown := Unmatched[:min(len(Unmatched), importUnmatchedShown)]
+
+	var Body strings.Builder
+
+	Body.WriteString("\n\n")
+	Body.WriteString(Localizations.GetFormat("Commands.Import.Unmatched", Locale, len(Unmatched)))
+	Body.WriteString("\n")
+
+	for _, Label := range Shown {
+
+		Body.WriteString(fmt.Sprintf("- %s\n", Utils.Truncate(Label, 80)))
+
+	}
+
+	if len(Unmatched) > len(Shown) {
+
+		Body.WriteString(Localizations.GetFormat("Commands.Import.UnmatchedMore", Locale, len(Unmatched)-len(Shown)))
+
+	}
+
+	return Body.String()
+
+}
+
+func Import(Event *events.ApplicationCommandInteractionCreate) {
+
+	DeferDone := make(chan struct{})
+
+	go func() {
+
+		Event.DeferCreateMessage(false)
+		close(DeferDone)
+
+	}()
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Respond := func(Embed discord.Embed, Components ...discord.LayoutComponent) {
+
+		Utils.WaitFor(DeferDone)
+		Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{Embeds: &[]discord.Embed{Embed}, Components: &Components})
+
+	}
+
+	Failed := func(Key string, Arguments ...any) {
+
+		Respond(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Import.Error."+Key+".Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+			Description: Localizations.GetFormat("Commands.Import.Error."+Key+".Description", Locale, Arguments...),
+			Color:       Utils.ERROR,
+
+		}))
+
+	}
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		Respond(Validation.GuildSessionError(Locale))
+		return
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, Event.User().ID, Locale); ErrorEmbed != nil {
+
+		Respond(*ErrorEmbed)
+		return
+
+	}
+
+	Data := Event.SlashCommandInteractionData()
+	Entries := []Structs.ImportEntry{}
+
+	if Attachment, HasFile := Data.OptAttachment("file"); HasFile {
+
+		Contents, Error := downloadImport(Attachment)
+
+		if errors.Is(Error, errImportTooLarge) {
+
+			Failed("TooLarge", Structs.MaxImportBytes/1024)
+			return
+
+		}
+
+		if Error != nil {
+
+			Utils.Logger.Error("Import", fmt.Sprintf("Error downloading import %s: %s", Attachment.Filename, Error.Error()))
+			Failed("Download")
+			return
+
+		}
+
+		Parsed, Error := Structs.ParseImport(Attachment.Filename, Contents)
+
+		if Error != nil && !errors.Is(Error, Structs.ErrImportEmpty) {
+
+			Failed("Parse", Attachment.Filename)
+			return
+
+		}
+
+		Entries = append(Entries, Parsed...)
+
+	}
+
+	if Links := strings.TrimSpace(Data.String("links")); Links != "" {
+
+		Entries = append(Entries, Structs.ParseImportText(Links)...)
+
+	}
+
+	if len(Entries) == 0 {
+
+		Failed("NoInput")
+		return
+
+	}
+
+	Result := Structs.ResolveImport(Entries[:min(len(Entries), Structs.MaxImportEntries)])
+
+	if len(Result.Songs) == 0 {
+
+		Respond(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Import.Error.NoMatches.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+			Description: Localizations.Get("Commands.Import.Error.NoMatches.Description", Locale) + importSummary(Result.Unmatched, Locale),
+			Color:       Utils.ERROR,
+
+		}))
+
+		return
+
+	}
+
+	Added, Skipped := Guild.Queue.AddAll(Result.Songs, fmt.Sprintf("<@%s>", Event.User().ID))
+
+	if Added > 0 && Guild.Queue.State == Structs.StateIdle && Guild.Queue.Current != nil {
+
+		Guild.Queue.Play() // the queue was empty
+
+	}
+
+	Matched := Result.Entries - len(Result.Unmatched)
+
+	Description := Localizations.GetFormat("Commands.Import.Description", Locale, Matched, Result.Entries, Added, Localizations.Pluralize("Song", Added, Locale))
+
+	if Result.Truncated > 0 {
+
+		Description += "\n" + Localizations.GetFormat("Commands.Import.Truncated", Locale, Result.Truncated, Localizations.Pluralize("Song", Result.Truncated, Locale), Structs.MaxImportSongs)
+
+	}
+
+	Respond(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Import.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+		Description: Skipped.AppendTo(Description, Locale) + importSummary(Result.Unmatched, Locale),
+
+	}), discord.NewActionRow(Guild.Queue.UndoButton(Locale)))
+
+}
@@ -0,0 +1,147 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func Inactivity(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false) // settings can be changed without an active session
+
+	Policy := Structs.LoadGuildSettings(GuildID.String()).Inactivity
+
+	if Guild != nil {
+
+		Policy = Guild.Settings.Inactivity
+
+	}
+
+	Data := Event.SlashCommandInteractionData()
+
+	Timeout, HasTimeout := Data.OptInt("timeout")
+	AlwaysOn, HasAlwaysOn := Data.OptBool("always_on")
+	LeaveWhenAlone, HasLeaveWhenAlone := Data.OptBool("leave_when_alone")
+	Grace, HasGrace := Data.OptInt("grace")
+
+	Changed := HasTimeout || HasAlwaysOn || HasLeaveWhenAlone || HasGrace
+
+	Title := Localizations.Get("Commands.Inactivity.Current.Title", Locale)
+
+	if Changed {
+
+		if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if HasTimeout {
+
+			Policy.TimeoutMinutes = Timeout
+
+		}
+
+		if HasAlwaysOn {
+
+			Policy.AlwaysOn = AlwaysOn
+
+		}
+
+		if HasLeaveWhenAlone {
+
+			Policy.LeaveWhenAlone = LeaveWhenAlone
+
+		}
+
+		if HasGrace {
+
+			Policy.AloneGraceSeconds = Grace
+
+		}
+
+		var SaveError error
+
+		if Guild != nil {
+
+			SaveError = Guild.SetInactivityPolicy(Policy)
+			Policy = Guild.Settings.Inactivity
+
+		} else {
+
+			Policy, SaveError = Structs.SaveInactivityPolicy(GuildID.String(), Policy)
+
+		}
+
+		if SaveError != nil {
+
+			Utils.Logger.Error("Inactivity", fmt.Sprintf("Failed to save inactivity policy for guild %s: %s", GuildID.String(), SaveError.Error()))
+
+			ErrorEmbed := Validation.SettingsSaveError(Locale)
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		Title = Localizations.Get("Commands.Inactivity.Updated.Title", Locale)
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Title,
+			Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+			Description: describeInactivityPolicy(Policy, Locale),
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+// describeInactivityPolicy lists each inactivity setting on its own line
+func describeInactivityPolicy(Policy Structs.InactivityPolicy, Locale string) string {
+
+	Timeout := Localizations.Get("Commands.Inactivity.DefaultTimeout", Locale)
+
+	if Policy.TimeoutMinutes > 0 {
+
+		Timeout = Structs.FormatPolicyDuration(time.Duration(Policy.TimeoutMinutes)*time.Minute, Locale)
+
+	}
+
+	Grace := Structs.FormatPolicyDuration(time.Duration(Policy.AloneGraceSeconds)*time.Second, Locale)
+
+	return Localizations.GetFormat("Commands.Inactivity.Timeout", Locale, Timeout) + "\n" +
+		Localizations.GetFormat("Commands.Inactivity.AlwaysOn", Locale, onOff(Policy.AlwaysOn, Locale)) + "\n" +
+		Localizations.GetFormat("Commands.Inactivity.LeaveWhenAlone", Locale, onOff(Policy.LeaveWhenAlone, Locale)) + "\n" +
+		Localizations.GetFormat("Commands.Inactivity.Grace", Locale, Grace)
+
+}
+
+func onOff(Value bool, Locale string) string {
+
+	if Value {
+
+		return Localizations.Get("Common.On", Locale)
+
+	}
+
+	return Localizations.Get("Common.Off", Locale)
+
+}
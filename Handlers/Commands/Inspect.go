@@ -50,6 +50,13 @@ func Inspect(Event *events.ApplicationCommandInteractionCreate) {
 
 	GuildIDString, HasGuildSelection := Data.OptString("guild")
 
+	if Data.Bool("voice") {
+
+		ShowVoiceLog(Event, Locale, GuildIDString)
+		return
+
+	}
+
 	// If no guild specified, show overview of all active guilds
 
 	if !HasGuildSelection || GuildIDString == "" {
@@ -519,6 +526,56 @@ func ShowGuildDetails(Event *events.ApplicationCommandInteractionCreate, Locale
 	
 }
 
+// ShowVoiceLog lists the latest voice commands in one guild, or across all guilds when none is given
+func ShowVoiceLog(Event *events.ApplicationCommandInteractionCreate, Locale string, GuildIDString string) {
+
+	Entries, ErrorLoading := Structs.RecentVoiceLog(strings.TrimSpace(GuildIDString), "", Structs.VoiceLogPageSize)
+
+	if ErrorLoading != nil {
+
+		Utils.Logger.Error("Inspect", fmt.Sprintf("Error loading voice command log: %s", ErrorLoading.Error()))
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.VoiceLog.Error.Title", Locale),
+				Description: Localizations.Get("Commands.VoiceLog.Error.Description", Locale),
+				Color:       Utils.ERROR,
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	Description := FormatVoiceLog(Entries, Locale, GuildIDString == "")
+
+	if len(Entries) == 0 {
+
+		Description = Localizations.Get("Commands.VoiceLog.Empty", Locale)
+
+	}
+
+	EmbedBuilder := discord.NewEmbedBuilder()
+
+	EmbedBuilder.SetTitle(Localizations.Get("Commands.VoiceLog.Title", Locale))
+	EmbedBuilder.SetDescription(Description)
+	EmbedBuilder.SetColor(Utils.PRIMARY)
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{EmbedBuilder},
+		Flags:  discord.MessageFlagEphemeral,
+
+	})
+
+}
+
 func BoolToVal(value bool) string {
 
 	if value {
@@ -57,6 +57,13 @@ func Jump(Event *events.ApplicationCommandInteractionCreate) {
 
 	}
 
+	if ErrorEmbed := Validation.SkipVoteError(Guild.RequestJump(Event.User().ID), Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Success := Guild.Queue.Jump(Position + 1)
 
 	if !Success {
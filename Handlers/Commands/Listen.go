@@ -0,0 +1,85 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Receive"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"errors"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+func listenError(Key string, Locale string) discord.Embed {
+
+	return Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Listen.Error."+Key+".Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+		Description: Localizations.Get("Commands.Listen.Error."+Key+".Description", Locale),
+		Color:       Utils.ERROR,
+
+	})
+
+}
+
+// StartListening opens a push-to-talk voice command capture for a user in the bot's voice channel
+func StartListening(GuildID snowflake.ID, UserID snowflake.ID, Locale string) discord.Embed {
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		return Validation.GuildSessionError(Locale)
+
+	}
+
+	VoiceState, Exists := Globals.DiscordClient.Caches.VoiceState(GuildID, UserID)
+
+	if !Exists || VoiceState.ChannelID == nil || *VoiceState.ChannelID != Guild.Channels.Voice {
+
+		return listenError("NotInChannel", Locale)
+
+	}
+
+	if ErrListen := Receive.Listen(GuildID, UserID); ErrListen != nil {
+
+		if errors.Is(ErrListen, Receive.ErrSessionUnavailable) {
+
+			return listenError("OptedOut", Locale)
+
+		}
+
+		return listenError("Unavailable", Locale)
+
+	}
+
+	return Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Listen.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Controls", Locale),
+		Description: Localizations.Get("Commands.Listen.Description", Locale),
+		Color:       Utils.PRIMARY,
+
+	})
+
+}
+
+func Listen(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Embed := StartListening(*Event.GuildID(), Event.User().ID, Locale)
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Embed},
+		Flags:  discord.MessageFlagEphemeral,
+
+	})
+
+}
@@ -33,6 +33,13 @@ func Load(Event *events.ApplicationCommandInteractionCreate) {
 
 	}
 
+	if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilitySaveLoad, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Data := Event.SlashCommandInteractionData()
 	Name := Data.String("name")
 
@@ -75,6 +82,8 @@ func Load(Event *events.ApplicationCommandInteractionCreate) {
 
 		})},
 
+		Components: []discord.LayoutComponent{discord.NewActionRow(Guild.Queue.UndoButton(Locale))},
+
 	})
 
 }
@@ -4,6 +4,7 @@ import (
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
@@ -37,6 +38,13 @@ func Lock(Event *events.ApplicationCommandInteractionCreate) {
 
 	}
 
+	if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilityLock, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Guild.Features.Locked = true
 
 	Event.CreateMessage(discord.MessageCreate{
@@ -1,6 +1,7 @@
 package Commands
 
 import (
+	"Synthara-Redux/APIs/Tidal"
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
@@ -140,36 +141,14 @@ func LyricsFetcher(Title string, Artist string) (*LyricsAPIResponse, error) {
 
 }
 
-type LyricsResponse struct {
-
-	Embeds []discord.Embed
-	Buttons []discord.InteractiveComponent
-
-}
-
-func BuildLyricsResponse(GuildID snowflake.ID, Locale string) (*LyricsResponse, error) {
-
-	Guild := Structs.GetGuild(GuildID, false)
-
-	if Guild == nil || Guild.Queue.Current == nil {
-
-		return nil, fmt.Errorf("no song playing")
-
-	}
-
-	Song := Guild.Queue.Current
-
-	// Page for web lyrics view
-
-	Page := fmt.Sprintf("%s/Queues/%s?View=Lyrics", os.Getenv("DOMAIN"), GuildID.String())
+// SongLyrics fetches a song's lyrics, trying the title without bracketed parts first like the frontend does
+func SongLyrics(Song *Tidal.Song) (*LyricsAPIResponse, error) {
 
 	// Cleans title similar to frontend
 
 	Regex := regexp.MustCompile(`\s*\(.*?\)`)
 	Cleaned := strings.TrimSpace(Regex.ReplaceAllString(Song.Title, ""))
 
-	// Try cleaned title first, fall back to original title like the frontend
-
 	Artist := ""
 
 	if len(Song.Artists) > 0 {
@@ -194,6 +173,41 @@ func BuildLyricsResponse(GuildID snowflake.ID, Locale string) (*LyricsResponse,
 
 	}
 
+	return APIRespPtr, nil
+
+}
+
+type LyricsResponse struct {
+
+	Embeds []discord.Embed
+	Buttons []discord.InteractiveComponent
+
+}
+
+func BuildLyricsResponse(GuildID snowflake.ID, Locale string) (*LyricsResponse, error) {
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil || Guild.Queue.Current == nil {
+
+		return nil, fmt.Errorf("no song playing")
+
+	}
+
+	Song := Guild.Queue.Current
+
+	// Page for web lyrics view
+
+	Page := fmt.Sprintf("%s/Queues/%s?View=Lyrics", os.Getenv("DOMAIN"), GuildID.String())
+
+	APIRespPtr, Err := SongLyrics(Song)
+
+	if Err != nil {
+
+		return nil, Err
+
+	}
+
 	APIResp := *APIRespPtr
 
 	// Builds plain-text lyrics grouped by song part
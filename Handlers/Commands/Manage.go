@@ -33,6 +33,13 @@ func Manage(Event *events.ApplicationCommandInteractionCreate) {
 
 	}
 
+	if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilitySaveLoad, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Data := Event.SlashCommandInteractionData()
 	Name := Data.String("name")
 	Action := Data.String("action")
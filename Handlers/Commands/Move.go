@@ -34,6 +34,13 @@ func Move(Event *events.ApplicationCommandInteractionCreate) {
 
 	}
 
+	if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilityMove, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Data := Event.SlashCommandInteractionData()
 
 	SongIndex := Data.Int("song")
@@ -154,6 +161,8 @@ func Move(Event *events.ApplicationCommandInteractionCreate) {
 
 		})},
 
+		Components: []discord.LayoutComponent{discord.NewActionRow(Guild.Queue.UndoButton(Locale))},
+
 	})
 
 }
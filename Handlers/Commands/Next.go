@@ -5,6 +5,7 @@ import (
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
 	"fmt"
 
 	"github.com/disgoorg/disgo/discord"
@@ -40,6 +41,13 @@ func Next(Event *events.ApplicationCommandInteractionCreate) {
 
 	}
 
+	if ErrorEmbed := Validation.SkipVoteError(Guild.RequestSkip(Event.User().ID, Event.User().Username), Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Advanced, Ended := Guild.Queue.Next(true)
 
 	if Ended {
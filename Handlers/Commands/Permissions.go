@@ -0,0 +1,209 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+const capabilityDJ = "dj"
+
+func Permissions(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false) // settings can be changed without an active session
+
+	Policy := Structs.GuildSettingsFor(GuildID).Permissions
+
+	Data := Event.SlashCommandInteractionData()
+
+	Action, _ := Data.OptString("action")
+	Capability, HasCapability := Data.OptString("capability")
+	Role, HasRole := Data.OptRole("role")
+
+	if Action == "" || Action == "view" {
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Permissions.Current.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+				Description: describePermissionPolicy(Policy, Locale),
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if !HasCapability || (Action != "reset" && !HasRole) {
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Permissions.Error.MissingOptions.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+				Description: Localizations.Get("Commands.Permissions.Error.MissingOptions.Description", Locale),
+				Color:       Utils.ERROR,
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	// Copy before editing so a failed save leaves the live policy untouched
+
+	Updated := Structs.PermissionPolicy{
+
+		DJRoles: slices.Clone(Policy.DJRoles),
+		Roles:   make(map[string][]string, len(Policy.Roles)),
+
+	}
+
+	for Key, Roles := range Policy.Roles {
+
+		Updated.Roles[Key] = slices.Clone(Roles)
+
+	}
+
+	Current := Updated.Roles[Capability]
+
+	if Capability == capabilityDJ {
+
+		Current = Updated.DJRoles
+
+	}
+
+	switch Action {
+
+	case "grant":
+
+		if !slices.Contains(Current, Role.ID.String()) {
+
+			Current = append(Current, Role.ID.String())
+
+		}
+
+	case "revoke":
+
+		Current = slices.DeleteFunc(Current, func(ID string) bool { return ID == Role.ID.String() })
+
+	case "reset":
+
+		Current = []string{}
+
+	}
+
+	if Capability == capabilityDJ {
+
+		Updated.DJRoles = Current
+
+	} else if len(Current) == 0 {
+
+		delete(Updated.Roles, Capability)
+
+	} else {
+
+		Updated.Roles[Capability] = Current
+
+	}
+
+	var SaveError error
+
+	if Guild != nil {
+
+		SaveError = Guild.SetPermissionPolicy(Updated)
+
+	} else {
+
+		SaveError = Structs.SavePermissionPolicy(GuildID.String(), Updated)
+
+	}
+
+	if SaveError != nil {
+
+		Utils.Logger.Error("Permissions", fmt.Sprintf("Failed to save permission policy for guild %s: %s", GuildID.String(), SaveError.Error()))
+
+		ErrorEmbed := Validation.SettingsSaveError(Locale)
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Permissions.Updated.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+			Description: describePermissionPolicy(Updated, Locale),
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+// describePermissionPolicy lists the DJ roles and the roles allowed to use each capability
+func describePermissionPolicy(Policy Structs.PermissionPolicy, Locale string) string {
+
+	Lines := []string{Localizations.GetFormat("Commands.Permissions.Line", Locale, Localizations.Get("Common.Capabilities.dj", Locale), mentionRoles(Policy.DJRoles, Localizations.Get("Commands.Permissions.NoDJ", Locale)))}
+
+	for _, Capability := range Structs.Capabilities {
+
+		Lines = append(Lines, Localizations.GetFormat("Commands.Permissions.Line", Locale, Validation.CapabilityName(Capability, Locale), mentionRoles(Policy.Roles[Capability], Localizations.Get("Commands.Permissions.Everyone", Locale))))
+
+	}
+
+	return strings.Join(Lines, "\n") + "\n\n" + Localizations.Get("Commands.Permissions.Footer", Locale)
+
+}
+
+func mentionRoles(RoleIDs []string, Fallback string) string {
+
+	if len(RoleIDs) == 0 {
+
+		return Fallback
+
+	}
+
+	Mentions := make([]string, 0, len(RoleIDs))
+
+	for _, RoleID := range RoleIDs {
+
+		Mentions = append(Mentions, fmt.Sprintf("<@&%s>", RoleID))
+
+	}
+
+	return strings.Join(Mentions, ", ")
+
+}
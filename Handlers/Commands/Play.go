@@ -2,10 +2,10 @@ package Commands
 
 import (
 	"Synthara-Redux/APIs"
-	"Synthara-Redux/APIs/Tidal"
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
 	"strconv"
 
 	"github.com/disgoorg/disgo/discord"
@@ -30,6 +30,8 @@ func Play(Event *events.ApplicationCommandInteractionCreate) {
 	Data := Event.SlashCommandInteractionData()
 	Query := Data.String("query")
 
+	Position := insertPosition(Data)
+
 	if Query == "" {
 
 		Utils.WaitFor(DeferDone)
@@ -96,6 +98,19 @@ func Play(Event *events.ApplicationCommandInteractionCreate) {
 
 	}
 
+	if Capability := Position.Capability(); Capability != "" {
+
+		if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Capability, Locale); ErrorEmbed != nil {
+
+			Utils.WaitFor(DeferDone)
+			Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{Embeds: &[]discord.Embed{*ErrorEmbed}})
+
+			return
+
+		}
+
+	}
+
 	ChannelID := VoiceState.ChannelID
 
 	Guild := Structs.GetGuild(GuildID, true) // creates if not found
@@ -150,7 +165,16 @@ func Play(Event *events.ApplicationCommandInteractionCreate) {
 
 	// Handle the URI
 
-	SongFound, Pos, ErrorHandling := Guild.HandleURI(URI, Event.User().Mention())
+	SongFound, Pos, ErrorHandling := Guild.HandleURIAt(URI, Event.User().Mention(), Position)
+
+	if ErrorEmbed := Validation.QuotaError(ErrorHandling, Locale); ErrorEmbed != nil {
+
+		Utils.WaitFor(DeferDone)
+		Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{Embeds: &[]discord.Embed{*ErrorEmbed}})
+
+		return
+
+	}
 
 	if ErrorHandling != nil {
 
@@ -205,22 +229,30 @@ func Play(Event *events.ApplicationCommandInteractionCreate) {
 
 	// Send response with current song info
 
-	State := Tidal.QueueInfo{
+	State := Guild.QueueInfo(true, Pos, Locale) // playing is forced here
+
+	Utils.WaitFor(DeferDone)
+	Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.NewMessageUpdate().AddEmbeds(SongFound.Embed(State)).AddComponents(SongFound.Components(State)...))
 
-		Playing: true, // Forced here
+}
 
-		GuildID: GuildID,
+// insertPosition reads the position and index options shared by /play, /album and /artist
+func insertPosition(Data discord.SlashCommandInteractionData) Structs.InsertPosition {
 
-		SongPosition: Pos,
+	Position := Structs.PositionEnd
 
-		TotalPrevious: len(Guild.Queue.Previous),
-		TotalUpcoming: len(Guild.Queue.Upcoming),
+	if Value, HasPosition := Data.OptString("position"); HasPosition {
 
-		Locale: Locale,
+		Position = Structs.ParsePosition(Value)
 
 	}
 
-	Utils.WaitFor(DeferDone)
-	Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.NewMessageUpdate().AddEmbeds(SongFound.Embed(State)).AddActionRow(SongFound.Buttons(State)...))
+	if Index, HasIndex := Data.OptInt("index"); HasIndex {
+
+		Position = Structs.InsertPosition{Mode: Structs.InsertIndex, Index: Index - 1}
+
+	}
+
+	return Position
 
-}
\ No newline at end of file
+}
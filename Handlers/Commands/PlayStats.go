@@ -0,0 +1,333 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// ListeningTime formats seconds of listening as hours or minutes
+func ListeningTime(Seconds int, Locale string) string {
+
+	if Seconds >= 3600 {
+
+		return Localizations.GetFormat("Common.HoursCount", Locale, Seconds/3600)
+
+	}
+
+	return Localizations.GetFormat("Common.MinutesCount", Locale, Seconds/60)
+
+}
+
+func statsPlays(Plays int, Locale string) string {
+
+	return fmt.Sprintf("%d %s", Plays, Localizations.Pluralize("Play", Plays, Locale))
+
+}
+
+// statsTopList renders a numbered top list, one entry per line
+func statsTopList(Entries []Structs.PlayStatsEntry, Label func(Structs.PlayStatsEntry) string, Locale string) string {
+
+	var Body strings.Builder
+
+	for Index, Entry := range Entries {
+
+		Body.WriteString(fmt.Sprintf("%d. %s • %s\n", Index+1, Label(Entry), statsPlays(Entry.Plays, Locale)))
+
+	}
+
+	return Body.String()
+
+}
+
+func statsSongLabel(Entry Structs.PlayStatsEntry) string {
+
+	if Entry.Detail == "" {
+
+		return fmt.Sprintf("**%s**", Entry.Name)
+
+	}
+
+	return fmt.Sprintf("**%s** - %s", Entry.Name, Entry.Detail)
+
+}
+
+func statsNameLabel(Entry Structs.PlayStatsEntry) string {
+
+	return fmt.Sprintf("**%s**", Entry.Name)
+
+}
+
+func statsRequestorLabel(Entry Structs.PlayStatsEntry) string {
+
+	return fmt.Sprintf("<@%s>", Entry.ID)
+
+}
+
+func statsHour(Hour int) string {
+
+	return fmt.Sprintf("`%02d:00` UTC", Hour)
+
+}
+
+// BuildPlayStatsEmbed renders aggregated play log stats; requestors are only listed for server-wide stats
+func BuildPlayStatsEmbed(Stats *Structs.PlayStats, Title string, Description string, ShowRequestors bool, Locale string) discord.Embed {
+
+	EmbedBuilder := discord.NewEmbedBuilder()
+	EmbedBuilder.SetTitle(Title)
+	EmbedBuilder.SetColor(Utils.PRIMARY)
+
+	if Stats.Plays == 0 {
+
+		EmbedBuilder.SetDescription(Description + "\n\n" + Localizations.Get("Commands.Stats.NoPlays", Locale))
+
+		return EmbedBuilder
+
+	}
+
+	EmbedBuilder.SetDescription(Description)
+
+	EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Fields.ListeningTime", Locale), ListeningTime(Stats.ListeningSeconds, Locale), true)
+	EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Fields.Plays", Locale), Localizations.GetFormat("Commands.Stats.PlaysSummary", Locale, Stats.Plays, Stats.Skips, Stats.UniqueSongs), true)
+
+	if len(Stats.TopSongs) > 0 {
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Fields.TopSongs", Locale), statsTopList(Stats.TopSongs, statsSongLabel, Locale), false)
+
+	}
+
+	if len(Stats.TopArtists) > 0 {
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Fields.TopArtists", Locale), statsTopList(Stats.TopArtists, statsNameLabel, Locale), true)
+
+	}
+
+	if len(Stats.TopAlbums) > 0 {
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Fields.TopAlbums", Locale), statsTopList(Stats.TopAlbums, statsSongLabel, Locale), true)
+
+	}
+
+	if ShowRequestors && len(Stats.TopRequestors) > 0 {
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Fields.TopRequestors", Locale), statsTopList(Stats.TopRequestors, statsRequestorLabel, Locale), false)
+
+	}
+
+	if Peaks := Stats.PeakHours(3); len(Peaks) > 0 {
+
+		Lines := make([]string, 0, len(Peaks))
+
+		for _, Hour := range Peaks {
+
+			Lines = append(Lines, fmt.Sprintf("%s • %s", statsHour(Hour), statsPlays(Stats.BusiestHours[Hour], Locale)))
+
+		}
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Fields.BusiestHours", Locale), strings.Join(Lines, "\n"), false)
+
+	}
+
+	return EmbedBuilder
+
+}
+
+// BuildWrappedEmbed renders a guild's yearly recap
+func BuildWrappedEmbed(Stats *Structs.PlayStats, Year int, Locale string) discord.Embed {
+
+	EmbedBuilder := discord.NewEmbedBuilder()
+	EmbedBuilder.SetTitle(Localizations.GetFormat("Commands.Stats.Wrapped.Title", Locale, Year))
+	EmbedBuilder.SetColor(Utils.PRIMARY)
+
+	if Stats.Plays == 0 {
+
+		EmbedBuilder.SetDescription(Localizations.GetFormat("Commands.Stats.Wrapped.Empty", Locale, Year))
+
+		return EmbedBuilder
+
+	}
+
+	EmbedBuilder.SetDescription(Localizations.GetFormat("Commands.Stats.Wrapped.Description", Locale, Year, ListeningTime(Stats.ListeningSeconds, Locale), Stats.Plays, Stats.UniqueSongs))
+
+	if len(Stats.TopSongs) > 0 {
+
+		Top := Stats.TopSongs[0]
+
+		if Top.Cover != "" {
+
+			EmbedBuilder.SetThumbnail(Top.Cover)
+
+		}
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Wrapped.TopSong", Locale), fmt.Sprintf("%s\n-# %s", statsSongLabel(Top), statsPlays(Top.Plays, Locale)), true)
+
+	}
+
+	if len(Stats.TopArtists) > 0 {
+
+		Top := Stats.TopArtists[0]
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Wrapped.TopArtist", Locale), fmt.Sprintf("%s\n-# %s", statsNameLabel(Top), statsPlays(Top.Plays, Locale)), true)
+
+	}
+
+	if len(Stats.TopAlbums) > 0 {
+
+		Top := Stats.TopAlbums[0]
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Wrapped.TopAlbum", Locale), fmt.Sprintf("%s\n-# %s", statsSongLabel(Top), statsPlays(Top.Plays, Locale)), true)
+
+	}
+
+	if len(Stats.TopRequestors) > 0 {
+
+		Top := Stats.TopRequestors[0]
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Wrapped.TopListener", Locale), fmt.Sprintf("%s\n-# %s", statsRequestorLabel(Top), statsPlays(Top.Plays, Locale)), true)
+
+	}
+
+	if Peaks := Stats.PeakHours(1); len(Peaks) > 0 {
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Wrapped.PeakHour", Locale), statsHour(Peaks[0]), true)
+
+	}
+
+	if len(Stats.TopSongs) > 1 {
+
+		EmbedBuilder.AddField(Localizations.Get("Commands.Stats.Fields.TopSongs", Locale), statsTopList(Stats.TopSongs, statsSongLabel, Locale), false)
+
+	}
+
+	return EmbedBuilder
+
+}
+
+// respondPlayStats defers, aggregates the play log and replies with the rendered embed
+func respondPlayStats(Event *events.ApplicationCommandInteractionCreate, Query Structs.PlayStatsQuery, Render func(*Structs.PlayStats) discord.Embed) {
+
+	Locale := Event.Locale().Code()
+
+	DeferDone := make(chan struct{})
+
+	go func() {
+
+		Event.DeferCreateMessage(false)
+		close(DeferDone)
+
+	}()
+
+	Stats, ErrorAggregating := Structs.CachedPlayStats(Query)
+
+	Utils.WaitFor(DeferDone)
+
+	if ErrorAggregating != nil {
+
+		Utils.Logger.Error("Stats", fmt.Sprintf("Error aggregating play log for guild %s: %s", Query.GuildID, ErrorAggregating.Error()))
+
+		Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{
+
+			Embeds: &[]discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Stats.Error.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+				Description: Localizations.Get("Commands.Stats.Error.Description", Locale),
+				Color:       Utils.ERROR,
+
+			})},
+
+		})
+
+		return
+
+	}
+
+	Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{
+
+		Embeds: &[]discord.Embed{Render(Stats)},
+
+	})
+
+}
+
+// statsPeriodQuery reads the period option into a query for the current guild
+func statsPeriodQuery(Event *events.ApplicationCommandInteractionCreate) (Structs.PlayStatsQuery, string) {
+
+	Period := Event.SlashCommandInteractionData().String("period")
+
+	Since, ErrorParsing := Structs.PeriodStart(Period)
+
+	if ErrorParsing != nil || Period == "" {
+
+		Period = Structs.StatsPeriodMonth
+		Since, _ = Structs.PeriodStart(Period)
+
+	}
+
+	return Structs.PlayStatsQuery{GuildID: Event.GuildID().String(), Since: Since}, Period
+
+}
+
+func statsPeriodLabel(Period string, Locale string) string {
+
+	return Localizations.Get(fmt.Sprintf("Commands.Stats.Periods.%s", strings.ToUpper(Period[:1])+Period[1:]), Locale)
+
+}
+
+func ServerStats(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Query, Period := statsPeriodQuery(Event)
+
+	respondPlayStats(Event, Query, func(Stats *Structs.PlayStats) discord.Embed {
+
+		return BuildPlayStatsEmbed(Stats, Localizations.Get("Commands.Stats.Server.Title", Locale), statsPeriodLabel(Period, Locale), true, Locale)
+
+	})
+
+}
+
+func UserStats(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Query, Period := statsPeriodQuery(Event)
+	Query.UserID = Event.User().ID.String()
+
+	Description := statsPeriodLabel(Period, Locale) + "\n" + Localizations.Get("Commands.Stats.Me.Description", Locale)
+
+	respondPlayStats(Event, Query, func(Stats *Structs.PlayStats) discord.Embed {
+
+		return BuildPlayStatsEmbed(Stats, Localizations.Get("Commands.Stats.Me.Title", Locale), Description, false, Locale)
+
+	})
+
+}
+
+// Wrapped posts a yearly recap of the guild's listening to the channel
+func Wrapped(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Year := time.Now().UTC().Year()
+
+	if Requested, HasYear := Event.SlashCommandInteractionData().OptInt("year"); HasYear {
+
+		Year = Requested
+
+	}
+
+	respondPlayStats(Event, Structs.WrappedQuery(Event.GuildID().String(), Year), func(Stats *Structs.PlayStats) discord.Embed {
+
+		return BuildWrappedEmbed(Stats, Year, Locale)
+
+	})
+
+}
@@ -0,0 +1,780 @@
+package Commands
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+type PlaylistResponse struct {
+	Embeds     []discord.Embed
+	Components []discord.LayoutComponent
+}
+
+func playlistMessage(Embed discord.Embed) discord.MessageCreate {
+
+	return discord.MessageCreate{Embeds: []discord.Embed{Embed}, Flags: discord.MessageFlagEphemeral}
+
+}
+
+func playlistErrorEmbed(Key string, Locale string, Arguments ...any) discord.Embed {
+
+	return Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.Error."+Key+".Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+		Description: Localizations.GetFormat("Commands.Playlist.Error."+Key+".Description", Locale, Arguments...),
+		Color:       Utils.ERROR,
+
+	})
+
+}
+
+// BuildPlaylistError maps playlist errors to an embed; unknown errors are logged and reported as a storage failure
+func BuildPlaylistError(Error error, Locale string) discord.Embed {
+
+	switch {
+
+	case errors.Is(Error, Structs.ErrSavedQueueNameEmpty), errors.Is(Error, Structs.ErrSavedQueueNameTooLong):
+
+		return buildSavedQueueNameError(Locale, Error)
+
+	case errors.Is(Error, Structs.ErrPlaylistNotFound):
+
+		return playlistErrorEmbed("NotFound", Locale)
+
+	case errors.Is(Error, Structs.ErrPlaylistExists):
+
+		return playlistErrorEmbed("Exists", Locale)
+
+	case errors.Is(Error, Structs.ErrPlaylistLimit):
+
+		return playlistErrorEmbed("Limit", Locale, Structs.MaxPlaylistsPerUser)
+
+	case errors.Is(Error, Structs.ErrPlaylistFull):
+
+		return playlistErrorEmbed("Full", Locale, Structs.MaxPlaylistSongs)
+
+	case errors.Is(Error, Structs.ErrPlaylistEmpty):
+
+		return playlistErrorEmbed("Empty", Locale)
+
+	case errors.Is(Error, Structs.ErrPlaylistPosition):
+
+		return playlistErrorEmbed("Position", Locale)
+
+	case errors.Is(Error, Structs.ErrShareCodeNotFound):
+
+		return playlistErrorEmbed("ShareCode", Locale)
+
+	default:
+
+		Utils.Logger.Error("Playlists", fmt.Sprintf("Error updating playlist: %s", Error.Error()))
+		return playlistErrorEmbed("Persist", Locale)
+
+	}
+
+}
+
+// BuildPlaylistResponse renders one page of a playlist with paging and append controls
+func BuildPlaylistResponse(Playlist *Structs.UserPlaylist, Page int, Locale string) PlaylistResponse {
+
+	Pages := max((len(Playlist.Songs)+Structs.PlaylistPageSize-1)/Structs.PlaylistPageSize, 1)
+	Page = min(max(Page, 0), Pages-1)
+
+	Start := Page * Structs.PlaylistPageSize
+	End := min(Start+Structs.PlaylistPageSize, len(Playlist.Songs))
+
+	var Body strings.Builder
+
+	Body.WriteString(Localizations.GetFormat("Commands.Playlist.View.Stats", Locale, len(Playlist.Songs), Localizations.Pluralize("Song", len(Playlist.Songs), Locale), Page+1, Pages))
+
+	if Playlist.ShareCode != "" {
+
+		Body.WriteString("\n")
+		Body.WriteString(Localizations.GetFormat("Commands.Playlist.View.Shared", Locale, Playlist.ShareCode))
+
+	}
+
+	Body.WriteString("\n\n")
+
+	if len(Playlist.Songs) == 0 {
+
+		Body.WriteString(Localizations.Get("Commands.Playlist.View.Empty", Locale))
+
+	}
+
+	for Index, Song := range Playlist.Songs[Start:End] {
+
+		Body.WriteString(fmt.Sprintf("%d. **%s** • %s\n", Start+Index+1, Song.Title, strings.Join(Song.Artists, ", ")))
+
+	}
+
+	Key := fmt.Sprintf("%s:%s", Playlist.OwnerID, Playlist.ID)
+
+	PreviousButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Common.Previous", Locale), fmt.Sprintf("PlaylistPage:%s:%d", Key, Page-1), "", 0).WithDisabled(Page == 0)
+	NextButton := discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Common.Next", Locale), fmt.Sprintf("PlaylistPage:%s:%d", Key, Page+1), "", 0).WithDisabled(Page+1 >= Pages)
+	AppendButton := discord.NewButton(discord.ButtonStylePrimary, Localizations.Get("Commands.Playlist.View.Append", Locale), fmt.Sprintf("PlaylistAppend:%s", Key), "", 0).WithDisabled(len(Playlist.Songs) == 0)
+
+	return PlaylistResponse{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Playlist.Name,
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Body.String(),
+
+		})},
+
+		Components: []discord.LayoutComponent{discord.NewActionRow(PreviousButton, NextButton, AppendButton)},
+
+	}
+
+}
+
+// AppendPlaylist adds one of a user's playlists to the guild's queue; the owner must be in the voice channel
+func AppendPlaylist(GuildID snowflake.ID, UserID snowflake.ID, Playlist *Structs.UserPlaylist, Locale string) discord.MessageCreate {
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		return discord.MessageCreate{Embeds: []discord.Embed{Validation.GuildSessionError(Locale)}, Flags: discord.MessageFlagEphemeral}
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, UserID, Locale); ErrorEmbed != nil {
+
+		return discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral}
+
+	}
+
+	Added, Skipped, Error := Guild.AppendPlaylist(Playlist, fmt.Sprintf("<@%s>", UserID))
+
+	if Error != nil {
+
+		return playlistMessage(BuildPlaylistError(Error, Locale))
+
+	}
+
+	return discord.NewMessageCreate().
+		AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Playlist.Append.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Skipped.AppendTo(Localizations.GetFormat("Commands.Playlist.Append.Description", Locale, Added, Localizations.Pluralize("Song", Added, Locale), Playlist.Name), Locale),
+
+		})).
+		AddActionRow(Guild.Queue.UndoButton(Locale))
+
+}
+
+func Playlist(Event *events.ApplicationCommandInteractionCreate) {
+
+	Data := Event.SlashCommandInteractionData()
+
+	Subcommand := "list"
+
+	if Data.SubCommandName != nil {
+
+		Subcommand = *Data.SubCommandName
+
+	}
+
+	switch Subcommand {
+
+	case "view":
+
+		playlistView(Event)
+
+	case "create":
+
+		playlistCreate(Event)
+
+	case "rename":
+
+		playlistRename(Event)
+
+	case "delete":
+
+		playlistDelete(Event)
+
+	case "add":
+
+		playlistAdd(Event)
+
+	case "remove":
+
+		playlistRemove(Event)
+
+	case "move":
+
+		playlistMove(Event)
+
+	case "load":
+
+		playlistLoad(Event)
+
+	case "append":
+
+		playlistAppend(Event)
+
+	case "share":
+
+		playlistShare(Event)
+
+	case "import":
+
+		playlistImport(Event)
+
+	default:
+
+		playlistList(Event)
+
+	}
+
+}
+
+func playlistList(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Playlists, Error := Structs.ListPlaylists(Event.User().ID.String())
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	var Body strings.Builder
+
+	Body.WriteString(Localizations.GetFormat("Commands.Playlist.List.Stats", Locale, len(Playlists), Structs.MaxPlaylistsPerUser))
+	Body.WriteString("\n\n")
+
+	if len(Playlists) == 0 {
+
+		Body.WriteString(Localizations.Get("Commands.Playlist.List.Empty", Locale))
+
+	}
+
+	for _, Playlist := range Playlists {
+
+		Body.WriteString(fmt.Sprintf("**%s** • %d %s", Playlist.Name, len(Playlist.Songs), Localizations.Pluralize("Song", len(Playlist.Songs), Locale)))
+
+		if Playlist.ShareCode != "" {
+
+			Body.WriteString(fmt.Sprintf(" • `%s`", Playlist.ShareCode))
+
+		}
+
+		Body.WriteString("\n")
+
+	}
+
+	Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.List.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+		Description: Body.String(),
+
+	})))
+
+}
+
+func playlistView(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	Playlist, Error := Structs.GetPlaylist(Event.User().ID.String(), Data.String("name"))
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Response := BuildPlaylistResponse(Playlist, Data.Int("page")-1, Locale)
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds:     Response.Embeds,
+		Components: Response.Components,
+		Flags:      discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+// queueSongs returns the current and upcoming songs of the guild's session, used to seed a playlist
+func queueSongs(GuildID snowflake.ID) []Tidal.Song {
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		return nil
+
+	}
+
+	Songs := []Tidal.Song{}
+
+	if Guild.Queue.Current != nil {
+
+		Songs = append(Songs, *Guild.Queue.Current)
+
+	}
+
+	for _, Song := range Guild.Queue.Upcoming {
+
+		Songs = append(Songs, *Song)
+
+	}
+
+	return Songs
+
+}
+
+func playlistCreate(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	Songs := []Tidal.Song{}
+
+	if Data.Bool("fromqueue") {
+
+		Songs = queueSongs(*Event.GuildID())
+
+		if len(Songs) == 0 {
+
+			Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Save.Error.EmptyQueue.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+				Description: Localizations.Get("Commands.Save.Error.EmptyQueue.Description", Locale),
+				Color:       Utils.ERROR,
+
+			})))
+
+			return
+
+		}
+
+	}
+
+	Playlist, Error := Structs.CreatePlaylist(Event.User().ID.String(), Data.String("name"), Songs)
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.Create.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+		Description: Localizations.GetFormat("Commands.Playlist.Create.Description", Locale, Playlist.Name, len(Playlist.Songs), Localizations.Pluralize("Song", len(Playlist.Songs), Locale)),
+
+	})))
+
+}
+
+func playlistRename(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	Playlist, Error := Structs.RenamePlaylist(Event.User().ID.String(), Data.String("name"), Data.String("newname"))
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.Rename.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+		Description: Localizations.GetFormat("Commands.Playlist.Rename.Description", Locale, Playlist.Name),
+
+	})))
+
+}
+
+func playlistDelete(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Name := strings.TrimSpace(Event.SlashCommandInteractionData().String("name"))
+
+	if Error := Structs.DeletePlaylist(Event.User().ID.String(), Name); Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.Delete.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+		Description: Localizations.GetFormat("Commands.Playlist.Delete.Description", Locale, Name),
+
+	})))
+
+}
+
+// resolvePlaylistSong resolves the song option of /playlist add: a Tidal ID from autocomplete, a free search, or the current song when empty
+func resolvePlaylistSong(GuildID snowflake.ID, Query string) (*Tidal.Song, bool) {
+
+	Query = strings.TrimSpace(Query)
+
+	if Query == "" || Query == "current" {
+
+		if Guild := Structs.GetGuild(GuildID, false); Guild != nil && Guild.Queue.Current != nil {
+
+			return Guild.Queue.Current, true
+
+		}
+
+		return nil, false
+
+	}
+
+	if TidalID, ErrorParsing := strconv.ParseInt(Query, 10, 64); ErrorParsing == nil {
+
+		if Guild := Structs.GetGuild(GuildID, false); Guild != nil {
+
+			if Song := Guild.Queue.FindSong(TidalID); Song != nil {
+
+				return Song, true
+
+			}
+
+		}
+
+		if Song, ErrorFetching := Tidal.GetSong(TidalID); ErrorFetching == nil {
+
+			return &Song, true
+
+		}
+
+	}
+
+	Songs, ErrorSearching := Tidal.SearchSongs(Query)
+
+	if ErrorSearching != nil || len(Songs) == 0 {
+
+		return nil, false
+
+	}
+
+	return &Songs[0], true
+
+}
+
+func playlistAdd(Event *events.ApplicationCommandInteractionCreate) {
+
+	DeferDone := make(chan struct{})
+
+	go func() {
+
+		Event.DeferCreateMessage(true)
+		close(DeferDone)
+
+	}()
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	Respond := func(Embed discord.Embed) {
+
+		Utils.WaitFor(DeferDone)
+		Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{Embeds: &[]discord.Embed{Embed}})
+
+	}
+
+	Playlist, Error := Structs.GetPlaylist(Event.User().ID.String(), Data.String("name"))
+
+	if Error != nil {
+
+		Respond(BuildPlaylistError(Error, Locale))
+		return
+
+	}
+
+	Song, Found := resolvePlaylistSong(*Event.GuildID(), Data.String("song"))
+
+	if !Found {
+
+		Respond(playlistErrorEmbed("SongNotFound", Locale))
+		return
+
+	}
+
+	Added, Error := Playlist.AddSongs([]Tidal.Song{*Song})
+
+	if Error != nil {
+
+		Respond(BuildPlaylistError(Error, Locale))
+		return
+
+	}
+
+	Key := "Commands.Playlist.Add"
+
+	if Added == 0 {
+
+		Key = "Commands.Playlist.Add.Duplicate"
+
+	}
+
+	Respond(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get(Key+".Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+		Description: Localizations.GetFormat(Key+".Description", Locale, Song.Title, Playlist.Name),
+
+	}))
+
+}
+
+func playlistRemove(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	Playlist, Error := Structs.GetPlaylist(Event.User().ID.String(), Data.String("name"))
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Removed, Error := Playlist.RemoveSong(Data.Int("position") - 1)
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.Remove.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+		Description: Localizations.GetFormat("Commands.Playlist.Remove.Description", Locale, Removed.Title, Playlist.Name),
+
+	})))
+
+}
+
+func playlistMove(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	Playlist, Error := Structs.GetPlaylist(Event.User().ID.String(), Data.String("name"))
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	From := Data.Int("from")
+	To := Data.Int("to")
+
+	if Error := Playlist.MoveSong(From-1, To-1); Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.Move.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+		Description: Localizations.GetFormat("Commands.Playlist.Move.Description", Locale, Playlist.Songs[To-1].Title, To),
+
+	})))
+
+}
+
+func playlistLoad(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		ErrorEmbed := Validation.GuildSessionError(Locale)
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, Event.User().ID, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilitySaveLoad, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Playlist, Error := Structs.GetPlaylist(Event.User().ID.String(), Event.SlashCommandInteractionData().String("name"))
+
+	if Error == nil {
+
+		Error = Guild.LoadPlaylist(Playlist, fmt.Sprintf("<@%s>", Event.User().ID))
+
+	}
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Load.Success.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Localizations.GetFormat("Commands.Load.Success.Description", Locale, Playlist.Name, len(Playlist.Songs), Localizations.Pluralize("Song", len(Playlist.Songs), Locale)),
+			Color:       Utils.PRIMARY,
+
+		})},
+
+		Components: []discord.LayoutComponent{discord.NewActionRow(Guild.Queue.UndoButton(Locale))},
+
+	})
+
+}
+
+func playlistAppend(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Playlist, Error := Structs.GetPlaylist(Event.User().ID.String(), Event.SlashCommandInteractionData().String("name"))
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(AppendPlaylist(*Event.GuildID(), Event.User().ID, Playlist, Locale))
+
+}
+
+func playlistShare(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	Playlist, Error := Structs.GetPlaylist(Event.User().ID.String(), Data.String("name"))
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	if Data.Bool("revoke") {
+
+		if Error := Playlist.Unshare(); Error != nil {
+
+			Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+			return
+
+		}
+
+		Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Playlist.Share.Revoked.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+			Description: Localizations.GetFormat("Commands.Playlist.Share.Revoked.Description", Locale, Playlist.Name),
+
+		})))
+
+		return
+
+	}
+
+	Code, Error := Playlist.Share()
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.Share.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+		Description: Localizations.GetFormat("Commands.Playlist.Share.Description", Locale, Playlist.Name, Code, Code),
+
+	})))
+
+}
+
+func playlistImport(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	Playlist, Error := Structs.ImportPlaylist(Event.User().ID.String(), Data.String("code"), Data.String("name"))
+
+	if Error != nil {
+
+		Event.CreateMessage(playlistMessage(BuildPlaylistError(Error, Locale)))
+		return
+
+	}
+
+	Event.CreateMessage(playlistMessage(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Playlist.Import.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+		Description: Localizations.GetFormat("Commands.Playlist.Import.Description", Locale, Playlist.Name, len(Playlist.Songs), Localizations.Pluralize("Song", len(Playlist.Songs), Locale)),
+
+	})))
+
+}
@@ -0,0 +1,146 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+	"strconv"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func Quotas(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false) // settings can be changed without an active session
+
+	Policy := Structs.GuildSettingsFor(GuildID).Quotas
+
+	Data := Event.SlashCommandInteractionData()
+
+	MaxPerUser, HasMaxPerUser := Data.OptInt("max_per_user")
+	MaxMinutes, HasMaxMinutes := Data.OptInt("max_minutes")
+	MaxQueue, HasMaxQueue := Data.OptInt("max_queue")
+	Duplicates, HasDuplicates := Data.OptString("duplicates")
+	Window, HasWindow := Data.OptInt("duplicate_window")
+
+	Changed := HasMaxPerUser || HasMaxMinutes || HasMaxQueue || HasDuplicates || HasWindow
+
+	Title := Localizations.Get("Commands.Quotas.Current.Title", Locale)
+
+	if Changed {
+
+		if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if HasMaxPerUser {
+
+			Policy.MaxSongsPerUser = MaxPerUser
+
+		}
+
+		if HasMaxMinutes {
+
+			Policy.MaxTrackMinutes = MaxMinutes
+
+		}
+
+		if HasMaxQueue {
+
+			Policy.MaxQueueLength = MaxQueue
+
+		}
+
+		if HasDuplicates {
+
+			Policy.Duplicates = Duplicates
+
+		}
+
+		if HasWindow {
+
+			Policy.DuplicateWindow = Window
+
+		}
+
+		var SaveError error
+
+		if Guild != nil {
+
+			SaveError = Guild.SetQuotaPolicy(Policy)
+			Policy = Guild.Settings.Quotas
+
+		} else {
+
+			Policy, SaveError = Structs.SaveQuotaPolicy(GuildID.String(), Policy)
+
+		}
+
+		if SaveError != nil {
+
+			Utils.Logger.Error("Quotas", fmt.Sprintf("Failed to save quota policy for guild %s: %s", GuildID.String(), SaveError.Error()))
+
+			ErrorEmbed := Validation.SettingsSaveError(Locale)
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		Title = Localizations.Get("Commands.Quotas.Updated.Title", Locale)
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Title,
+			Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+			Description: describeQuotaPolicy(Policy, Locale),
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+// describeQuotaPolicy lists each queue limit on its own line
+func describeQuotaPolicy(Policy Structs.QuotaPolicy, Locale string) string {
+
+	Duplicates := Localizations.Get("Commands.Quotas.Duplicates."+Policy.Duplicates, Locale)
+
+	if Policy.Duplicates == Structs.DuplicatesWindow {
+
+		Duplicates = Localizations.GetFormat("Commands.Quotas.Duplicates.window", Locale, Policy.DuplicateWindow)
+
+	}
+
+	return Localizations.GetFormat("Commands.Quotas.MaxPerUser", Locale, quotaLimit(Policy.MaxSongsPerUser, Locale)) + "\n" +
+		Localizations.GetFormat("Commands.Quotas.MaxMinutes", Locale, quotaLimit(Policy.MaxTrackMinutes, Locale)) + "\n" +
+		Localizations.GetFormat("Commands.Quotas.MaxQueue", Locale, quotaLimit(Policy.MaxQueueLength, Locale)) + "\n" +
+		Localizations.GetFormat("Commands.Quotas.DuplicatePolicy", Locale, Duplicates)
+
+}
+
+func quotaLimit(Limit int, Locale string) string {
+
+	if Limit <= 0 {
+
+		return Localizations.Get("Commands.Quotas.Unlimited", Locale)
+
+	}
+
+	return strconv.Itoa(Limit)
+
+}
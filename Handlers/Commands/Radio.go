@@ -0,0 +1,158 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func Radio(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		ErrorEmbed := Validation.GuildSessionError(Locale)
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, Event.User().ID, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	DeferDone := make(chan struct{})
+
+	go func() {
+
+		Event.DeferCreateMessage(false)
+		close(DeferDone)
+
+	}()
+
+	Data := Event.SlashCommandInteractionData()
+
+	var Seed *Structs.RadioSeed
+
+	Kind, Query := Structs.RadioSeedArtist, Data.String("artist")
+
+	if SongQuery, HasSong := Data.OptString("song"); HasSong {
+
+		Kind, Query = Structs.RadioSeedSong, SongQuery
+
+	}
+
+	if strings.TrimSpace(Query) != "" {
+
+		Resolved, ErrorResolving := Structs.ResolveRadioSeed(Kind, Query)
+
+		if ErrorResolving != nil {
+
+			Utils.WaitFor(DeferDone)
+			Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{
+
+				Embeds: &[]discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+					Title:       Localizations.Get("Commands.Radio.NotFound.Title", Locale),
+					Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+					Description: Localizations.GetFormat("Commands.Radio.NotFound.Description", Locale, Query),
+					Color:       Utils.ERROR,
+
+				})},
+
+			})
+
+			return
+
+		}
+
+		Seed = Resolved
+
+	}
+
+	// A new seed replaces whatever the radio had lined up
+
+	Guild.Queue.Radio.SetSeed(Seed)
+	Guild.Queue.Suggestions = nil
+
+	Guild.Features.Autoplay = true
+	Guild.Queue.RegenerateSuggestions()
+
+	Guild.StartInactivityTimer()
+
+	if len(Guild.Queue.Suggestions) == 0 {
+
+		Utils.WaitFor(DeferDone)
+		Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{
+
+			Embeds: &[]discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Radio.Empty.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+				Description: Localizations.Get("Commands.Radio.Empty.Description", Locale),
+				Color:       Utils.ERROR,
+
+			})},
+
+		})
+
+		return
+
+	}
+
+	if Guild.Queue.Current == nil && len(Guild.Queue.Upcoming) == 0 {
+
+		Guild.Queue.Next(true)
+
+	} else {
+
+		Guild.Queue.Functions.Updated(&Guild.Queue)
+
+	}
+
+	Description := Localizations.Get("Commands.Radio.Session", Locale)
+
+	if Seed != nil {
+
+		Description = Localizations.GetFormat("Commands.Radio.Seeded", Locale, Seed.Title)
+
+	}
+
+	var Body strings.Builder
+
+	Body.WriteString(Description + "\n")
+
+	for Index, Song := range Guild.Queue.Suggestions {
+
+		Body.WriteString(fmt.Sprintf("\n%d. **%s** • %s", Index+1, Song.Title, strings.Join(Song.Artists, ", ")))
+
+	}
+
+	Utils.WaitFor(DeferDone)
+	Event.Client().Rest.UpdateInteractionResponse(Event.ApplicationID(), Event.Token(), discord.MessageUpdate{
+
+		Embeds: &[]discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Radio.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Body.String(),
+			Color:       Utils.PRIMARY,
+
+		})},
+
+	})
+
+}
@@ -93,6 +93,8 @@ func Replay(Event *events.ApplicationCommandInteractionCreate) {
 
 		})},
 
+		Components: []discord.LayoutComponent{discord.NewActionRow(Guild.Queue.UndoButton(Locale))},
+
 	})
 
 }
@@ -0,0 +1,518 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func Schedule(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	Data := Event.SlashCommandInteractionData()
+
+	if Data.SubCommandName == nil {
+
+		return
+
+	}
+
+	if *Data.SubCommandName == "list" {
+
+		scheduleList(Event, Locale)
+		return
+
+	}
+
+	if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	switch *Data.SubCommandName {
+
+		case "create":
+
+			scheduleCreate(Event, Locale)
+
+		case "edit":
+
+			scheduleEdit(Event, Locale)
+
+		case "cancel":
+
+			scheduleCancel(Event, Locale)
+
+	}
+
+}
+
+// describeSchedule renders one job as a few lines for lists and confirmations
+func describeSchedule(Job Structs.ScheduledJob, Locale string) string {
+
+	Lines := []string{
+
+		Localizations.GetFormat("Commands.Schedule.Entry", Locale, Job.ID, Job.SourceName, Job.VoiceChannelID),
+		Localizations.GetFormat("Commands.Schedule.Next", Locale, Job.NextRun.Unix()),
+
+	}
+
+	Timezone := Job.Timezone
+
+	if Timezone == "" {
+
+		Timezone = "UTC"
+
+	}
+
+	if Job.Recurring() {
+
+		Lines = append(Lines, Localizations.GetFormat("Commands.Schedule.Repeats", Locale, Job.Cron, Timezone))
+
+	} else {
+
+		Lines = append(Lines, Localizations.Get("Commands.Schedule.Once", Locale))
+
+	}
+
+	Settings := []string{}
+
+	if Job.Volume != nil {
+
+		Settings = append(Settings, Localizations.GetFormat("Commands.Schedule.Volume", Locale, *Job.Volume))
+
+	}
+
+	if Job.SpeedMilli != nil {
+
+		Settings = append(Settings, Localizations.GetFormat("Commands.Schedule.Speed", Locale, Structs.FormatSpeedLabel(*Job.SpeedMilli)))
+
+	}
+
+	if Job.Reverb != nil {
+
+		Settings = append(Settings, Localizations.GetFormat("Commands.Schedule.Reverb", Locale, *Job.Reverb))
+
+	}
+
+	if len(Settings) > 0 {
+
+		Lines = append(Lines, strings.Join(Settings, " • "))
+
+	}
+
+	if Job.LastError != "" {
+
+		Lines = append(Lines, Localizations.GetFormat("Commands.Schedule.LastError", Locale, Utils.Truncate(Job.LastError, 100)))
+
+	}
+
+	return strings.Join(Lines, "\n")
+
+}
+
+func scheduleErrorEmbed(Error error, Locale string) discord.Embed {
+
+	Key := "Persist"
+	Arguments := []any{}
+
+	switch {
+
+		case errors.Is(Error, Structs.ErrScheduleLimit):
+
+			Key = "Limit"
+			Arguments = append(Arguments, Structs.MaxSchedulesPerGuild)
+
+		case errors.Is(Error, Structs.ErrScheduleNotFound):
+
+			Key = "NotFound"
+
+		case errors.Is(Error, Structs.ErrScheduleTime):
+
+			Key = "Time"
+			Arguments = append(Arguments, Structs.ScheduleTimeLayout)
+
+		case errors.Is(Error, Structs.ErrSchedulePast):
+
+			Key = "Past"
+
+		case errors.Is(Error, Structs.ErrScheduleTimezone):
+
+			Key = "Timezone"
+
+		case errors.Is(Error, Structs.ErrScheduleFrequent):
+
+			Key = "Frequent"
+
+		case errors.Is(Error, Structs.ErrScheduleNoTrigger):
+
+			Key = "NoTrigger"
+
+		case errors.Is(Error, Structs.ErrCronSyntax):
+
+			Key = "Cron"
+
+		case errors.Is(Error, Structs.ErrSavedQueueNotFound), errors.Is(Error, Structs.ErrPlaylistNotFound),
+			errors.Is(Error, Structs.ErrSavedQueueNameEmpty), errors.Is(Error, Structs.ErrSavedQueueNameTooLong):
+
+			Key = "Source"
+
+	}
+
+	return Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Schedule.Error."+Key+".Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+		Description: Localizations.GetFormat("Commands.Schedule.Error."+Key+".Description", Locale, Arguments...),
+		Color:       Utils.ERROR,
+
+	})
+
+}
+
+// resolveScheduleSource accepts "queue:<name>" or "playlist:<id>" from autocomplete, or a bare saved queue name
+func resolveScheduleSource(Job *Structs.ScheduledJob, Value string, UserID string) error {
+
+	Kind, Reference, Prefixed := strings.Cut(strings.TrimSpace(Value), ":")
+
+	if !Prefixed || (Kind != Structs.ScheduleSourceQueue && Kind != Structs.ScheduleSourcePlaylist) {
+
+		Kind, Reference = Structs.ScheduleSourceQueue, strings.TrimSpace(Value)
+
+	}
+
+	if Kind == Structs.ScheduleSourcePlaylist {
+
+		Playlist, Error := Structs.GetPlaylistByID(UserID, Reference)
+
+		if Error != nil {
+
+			return Error
+
+		}
+
+		Job.SourceKind, Job.SourceID, Job.SourceName = Kind, Playlist.ID, Playlist.Name
+		Job.CreatorID = UserID // playlists are loaded from their owner's library
+		return nil
+
+	}
+
+	Normalized, Error := Structs.NormalizeSavedQueueName(Reference)
+
+	if Error != nil {
+
+		return Error
+
+	}
+
+	if _, Error := Structs.GetSavedQueue(Job.GuildID, Normalized); Error != nil {
+
+		return Error
+
+	}
+
+	Job.SourceKind, Job.SourceID, Job.SourceName = Kind, Normalized, Normalized
+	return nil
+
+}
+
+// applyScheduleOptions copies the optional settings shared by create and edit onto the job
+func applyScheduleOptions(Job *Structs.ScheduledJob, Data discord.SlashCommandInteractionData) {
+
+	if Channel, Exists := Data.OptSnowflake("channel"); Exists {
+
+		Job.VoiceChannelID = Channel.String()
+
+	}
+
+	if Volume, Exists := Data.OptInt("volume"); Exists {
+
+		Volume = Structs.ClampVolume(Volume)
+		Job.Volume = &Volume
+
+	}
+
+	if Speed, Exists := Data.OptInt("speed"); Exists {
+
+		Speed = Structs.ClampSpeedMilli(Speed)
+		Job.SpeedMilli = &Speed
+
+	}
+
+	if Reverb, Exists := Data.OptInt("reverb"); Exists {
+
+		Reverb = Structs.ClampReverb(Reverb)
+		Job.Reverb = &Reverb
+
+	}
+
+	if Announcement, Exists := Data.OptString("announcement"); Exists {
+
+		Job.Announcement = strings.TrimSpace(Announcement)
+
+	}
+
+}
+
+func scheduleCreate(Event *events.ApplicationCommandInteractionCreate, Locale string) {
+
+	Data := Event.SlashCommandInteractionData()
+	UserID := Event.User().ID.String()
+
+	Job := &Structs.ScheduledJob{
+
+		GuildID:       Event.GuildID().String(),
+		CreatorID:     UserID,
+		TextChannelID: Event.Channel().ID().String(),
+		Cron:          strings.TrimSpace(Data.String("cron")),
+		Timezone:      strings.TrimSpace(Data.String("timezone")),
+
+	}
+
+	applyScheduleOptions(Job, Data)
+
+	Respond := func(Embed discord.Embed, Ephemeral bool) {
+
+		Message := discord.MessageCreate{Embeds: []discord.Embed{Embed}}
+
+		if Ephemeral {
+
+			Message.Flags = discord.MessageFlagEphemeral
+
+		}
+
+		Event.CreateMessage(Message)
+
+	}
+
+	NextRun, Error := Structs.PlanSchedule(Data.String("at"), Job.Cron, Job.Timezone)
+
+	if Error != nil {
+
+		Respond(scheduleErrorEmbed(Error, Locale), true)
+		return
+
+	}
+
+	Job.NextRun = NextRun
+
+	if Error := resolveScheduleSource(Job, Data.String("source"), UserID); Error != nil {
+
+		Respond(scheduleErrorEmbed(Error, Locale), true)
+		return
+
+	}
+
+	if Error := Structs.CreateSchedule(Job); Error != nil {
+
+		if !errors.Is(Error, Structs.ErrScheduleLimit) {
+
+			Utils.Logger.Error("Schedule", fmt.Sprintf("Error creating schedule for guild %s: %s", Job.GuildID, Error.Error()))
+
+		}
+
+		Respond(scheduleErrorEmbed(Error, Locale), true)
+		return
+
+	}
+
+	Structs.StartScheduler()
+
+	Respond(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Commands.Schedule.Created.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+		Description: describeSchedule(*Job, Locale),
+		Color:       Utils.PRIMARY,
+
+	}), false)
+
+}
+
+func scheduleEdit(Event *events.ApplicationCommandInteractionCreate, Locale string) {
+
+	Data := Event.SlashCommandInteractionData()
+	GuildID := Event.GuildID().String()
+
+	Job, Error := Structs.GetSchedule(GuildID, Data.String("id"))
+
+	if Error != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{scheduleErrorEmbed(Error, Locale)}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	applyScheduleOptions(Job, Data)
+
+	At, HasAt := Data.OptString("at")
+	Cron, HasCron := Data.OptString("cron")
+	Timezone, HasTimezone := Data.OptString("timezone")
+
+	if HasAt || HasCron || HasTimezone {
+
+		if HasTimezone {
+
+			Job.Timezone = strings.TrimSpace(Timezone)
+
+		}
+
+		// Giving a one-off time turns a recurring job into a one-off and vice versa
+
+		if HasCron {
+
+			Job.Cron = strings.TrimSpace(Cron)
+
+		} else if HasAt {
+
+			Job.Cron = ""
+
+		}
+
+		if !HasAt && !Job.Recurring() {
+
+			if Location, Error := Structs.LoadScheduleLocation(Job.Timezone); Error == nil {
+
+				At = Job.NextRun.In(Location).Format(Structs.ScheduleTimeLayout)
+
+			}
+
+		}
+
+		NextRun, Error := Structs.PlanSchedule(At, Job.Cron, Job.Timezone)
+
+		if Error != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{scheduleErrorEmbed(Error, Locale)}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		Job.NextRun = NextRun
+
+	}
+
+	if Source, HasSource := Data.OptString("source"); HasSource {
+
+		if Error := resolveScheduleSource(Job, Source, Event.User().ID.String()); Error != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{scheduleErrorEmbed(Error, Locale)}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+	}
+
+	Job.LastError = ""
+
+	if Error := Structs.UpdateSchedule(Job); Error != nil {
+
+		Utils.Logger.Error("Schedule", fmt.Sprintf("Error updating schedule %s for guild %s: %s", Job.ID, GuildID, Error.Error()))
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{scheduleErrorEmbed(Error, Locale)}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Schedule.Edited.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: describeSchedule(*Job, Locale),
+			Color:       Utils.PRIMARY,
+
+		})},
+
+	})
+
+}
+
+func scheduleCancel(Event *events.ApplicationCommandInteractionCreate, Locale string) {
+
+	GuildID := Event.GuildID().String()
+	ID := Event.SlashCommandInteractionData().String("id")
+
+	Job, Error := Structs.GetSchedule(GuildID, ID)
+
+	if Error == nil {
+
+		Error = Structs.DeleteSchedule(GuildID, Job.ID)
+
+	}
+
+	if Error != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{scheduleErrorEmbed(Error, Locale)}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Schedule.Cancelled.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Localizations.GetFormat("Commands.Schedule.Cancelled.Description", Locale, Job.SourceName, Job.ID),
+			Color:       Utils.PRIMARY,
+
+		})},
+
+	})
+
+}
+
+func scheduleList(Event *events.ApplicationCommandInteractionCreate, Locale string) {
+
+	Jobs, Error := Structs.ListSchedules(Event.GuildID().String())
+
+	if Error != nil {
+
+		Utils.Logger.Error("Schedule", fmt.Sprintf("Error listing schedules: %s", Error.Error()))
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{scheduleErrorEmbed(Error, Locale)}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Description := Localizations.Get("Commands.Schedule.List.Empty", Locale)
+
+	if len(Jobs) > 0 {
+
+		Entries := make([]string, 0, len(Jobs))
+
+		for _, Job := range Jobs {
+
+			Entries = append(Entries, describeSchedule(Job, Locale))
+
+		}
+
+		Description = strings.Join(Entries, "\n\n")
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.GetFormat("Commands.Schedule.List.Title", Locale, len(Jobs), Structs.MaxSchedulesPerGuild),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Description,
+			Color:       Utils.PRIMARY,
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
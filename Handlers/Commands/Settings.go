@@ -4,6 +4,7 @@ import (
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
+	"fmt"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
@@ -70,6 +71,22 @@ func Settings(Event *events.ApplicationCommandInteractionCreate) {
 
 		}
 
+		if Value {
+
+			go func(UserID string) {
+
+				// voice commands already logged for an opted-out user are erased too
+
+				if ErrorDeleting := Structs.DeleteVoiceLogForUser(UserID); ErrorDeleting != nil {
+
+					Utils.Logger.Error("VoiceLog", fmt.Sprintf("Error deleting voice command log for user %s: %s", UserID, ErrorDeleting.Error()))
+
+				}
+
+			}(Event.User().ID.String())
+
+		}
+
 		var TitleKey, DescriptionKey string
 
 		if Value {
@@ -32,14 +32,32 @@ func Shuffle(Event *events.ApplicationCommandInteractionCreate) {
 
 	}
 
+	if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilityMove, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Data := Event.SlashCommandInteractionData()
 	Enabled := Data.Bool("enabled")
 
 	Guild.Features.Shuffle = Enabled
 
+	if Mode, Exists := Data.OptString("mode"); Exists {
+
+		Guild.Features.ShuffleMode = Structs.ParseShuffleMode(Mode)
+
+	}
+
 	var Title, Description string
 
-	if Enabled {
+	if Enabled && Guild.Features.ShuffleMode == Structs.ShuffleSmart {
+
+		Title = Localizations.Get("Commands.Shuffle.Enabled.SmartTitle", Locale)
+		Description = Localizations.Get("Commands.Shuffle.Enabled.Smart", Locale)
+
+	} else if Enabled {
 
 		Title = Localizations.Get("Commands.Shuffle.Enabled.Title", Locale)
 		Description = Localizations.Get("Commands.Shuffle.Enabled.Description", Locale)
@@ -13,6 +13,41 @@ import (
 
 func Stats(Event *events.ApplicationCommandInteractionCreate) {
 
+	Data := Event.SlashCommandInteractionData()
+
+	Subcommand := "session"
+
+	if Data.SubCommandName != nil {
+
+		Subcommand = *Data.SubCommandName
+
+	}
+
+	switch Subcommand {
+
+	case "server":
+
+		ServerStats(Event)
+
+	case "me":
+
+		UserStats(Event)
+
+	case "wrapped":
+
+		Wrapped(Event)
+
+	default:
+
+		sessionStats(Event)
+
+	}
+
+}
+
+// sessionStats shows runtime numbers for the current playback session
+func sessionStats(Event *events.ApplicationCommandInteractionCreate) {
+
 	Locale := Event.Locale().Code()
 
 	Guild := Structs.GetGuild(*Event.GuildID(), false)
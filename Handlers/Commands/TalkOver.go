@@ -0,0 +1,121 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Receive"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func TalkOver(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false) // settings can be changed without an active session
+
+	Policy := Structs.LoadGuildSettings(GuildID.String()).TalkOver
+
+	if Guild != nil {
+
+		Policy = Guild.Settings.TalkOver
+
+	}
+
+	Data := Event.SlashCommandInteractionData()
+
+	Enabled, HasEnabled := Data.OptBool("enabled")
+	Depth, HasDepth := Data.OptInt("depth")
+	Attack, HasAttack := Data.OptInt("attack")
+	Release, HasRelease := Data.OptInt("release")
+
+	Title := Localizations.Get("Commands.TalkOver.Current.Title", Locale)
+
+	if HasEnabled || HasDepth || HasAttack || HasRelease {
+
+		if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if HasEnabled {
+
+			Policy.Enabled = Enabled
+
+		}
+
+		if HasDepth {
+
+			Policy.Depth = Depth
+
+		}
+
+		if HasAttack {
+
+			Policy.AttackMs = Attack
+
+		}
+
+		if HasRelease {
+
+			Policy.ReleaseMs = Release
+
+		}
+
+		var SaveError error
+
+		Policy, SaveError = Structs.SaveTalkOverPolicy(GuildID.String(), Policy)
+
+		if SaveError != nil {
+
+			Utils.Logger.Error("TalkOver", fmt.Sprintf("Failed to save talk-over policy for guild %s: %s", GuildID.String(), SaveError.Error()))
+
+			ErrorEmbed := Validation.SettingsSaveError(Locale)
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if Guild != nil {
+
+			Guild.Settings.TalkOver = Policy
+			Guild.ApplyTalkOverPolicy()
+
+		}
+
+		Title = Localizations.Get("Commands.TalkOver.Updated.Title", Locale)
+
+	}
+
+	Description := Localizations.GetFormat("Commands.TalkOver.Enabled", Locale, onOff(Policy.Enabled, Locale)) + "\n" +
+		Localizations.GetFormat("Commands.TalkOver.Depth", Locale, Policy.Depth) + "\n" +
+		Localizations.GetFormat("Commands.TalkOver.Timing", Locale, Policy.AttackMs, Policy.ReleaseMs)
+
+	if Policy.Enabled && !Receive.IsEnabled() {
+
+		Description += "\n\n" + Localizations.Get("Commands.TalkOver.ReceiveDisabled", Locale)
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Title,
+			Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+			Description: Description,
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
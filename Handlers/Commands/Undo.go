@@ -0,0 +1,98 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func Undo(Event *events.ApplicationCommandInteractionCreate) {
+
+	stepQueueHistory(Event, false)
+
+}
+
+func Redo(Event *events.ApplicationCommandInteractionCreate) {
+
+	stepQueueHistory(Event, true)
+
+}
+
+// stepQueueHistory walks the queue's operation log one step back (or forward) and reports what changed
+func stepQueueHistory(Event *events.ApplicationCommandInteractionCreate, Redo bool) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		ErrorEmbed := Validation.GuildSessionError(Locale)
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, Event.User().ID, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Key := "Commands.Undo"
+
+	if Redo {
+
+		Key = "Commands.Redo"
+
+	}
+
+	Operation, Missing, Done := Guild.StepHistory(Event.User().ID, Redo, 0)
+
+	if Missing != "" {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{Validation.CapabilityDeniedError(Missing, Locale)}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if !Done {
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get(Key+".Empty.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+				Description: Localizations.Get(Key+".Empty.Description", Locale),
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get(Key+".Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Localizations.GetFormat(Key+".Description", Locale, Localizations.Get("Common.Operations."+Operation.Kind, Locale)),
+			Color:       Utils.PRIMARY,
+
+		})},
+
+	})
+
+}
@@ -0,0 +1,122 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// FormatVoiceLog renders voice command log entries, one per line with the transcript beneath
+func FormatVoiceLog(Entries []Structs.VoiceLogEntry, Locale string, ShowGuild bool) string {
+
+	var Body strings.Builder
+
+	for _, Entry := range Entries {
+
+		Command := Entry.Command
+
+		if Entry.Args != "" {
+
+			Command = fmt.Sprintf("%s %s", Entry.Command, Entry.Args)
+
+		}
+
+		Outcome := Localizations.Get(fmt.Sprintf("Commands.VoiceLog.Outcome.%s", Entry.Outcome), Locale)
+
+		Body.WriteString(fmt.Sprintf("<t:%d:R> • <@%s> • **%s** • %s • %d ms\n", Entry.At.Unix(), Entry.UserID, Utils.Truncate(Command, 80), Outcome, Entry.LatencyMs))
+
+		if ShowGuild {
+
+			Body.WriteString(fmt.Sprintf("-# %s • \"%s\"\n", Entry.GuildID, Utils.Truncate(Entry.Transcript, 120)))
+
+		} else {
+
+			Body.WriteString(fmt.Sprintf("-# \"%s\"\n", Utils.Truncate(Entry.Transcript, 120)))
+
+		}
+
+	}
+
+	return Body.String()
+
+}
+
+func VoiceLog(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Data := Event.SlashCommandInteractionData()
+
+	UserID := ""
+
+	if User, HasUser := Data.OptUser("user"); HasUser {
+
+		UserID = User.ID.String()
+
+	}
+
+	Entries, ErrorLoading := Structs.RecentVoiceLog(GuildID.String(), UserID, Structs.VoiceLogPageSize)
+
+	if ErrorLoading != nil {
+
+		Utils.Logger.Error("VoiceLog", fmt.Sprintf("Error loading voice command log for guild %s: %s", GuildID.String(), ErrorLoading.Error()))
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.VoiceLog.Error.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+				Description: Localizations.Get("Commands.VoiceLog.Error.Description", Locale),
+				Color:       Utils.ERROR,
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	Description := FormatVoiceLog(Entries, Locale, false)
+
+	if len(Entries) == 0 {
+
+		Description = Localizations.Get("Commands.VoiceLog.Empty", Locale)
+
+	}
+
+	Retention := int(Structs.VoiceLogRetention().Hours() / 24)
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.VoiceLog.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+			Description: Description,
+			Footer:      Localizations.GetFormat("Commands.VoiceLog.Retention", Locale, Retention),
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
@@ -0,0 +1,97 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func VoteSkip(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false) // settings can be changed without an active session
+
+	Policy := Structs.LoadGuildSettings(GuildID.String()).VoteSkip
+
+	if Guild != nil {
+
+		Policy = Guild.Settings.VoteSkip
+
+	}
+
+	Data := Event.SlashCommandInteractionData()
+
+	Enabled, HasEnabled := Data.OptBool("enabled")
+	Threshold, HasThreshold := Data.OptInt("threshold")
+
+	Title := Localizations.Get("Commands.VoteSkip.Current.Title", Locale)
+
+	if HasEnabled || HasThreshold {
+
+		if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if HasEnabled {
+
+			Policy.Enabled = Enabled
+
+		}
+
+		if HasThreshold {
+
+			Policy.Threshold = Threshold
+
+		}
+
+		var SaveError error
+
+		Policy, SaveError = Structs.SaveVoteSkipPolicy(GuildID.String(), Policy)
+
+		if SaveError != nil {
+
+			Utils.Logger.Error("VoteSkip", fmt.Sprintf("Failed to save vote-skip policy for guild %s: %s", GuildID.String(), SaveError.Error()))
+
+			ErrorEmbed := Validation.SettingsSaveError(Locale)
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if Guild != nil {
+
+			Guild.Settings.VoteSkip = Policy
+			Guild.ClearSkipVotes()
+
+		}
+
+		Title = Localizations.Get("Commands.VoteSkip.Updated.Title", Locale)
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Title,
+			Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+			Description: Localizations.GetFormat("Commands.VoteSkip.Enabled", Locale, onOff(Policy.Enabled, Locale)) + "\n" +
+				Localizations.GetFormat("Commands.VoteSkip.Threshold", Locale, Policy.Threshold),
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
@@ -0,0 +1,144 @@
+package Commands
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Receive"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+	"fmt"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func WakeWord(Event *events.ApplicationCommandInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false) // settings can be changed without an active session
+
+	Policy := Structs.LoadGuildSettings(GuildID.String()).Voice
+
+	if Guild != nil {
+
+		Policy = Guild.Settings.Voice
+
+	}
+
+	Data := Event.SlashCommandInteractionData()
+
+	Phrase, HasPhrase := Data.OptString("phrase")
+	Sensitivity, HasSensitivity := Data.OptInt("sensitivity")
+	Reset, HasReset := Data.OptBool("reset")
+
+	Title := Localizations.Get("Commands.WakeWord.Current.Title", Locale)
+
+	if HasPhrase || HasSensitivity || HasReset {
+
+		if ErrorEmbed := Validation.ManageGuildError(Event, Locale); ErrorEmbed != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if HasPhrase {
+
+			Normalized, Valid := Receive.NormalizeWakePhrase(Phrase)
+
+			if !Valid {
+
+				Event.CreateMessage(discord.MessageCreate{
+
+					Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+						Title:       Localizations.Get("Commands.WakeWord.Error.InvalidPhrase.Title", Locale),
+						Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+						Description: Localizations.Get("Commands.WakeWord.Error.InvalidPhrase.Description", Locale),
+						Color:       Utils.ERROR,
+
+					})},
+
+					Flags: discord.MessageFlagEphemeral,
+
+				})
+
+				return
+
+			}
+
+			Policy.WakePhrase = Normalized
+
+		}
+
+		if HasSensitivity {
+
+			Policy.WakeSensitivity = Sensitivity
+
+		}
+
+		if HasReset && Reset {
+
+			Policy = Structs.DefaultGuildSettings(GuildID.String()).Voice
+
+		}
+
+		var SaveError error
+
+		Policy, SaveError = Structs.SaveVoicePolicy(GuildID.String(), Policy)
+
+		if SaveError != nil {
+
+			Utils.Logger.Error("WakeWord", fmt.Sprintf("Failed to save wake word for guild %s: %s", GuildID.String(), SaveError.Error()))
+
+			ErrorEmbed := Validation.SettingsSaveError(Locale)
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+		if Guild != nil {
+
+			Guild.Settings.Voice = Policy
+			Receive.ReloadWakeWord(GuildID)
+
+		}
+
+		Title = Localizations.Get("Commands.WakeWord.Updated.Title", Locale)
+
+	}
+
+	Shown := Policy.WakePhrase
+
+	if Shown == "" {
+
+		Shown = Receive.DefaultWakePhrase
+
+	}
+
+	Description := Localizations.GetFormat("Commands.WakeWord.Phrase", Locale, Shown) + "\n" +
+		Localizations.GetFormat("Commands.WakeWord.Sensitivity", Locale, Policy.WakeSensitivity)
+
+	if !Receive.WakeKeywordAvailable(Shown) {
+
+		Description += "\n\n" + Localizations.GetFormat("Commands.WakeWord.KeywordMissing", Locale, Receive.WakeKeywordPath(Shown))
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Title,
+			Author:      Localizations.Get("Embeds.Categories.Settings", Locale),
+			Description: Description,
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
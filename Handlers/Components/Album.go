@@ -86,6 +86,25 @@ func AlbumEnqueue(Event *events.ComponentInteractionCreate) {
 
 	}
 
+	Position := Structs.PositionEnd
+
+	if len(Parts) > 2 {
+
+		Position = Structs.ParsePosition(Parts[2])
+
+	}
+
+	if Capability := Position.Capability(); Capability != "" {
+
+		if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Capability, Locale); ErrorEmbed != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+	}
+
 	AlbumSongs, ErrorFetching := Tidal.FetchAlbumTracks(AlbumID)
 
 	if ErrorFetching != nil || len(AlbumSongs) == 0 {
@@ -142,12 +161,7 @@ func AlbumEnqueue(Event *events.ComponentInteractionCreate) {
 
 	}
 
-	for _, Song := range EnqueueSongs {
-
-		SongCopy := Song
-		Guild.Queue.Add(&SongCopy, Event.User().ID.String())
-
-	}
+	Added, Skipped := Guild.Queue.AddAllAt(EnqueueSongs, Event.User().ID.String(), Position)
 
 	QueueURL := fmt.Sprintf("%s/Queues/%s?View=Queue", strings.TrimRight(os.Getenv("DOMAIN"), "/"), GuildID.String())
 
@@ -165,18 +179,18 @@ func AlbumEnqueue(Event *events.ComponentInteractionCreate) {
 
 			Description: func() string {
 
-				if StartIndex > 0 && Guild.Queue.Current != nil && len(EnqueueSongs) > 0 {
+				if StartIndex > 0 && Guild.Queue.Current != nil && Added > 0 {
 
-					return Localizations.GetFormat("Components.Album.Enqueued.DescriptionAfterCurrent", Locale, len(EnqueueSongs), Guild.Queue.Current.Title)
+					return Skipped.AppendTo(Localizations.GetFormat("Components.Album.Enqueued.DescriptionAfterCurrent", Locale, Added, Guild.Queue.Current.Title), Locale)
 
 				}
 
-				return Localizations.GetFormat("Components.Album.Enqueued.Description", Locale, len(EnqueueSongs))
+				return Skipped.AppendTo(Localizations.GetFormat("Components.Album.Enqueued.Description", Locale, Added), Locale)
 
 			}(),
 
 		})).
-		AddActionRow(ViewQueueButton))
+		AddActionRow(ViewQueueButton, Guild.Queue.UndoButton(Locale)))
 
 }
 
@@ -200,6 +214,15 @@ func AlbumPlay(Event *events.ComponentInteractionCreate) {
 
 	}
 
+	// Playing replaces the whole queue
+
+	if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilityClear, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Parts := strings.Split(Event.Data.CustomID(), ":")
 
 	if len(Parts) < 2 {
@@ -272,14 +295,7 @@ func AlbumPlay(Event *events.ComponentInteractionCreate) {
 
 	}
 
-	Guild.Queue.Clear()
-
-	for _, Song := range AlbumSongs {
-
-		SongCopy := Song
-		Guild.Queue.Add(&SongCopy, Event.User().ID.String())
-
-	}
+	Added, Skipped := Guild.Queue.ReplaceAll(AlbumSongs, Event.User().ID.String()) // one undo step for replacing the queue
 
 	if len(Guild.Queue.Upcoming) > 0 {
 
@@ -292,9 +308,10 @@ func AlbumPlay(Event *events.ComponentInteractionCreate) {
 
 			Title:       Localizations.Get("Components.Album.Playing.Title", Locale),
 			Author:      Localizations.Get("Embeds.Categories.Success", Locale),
-			Description: Localizations.GetFormat("Components.Album.Playing.Description", Locale, len(AlbumSongs)),
+			Description: Skipped.AppendTo(Localizations.GetFormat("Components.Album.Playing.Description", Locale, Added), Locale),
 			Color:       Utils.PRIMARY,
 
-		})))
+		})).
+		AddActionRow(Guild.Queue.UndoButton(Locale)))
 
 }
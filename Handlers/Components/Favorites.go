@@ -0,0 +1,234 @@
+package Components
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Handlers/Commands"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func favoritesFailed(Event *events.ComponentInteractionCreate, Locale string) {
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Favorites.Error.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+			Description: Localizations.Get("Commands.Favorites.Error.Description", Locale),
+			Color:       Utils.ERROR,
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+// favoritesOwner parses "Base:UserID:Value" custom IDs; only the owner of a favorites view may use its controls
+func favoritesOwner(Event *events.ComponentInteractionCreate) (*Structs.User, string, bool) {
+
+	Parts := strings.Split(Event.Data.CustomID(), ":")
+
+	if len(Parts) < 3 || Parts[1] != Event.User().ID.String() {
+
+		return nil, "", false
+
+	}
+
+	User, ErrorFetching := Structs.GetUser(Parts[1])
+
+	if ErrorFetching != nil {
+
+		return nil, "", false
+
+	}
+
+	return User, Parts[2], true
+
+}
+
+// Favorite toggles a song in the clicking user's favorites
+func Favorite(Event *events.ComponentInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Parts := strings.Split(Event.Data.CustomID(), ":")
+
+	if len(Parts) < 2 {
+
+		return
+
+	}
+
+	TidalID, ErrorParsing := strconv.ParseInt(Parts[1], 10, 64)
+
+	if ErrorParsing != nil || TidalID == 0 {
+
+		favoritesFailed(Event, Locale)
+		return
+
+	}
+
+	var Song *Tidal.Song
+
+	if Guild := Structs.GetGuild(*Event.GuildID(), false); Guild != nil {
+
+		Song = Guild.Queue.FindSong(TidalID)
+
+	}
+
+	if Song == nil {
+
+		Fetched, ErrorFetching := Tidal.GetSong(TidalID)
+
+		if ErrorFetching != nil {
+
+			favoritesFailed(Event, Locale)
+			return
+
+		}
+
+		Song = &Fetched
+
+	}
+
+	User, ErrorFetching := Structs.GetUser(Event.User().ID.String())
+
+	if ErrorFetching != nil {
+
+		favoritesFailed(Event, Locale)
+		return
+
+	}
+
+	Liked, ErrorToggling := User.ToggleLike(Song)
+
+	if ErrorToggling != nil {
+
+		Utils.Logger.Error("Favorites", fmt.Sprintf("Error updating favorites for user %s: %s", User.DiscordID, ErrorToggling.Error()))
+		favoritesFailed(Event, Locale)
+		return
+
+	}
+
+	Key := "Commands.Favorites.Removed"
+
+	if Liked {
+
+		Key = "Commands.Favorites.Added"
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get(Key+".Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+			Description: Localizations.GetFormat(Key+".Description", Locale, Song.Title),
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+func FavoritesPage(Event *events.ComponentInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	User, Value, Valid := favoritesOwner(Event)
+	Page, ErrorParsing := strconv.Atoi(Value)
+
+	if !Valid || ErrorParsing != nil {
+
+		favoritesFailed(Event, Locale)
+		return
+
+	}
+
+	Response := Commands.BuildFavoritesResponse(User, Page, Locale)
+
+	Event.UpdateMessage(discord.MessageUpdate{
+
+		Embeds:     &Response.Embeds,
+		Components: &Response.Components,
+
+	})
+
+}
+
+// FavoritesPlay queues the owner's favorites; the value is 1 when shuffled
+func FavoritesPlay(Event *events.ComponentInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	_, Value, Valid := favoritesOwner(Event)
+
+	if !Valid {
+
+		favoritesFailed(Event, Locale)
+		return
+
+	}
+
+	Event.CreateMessage(Commands.PlayFavorites(*Event.GuildID(), Event.User().ID, Value == "1", Locale))
+
+}
+
+// FavoritesRemove removes the song picked from the select menu and re-renders the page
+func FavoritesRemove(Event *events.ComponentInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	User, Value, Valid := favoritesOwner(Event)
+	Page, ErrorParsing := strconv.Atoi(Value)
+
+	Values := Event.StringSelectMenuInteractionData().Values
+
+	if !Valid || ErrorParsing != nil || len(Values) == 0 {
+
+		favoritesFailed(Event, Locale)
+		return
+
+	}
+
+	TidalID, ErrorParsingID := strconv.ParseInt(Values[0], 10, 64)
+
+	if ErrorParsingID != nil {
+
+		favoritesFailed(Event, Locale)
+		return
+
+	}
+
+	if ErrorRemoving := User.Unlike(TidalID); ErrorRemoving != nil {
+
+		Utils.Logger.Error("Favorites", fmt.Sprintf("Error removing favorite for user %s: %s", User.DiscordID, ErrorRemoving.Error()))
+		favoritesFailed(Event, Locale)
+		return
+
+	}
+
+	Response := Commands.BuildFavoritesResponse(User, Page, Locale)
+
+	Event.UpdateMessage(discord.MessageUpdate{
+
+		Embeds:     &Response.Embeds,
+		Components: &Response.Components,
+
+	})
+
+}
@@ -0,0 +1,188 @@
+package Components
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Handlers/Commands"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// historyTarget parses "Base:Token:Page" custom IDs
+func historyTarget(CustomID string) (string, int, bool) {
+
+	Parts := strings.Split(CustomID, ":")
+
+	if len(Parts) < 3 {
+
+		return "", 0, false
+
+	}
+
+	Page, ErrorParsing := strconv.Atoi(Parts[2])
+
+	return Parts[1], Page, ErrorParsing == nil && Page >= 0
+
+}
+
+func historyExpired(Event *events.ComponentInteractionCreate, Locale string) {
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.History.Expired.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+			Description: Localizations.Get("Commands.History.Expired.Description", Locale),
+			Color:       Utils.ERROR,
+
+		})},
+
+		Flags: discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+func HistoryPage(Event *events.ComponentInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Token, Page, Valid := historyTarget(Event.Data.CustomID())
+
+	if !Valid {
+
+		historyExpired(Event, Locale)
+		return
+
+	}
+
+	Response, ErrorBuilding := Commands.BuildHistoryResponse(GuildID, Token, Page, Locale)
+
+	if ErrorBuilding != nil {
+
+		historyExpired(Event, Locale)
+		return
+
+	}
+
+	Event.UpdateMessage(discord.MessageUpdate{
+
+		Embeds:     &Response.Embeds,
+		Components: &Response.Components,
+
+	})
+
+}
+
+// HistoryRequeue queues every song on the shown page, oldest first
+func HistoryRequeue(Event *events.ComponentInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Token, Page, Valid := historyTarget(Event.Data.CustomID())
+	Entries, Exists := Commands.HistoryView(Token, Page)
+
+	if !Valid || !Exists {
+
+		historyExpired(Event, Locale)
+		return
+
+	}
+
+	Songs := make([]Tidal.Song, 0, len(Entries))
+
+	for Index := len(Entries) - 1; Index >= 0; Index-- {
+
+		Songs = append(Songs, *Entries[Index].Song)
+
+	}
+
+	requeueHistory(Event, Songs, Locale)
+
+}
+
+// HistoryPick queues the single entry chosen from the select menu
+func HistoryPick(Event *events.ComponentInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Token, Page, Valid := historyTarget(Event.Data.CustomID())
+	Entries, Exists := Commands.HistoryView(Token, Page)
+
+	if !Valid || !Exists {
+
+		historyExpired(Event, Locale)
+		return
+
+	}
+
+	Values := Event.StringSelectMenuInteractionData().Values
+
+	if len(Values) == 0 {
+
+		return
+
+	}
+
+	Index, ErrorParsing := strconv.Atoi(Values[0])
+
+	if ErrorParsing != nil || Index < 0 || Index >= len(Entries) {
+
+		historyExpired(Event, Locale)
+		return
+
+	}
+
+	requeueHistory(Event, []Tidal.Song{*Entries[Index].Song}, Locale)
+
+}
+
+func requeueHistory(Event *events.ComponentInteractionCreate, Songs []Tidal.Song, Locale string) {
+
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		ErrorEmbed := Validation.GuildSessionError(Locale)
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, Event.User().ID, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Added, Skipped := Guild.Queue.AddAll(Songs, Event.User().Mention())
+
+	if Added > 0 && Guild.Queue.State == Structs.StateIdle && Guild.Queue.Current != nil {
+
+		Guild.Queue.Play() // the queue was empty
+
+	}
+
+	Event.CreateMessage(discord.NewMessageCreate().
+		AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.History.Requeued.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Success", Locale),
+			Description: Skipped.AppendTo(Localizations.GetFormat("Commands.History.Requeued.Description", Locale, Added, Localizations.Pluralize("Song", Added, Locale)), Locale),
+
+		})).
+		AddActionRow(Guild.Queue.UndoButton(Locale)))
+
+}
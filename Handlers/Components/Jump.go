@@ -36,6 +36,13 @@ func JumpToSong(Event *events.ComponentInteractionCreate, TidalID int64) {
 
 	}
 
+	if ErrorEmbed := Validation.SkipVoteError(Guild.RequestJump(Event.User().ID), Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	// Find the song in the upcoming queue by Tidal ID
 	SongIndex := -1
 	for Index, Song := range Guild.Queue.Upcoming {
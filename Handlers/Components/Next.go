@@ -35,6 +35,13 @@ func Next(Event *events.ComponentInteractionCreate) {
 
 	}
 
+	if ErrorEmbed := Validation.SkipVoteError(Guild.RequestSkip(Event.User().ID, Event.User().Username), Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
 	Advanced, Ended := Guild.Queue.Next(true)
 
 	if Ended {
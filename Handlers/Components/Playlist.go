@@ -0,0 +1,86 @@
+package Components
+
+import (
+	"Synthara-Redux/Handlers/Commands"
+	"Synthara-Redux/Structs"
+
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// playlistOwner parses "Base:UserID:PlaylistID[:Value]" custom IDs; only the playlist's owner may use its controls
+func playlistOwner(Event *events.ComponentInteractionCreate) (*Structs.UserPlaylist, []string, error) {
+
+	Parts := strings.Split(Event.Data.CustomID(), ":")
+
+	if len(Parts) < 3 || Parts[1] != Event.User().ID.String() {
+
+		return nil, nil, Structs.ErrPlaylistNotFound
+
+	}
+
+	Playlist, Error := Structs.GetPlaylistByID(Parts[1], Parts[2])
+
+	return Playlist, Parts[3:], Error
+
+}
+
+func playlistFailed(Event *events.ComponentInteractionCreate, Error error) {
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Commands.BuildPlaylistError(Error, Event.Locale().Code())},
+		Flags:  discord.MessageFlagEphemeral,
+
+	})
+
+}
+
+func PlaylistPage(Event *events.ComponentInteractionCreate) {
+
+	Playlist, Values, Error := playlistOwner(Event)
+
+	if Error != nil {
+
+		playlistFailed(Event, Error)
+		return
+
+	}
+
+	Page := 0
+
+	if len(Values) > 0 {
+
+		Page, _ = strconv.Atoi(Values[0])
+
+	}
+
+	Response := Commands.BuildPlaylistResponse(Playlist, Page, Event.Locale().Code())
+
+	Event.UpdateMessage(discord.MessageUpdate{
+
+		Embeds:     &Response.Embeds,
+		Components: &Response.Components,
+
+	})
+
+}
+
+// PlaylistAppend adds the viewed playlist to the queue of the guild it is viewed in
+func PlaylistAppend(Event *events.ComponentInteractionCreate) {
+
+	Playlist, _, Error := playlistOwner(Event)
+
+	if Error != nil {
+
+		playlistFailed(Event, Error)
+		return
+
+	}
+
+	Event.CreateMessage(Commands.AppendPlaylist(*Event.GuildID(), Event.User().ID, Playlist, Event.Locale().Code()))
+
+}
@@ -68,6 +68,17 @@ func RemoveSong(Event *events.ComponentInteractionCreate, TidalID int64) {
 
 	}
 
+	if !Guild.IsRequestor(Guild.Queue.Upcoming[SongIndex], Event.User().ID, Event.User().Username) {
+
+		if ErrorEmbed := Validation.CapabilityError(GuildID, Event.User().ID, Structs.CapabilityRemove, Locale); ErrorEmbed != nil {
+
+			Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+			return
+
+		}
+
+	}
+
 	Success := Guild.Queue.Remove(SongIndex)
 
 	if !Success {
@@ -98,6 +109,7 @@ func RemoveSong(Event *events.ComponentInteractionCreate, TidalID int64) {
 			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
 			Description: "Song removed from queue.",
 
-		})))
+		})).
+		AddActionRow(Guild.Queue.UndoButton(Locale)))
 
 }
@@ -1,7 +1,6 @@
 package Components
 
 import (
-	"Synthara-Redux/APIs/Tidal"
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
@@ -40,24 +39,11 @@ func Resume(Event *events.ComponentInteractionCreate) {
 	// Update the original message with the new pause/play button
 	if Guild.Queue.Current != nil {
 
-		State := Tidal.QueueInfo{
-
-			Playing: true, // Now playing
-
-			GuildID: GuildID,
-
-			SongPosition: 0,
-
-			TotalPrevious: len(Guild.Queue.Previous),
-			TotalUpcoming: len(Guild.Queue.Upcoming),
-
-			Locale: Locale,
-
-		}
+		State := Guild.QueueInfo(true, 0, Locale)
 
 		Event.UpdateMessage(discord.NewMessageUpdate().
 			AddEmbeds(Guild.Queue.Current.Embed(State)).
-			AddActionRow(Guild.Queue.Current.Buttons(State)...))
+			AddComponents(Guild.Queue.Current.Components(State)...))
 
 	} else {
 
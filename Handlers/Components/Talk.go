@@ -0,0 +1,24 @@
+package Components
+
+import (
+	"Synthara-Redux/Handlers/Commands"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// Talk opens a push-to-talk voice command capture for whoever clicked the now playing button
+func Talk(Event *events.ComponentInteractionCreate) {
+
+	Locale := Event.Locale().Code()
+
+	Embed := Commands.StartListening(*Event.GuildID(), Event.User().ID, Locale)
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Embed},
+		Flags:  discord.MessageFlagEphemeral,
+
+	})
+
+}
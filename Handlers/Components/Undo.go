@@ -0,0 +1,99 @@
+package Components
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func Undo(Event *events.ComponentInteractionCreate, OperationID int) {
+
+	Locale := Event.Locale().Code()
+	GuildID := *Event.GuildID()
+
+	Guild := Structs.GetGuild(GuildID, false)
+
+	if Guild == nil {
+
+		ErrorEmbed := Validation.GuildSessionError(Locale)
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if ErrorEmbed := Validation.VoiceStateError(GuildID, Event.User().ID, Locale); ErrorEmbed != nil {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{*ErrorEmbed}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	Operation, Missing, Done := Guild.StepHistory(Event.User().ID, false, OperationID)
+
+	if Missing != "" {
+
+		Event.CreateMessage(discord.MessageCreate{Embeds: []discord.Embed{Validation.CapabilityDeniedError(Missing, Locale)}, Flags: discord.MessageFlagEphemeral})
+		return
+
+	}
+
+	if !Done && Operation.ID != 0 {
+
+		// the queue changed since this button was sent; undoing now would revert someone else's change
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Undo.Stale.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+				Description: Localizations.Get("Commands.Undo.Stale.Description", Locale),
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	if !Done {
+
+		Event.CreateMessage(discord.MessageCreate{
+
+			Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Undo.Empty.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+				Description: Localizations.Get("Commands.Undo.Empty.Description", Locale),
+
+			})},
+
+			Flags: discord.MessageFlagEphemeral,
+
+		})
+
+		return
+
+	}
+
+	Event.CreateMessage(discord.MessageCreate{
+
+		Embeds: []discord.Embed{Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Commands.Undo.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Playback", Locale),
+			Description: Localizations.GetFormat("Commands.Undo.Description", Locale, Localizations.Get("Common.Operations."+Operation.Kind, Locale)),
+			Color:       Utils.PRIMARY,
+
+		})},
+
+	})
+
+}
@@ -112,6 +112,16 @@ func registerVoiceCommands() {
 	Receive.Register(Receive.CommandVolume, Voice.Volume)
 	Receive.Register(Receive.CommandSpeed, Voice.Speed)
 	Receive.Register(Receive.CommandReverb, Voice.Reverb)
+	Receive.Register(Receive.CommandNowPlaying, Voice.NowPlaying)
+	Receive.Register(Receive.CommandRequester, Voice.Requester)
+	Receive.Register(Receive.CommandRemove, Voice.Remove)
+	Receive.Register(Receive.CommandClear, Voice.Clear)
+	Receive.Register(Receive.CommandSave, Voice.Save)
+	Receive.Register(Receive.CommandLike, Voice.Like)
+	Receive.Register(Receive.CommandSeek, Voice.Seek)
+	Receive.Register(Receive.CommandLyrics, Voice.Lyrics)
+
+	Receive.SetAuthorizer(Voice.Authorize)
 
 	Receive.SetFeedbackCueHandler(func(GuildID snowflake.ID, Kind Receive.FeedbackCueKind) {
 
@@ -141,6 +151,20 @@ func registerVoiceCommands() {
 
 	})
 
+	Receive.SetTalkOverHandler(func(GuildID snowflake.ID, Talking bool) {
+
+		Guild := Structs.GetGuild(GuildID, false)
+
+		if Guild == nil {
+
+			return
+
+		}
+
+		Guild.SetTalkingOver(Talking)
+
+	})
+
 	Receive.SetVoiceCommandOptOutChecker(func(UserID snowflake.ID) bool {
 
 		UserData, ErrUser := Structs.GetUser(UserID.String())
@@ -155,6 +179,8 @@ func registerVoiceCommands() {
 
 	})
 
+	Receive.SetVoiceCommandLogger(Structs.RecordVoiceCommand)
+
 	Receive.SetVoiceResponseHandler(func(GuildID snowflake.ID, text string) {
 
 		Guild := Structs.GetGuild(GuildID, false)
@@ -169,6 +195,34 @@ func registerVoiceCommands() {
 
 	})
 
+	Receive.SetGuildLocaleResolver(func(GuildID snowflake.ID) string {
+
+		Guild := Structs.GetGuild(GuildID, false)
+
+		if Guild == nil {
+
+			return Localizations.Default
+
+		}
+
+		return Guild.Locale.Code()
+
+	})
+
+	Receive.SetWakeWordResolver(func(GuildID snowflake.ID) Receive.WakeWord {
+
+		Guild := Structs.GetGuild(GuildID, false)
+
+		if Guild == nil {
+
+			return Receive.WakeWord{}
+
+		}
+
+		return Guild.WakeWord()
+
+	})
+
 }
 
 func InitializeHandlers() {
@@ -299,6 +353,10 @@ func InitializeHandlers() {
 
 				Commands.Pause(Event)
 
+			case "listen":
+
+				Commands.Listen(Event)
+
 			case "resume":
 
 				Commands.Resume(Event)
@@ -387,6 +445,74 @@ func InitializeHandlers() {
 
 				Commands.Leave(Event)
 
+			case "inactivity":
+
+				Commands.Inactivity(Event)
+
+			case "voteskip":
+
+				Commands.VoteSkip(Event)
+
+			case "wakeword":
+
+				Commands.WakeWord(Event)
+
+			case "voicelog":
+
+				Commands.VoiceLog(Event)
+
+			case "talkover":
+
+				Commands.TalkOver(Event)
+
+			case "permissions":
+
+				Commands.Permissions(Event)
+
+			case "fairqueue":
+
+				Commands.FairQueue(Event)
+
+			case "quotas":
+
+				Commands.Quotas(Event)
+
+			case "undo":
+
+				Commands.Undo(Event)
+
+			case "redo":
+
+				Commands.Redo(Event)
+
+			case "radio":
+
+				Commands.Radio(Event)
+
+			case "history":
+
+				Commands.History(Event)
+
+			case "favorites":
+
+				Commands.Favorites(Event)
+
+			case "playlist":
+
+				Commands.Playlist(Event)
+
+			case "export":
+
+				Commands.Export(Event)
+
+			case "import":
+
+				Commands.Import(Event)
+
+			case "schedule":
+
+				Commands.Schedule(Event)
+
 			case "notify":
 
 				Commands.Notify(Event)
@@ -478,10 +604,22 @@ func InitializeHandlers() {
 
 				Autocomplete.DeleteAutocomplete(Event)
 
-			case "load", "manage":
+			case "load", "manage", "export":
 
 				Autocomplete.SavedQueueAutocomplete(Event)
 
+			case "favorites":
+
+				Autocomplete.FavoritesAutocomplete(Event)
+
+			case "playlist":
+
+				Autocomplete.PlaylistAutocomplete(Event)
+
+			case "schedule":
+
+				Autocomplete.ScheduleAutocomplete(Event)
+
 			}
 
 		}()
@@ -529,6 +667,10 @@ func InitializeHandlers() {
 
 				Components.Pause(Event)
 
+			case "Talk":
+
+				Components.Talk(Event)
+
 			case "Queue":
 
 				Components.Queue(Event)
@@ -589,6 +731,58 @@ func InitializeHandlers() {
 
 				Components.ArtistPlay(Event)
 
+			case "Undo":
+
+				OperationID := -1 // buttons without a valid operation ID never match, so they count as stale
+
+				if len(Parts) > 1 {
+
+					if ParsedID, ParseErr := strconv.Atoi(Parts[1]); ParseErr == nil && ParsedID > 0 {
+
+						OperationID = ParsedID
+
+					}
+
+				}
+
+				Components.Undo(Event, OperationID)
+
+			case "HistoryPage":
+
+				Components.HistoryPage(Event)
+
+			case "HistoryRequeue":
+
+				Components.HistoryRequeue(Event)
+
+			case "HistoryPick":
+
+				Components.HistoryPick(Event)
+
+			case "Favorite":
+
+				Components.Favorite(Event)
+
+			case "FavoritesPage":
+
+				Components.FavoritesPage(Event)
+
+			case "FavoritesPlay":
+
+				Components.FavoritesPlay(Event)
+
+			case "FavoritesRemove":
+
+				Components.FavoritesRemove(Event)
+
+			case "PlaylistPage":
+
+				Components.PlaylistPage(Event)
+
+			case "PlaylistAppend":
+
+				Components.PlaylistAppend(Event)
+
 			case "Disconnect":
 
 				Components.Disconnect(Event)
@@ -624,17 +818,37 @@ func InitializeHandlers() {
 
 			}()
 
+			Guild := Structs.GetGuild(Event.VoiceState.GuildID, false) // does not create if not found
+
+			if Guild == nil {
+
+				return // No active guild session
+
+			}
+
 			if Event.VoiceState.UserID != Globals.DiscordClient.ApplicationID {
 
-				return // Not our bot
+				// Someone joined or left our channel; check whether we are now alone
+
+				Joined := Event.VoiceState.ChannelID != nil && *Event.VoiceState.ChannelID == Guild.Channels.Voice
+				Left := Event.OldVoiceState.ChannelID != nil && *Event.OldVoiceState.ChannelID == Guild.Channels.Voice
+
+				if (Joined || Left) && !Event.Member.User.Bot {
+
+					Guild.CheckAlone()
+
+				}
+
+				return
 
 			}
 
-			Guild := Structs.GetGuild(Event.VoiceState.GuildID, false) // does not create if not found
+			if Event.VoiceState.ChannelID != nil && *Event.VoiceState.ChannelID != Guild.Channels.Voice {
 
-			if Guild == nil {
+				Guild.Channels.Voice = *Event.VoiceState.ChannelID // moved to another channel
+				Guild.CheckAlone()
 
-				return // No active guild session
+				return
 
 			}
 
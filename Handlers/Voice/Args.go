@@ -30,6 +30,35 @@ func ParseShuffleEnabled(args string, current bool) bool {
 
 }
 
+// ParseShuffleMode picks a shuffle mode out of the arguments; the parser has already mapped each locale's words onto the
+// "smart" and "random" keywords (see Voice.Arguments in the manifest). ok is false when none is named.
+func ParseShuffleMode(args string) (mode string, rest string, ok bool) {
+
+	fields := strings.Fields(strings.ToLower(args))
+
+	for i, field := range fields {
+
+		switch field {
+
+		case Structs.ShuffleSmart:
+			mode = Structs.ShuffleSmart
+
+		case Structs.ShuffleRandom, "normal":
+			mode = Structs.ShuffleRandom
+
+		default:
+			continue
+
+		}
+
+		return mode, strings.Join(append(fields[:i:i], fields[i+1:]...), " "), true
+
+	}
+
+	return "", args, false
+
+}
+
 func ParseRepeatMode(args string, current int) int {
 
 	args = strings.TrimSpace(strings.ToLower(args))
@@ -109,6 +138,124 @@ func ParseReplayPosition(args string) int {
 
 }
 
+// playPositionSuffixes are trailing phrases that pick where a voice play request goes ("play X up next"); a transcript has no separate
+// arguments, so only phrases that don't end song titles count: bare "next" and "now" would queue "Who's Next" or "Right Now" wrong
+var playPositionSuffixes = []struct {
+	phrase   string
+	position string
+}{
+	{"up next", Structs.InsertNext},
+	{"next in line", Structs.InsertNext},
+	{"next in the queue", Structs.InsertNext},
+	{"as the next song", Structs.InsertNext},
+	{"immediately", Structs.InsertNow},
+	{"at the end of the queue", Structs.InsertEnd},
+}
+
+func ParsePlayPosition(args string) (string, Structs.InsertPosition) {
+
+	trimmed := strings.TrimSpace(args)
+	lower := strings.ToLower(trimmed)
+
+	for _, suffix := range playPositionSuffixes {
+
+		if strings.HasSuffix(lower, " "+suffix.phrase) {
+			return strings.TrimSpace(trimmed[:len(trimmed)-len(suffix.phrase)]), Structs.ParsePosition(suffix.position)
+		}
+
+	}
+
+	return trimmed, Structs.PositionEnd
+
+}
+
+// ParseSeekTarget reads where to seek, in milliseconds. "90" and "1 30" (the parser turns "1:30" into "1 30") are absolute,
+// "forward" or "back" with an optional amount moves relative to current, and "start" is the beginning; ok is false when no time was given.
+func ParseSeekTarget(args string, current int64) (int64, bool) {
+
+	fields := strings.Fields(strings.ToLower(args))
+
+	direction := int64(0)
+	total := int64(0)
+	numbers := []int64{}
+	timed := false
+
+	for i, field := range fields {
+
+		switch field {
+
+		case "forward":
+			direction = 1
+
+		case "back":
+			direction = -1
+
+		case "start":
+			return 0, true
+
+		case "minutes", "seconds":
+
+			if len(numbers) == 0 {
+				continue
+			}
+
+			value := numbers[len(numbers)-1]
+			numbers = numbers[:len(numbers)-1]
+
+			if field == "minutes" {
+				total += value * 60000
+			} else {
+				total += value * 1000
+			}
+
+			timed = true
+
+		case "a", "an", "one":
+
+			if i+1 < len(fields) && (fields[i+1] == "minutes" || fields[i+1] == "seconds") {
+				numbers = append(numbers, 1) // "a minute"
+			}
+
+		default:
+
+			if value, err := strconv.Atoi(strings.TrimSuffix(field, "s")); err == nil && value >= 0 {
+				numbers = append(numbers, int64(value))
+			}
+
+		}
+
+	}
+
+	// Numbers left without a unit: a lone one is seconds, a pair is minutes and seconds
+
+	switch len(numbers) {
+
+	case 0:
+
+	case 1:
+		total += numbers[0] * 1000
+
+	default:
+		total += numbers[len(numbers)-2]*60000 + numbers[len(numbers)-1]*1000
+
+	}
+
+	timed = timed || len(numbers) > 0
+
+	if direction != 0 {
+
+		if !timed {
+			total = Structs.SeekStepMs
+		}
+
+		return current + direction*total, true
+
+	}
+
+	return total, timed
+
+}
+
 func ParseAutoplayEnabled(args string, current bool) bool {
 	return ParseShuffleEnabled(args, current)
 }
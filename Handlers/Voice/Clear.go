@@ -0,0 +1,92 @@
+package Voice
+
+import (
+	"Synthara-Redux/Receive"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Clear empties the upcoming queue after a spoken yes and keeps the current song playing
+func Clear(GuildID, UserID snowflake.ID, _ string) {
+
+	Guild, Locale := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return
+
+	}
+
+	if !requireVoice(Guild, GuildID, UserID, Locale) {
+
+		return
+
+	}
+
+	Guild.ResetInactivityTimer()
+
+	Upcoming := len(Guild.Queue.Upcoming)
+
+	if Upcoming == 0 {
+
+		voiceRespond(GuildID, "QueueAlreadyEmpty")
+		return
+
+	}
+
+	Prompt := voiceText(GuildID, "ConfirmClearOne")
+
+	if Upcoming > 1 {
+
+		Prompt = voiceText(GuildID, "ConfirmClearAll", Upcoming)
+
+	}
+
+	// Ask first: a misheard "clear" would otherwise wipe the queue. Without a voice session to answer through, clear right away
+
+	Asked := Receive.Confirm(GuildID, UserID, Prompt, func(Confirmed bool) {
+
+		if !Confirmed {
+
+			voiceRespond(GuildID, "KeepQueue")
+			return
+
+		}
+
+		clearUpcoming(Guild, GuildID, UserID)
+
+	})
+
+	if !Asked {
+
+		clearUpcoming(Guild, GuildID, UserID)
+
+	}
+
+}
+
+func clearUpcoming(Guild *Structs.Guild, GuildID, UserID snowflake.ID) {
+
+	Removed := Guild.Queue.ClearUpcoming()
+
+	if Removed == 0 {
+
+		voiceRespond(GuildID, "QueueAlreadyEmpty")
+		return
+
+	}
+
+	notifyLocalizedWithMember(Guild, UserID, "Embeds.Voice.Cleared.Title", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
+
+	if Removed == 1 {
+
+		voiceRespond(GuildID, "ClearedOne")
+		return
+
+	}
+
+	voiceRespond(GuildID, "ClearedMany", Removed)
+
+}
@@ -111,7 +111,7 @@ func requireVoice(Guild *Structs.Guild, GuildID, UserID snowflake.ID, Locale str
 	if Embed := Validation.VoiceStateError(GuildID, UserID, Locale); Embed != nil {
 
 		notifyValidationEmbed(Guild, Embed)
-		voiceRespond(GuildID, "You're not in a voice channel.")
+		voiceRespond(GuildID, "NotInVoice")
 
 		return false
 
@@ -121,9 +121,19 @@ func requireVoice(Guild *Structs.Guild, GuildID, UserID snowflake.ID, Locale str
 
 }
 
-func voiceRespond(GuildID snowflake.ID, text string) {
+// voiceRespond speaks Voice.Responses.<Key> in the guild's language
+func voiceRespond(GuildID snowflake.ID, Key string, Args ...any) {
 
-	Receive.EmitVoiceResponse(GuildID, text)
+	Receive.EmitVoiceResponse(GuildID, voiceText(GuildID, Key, Args...))
+
+}
+
+// voiceText formats Voice.Responses.<Key> in the guild's language, for prompts handed to Receive.Confirm
+func voiceText(GuildID snowflake.ID, Key string, Args ...any) string {
+
+	_, Locale := guildAndLocale(GuildID)
+
+	return Localizations.GetFormat("Voice.Responses."+Key, Locale, Args...)
 
 }
 
@@ -139,22 +149,7 @@ func notifyCurrentSongWithMember(Guild *Structs.Guild, UserID snowflake.ID) {
 
 	Song := Guild.Queue.Current
 
-	Playing := Guild.Queue.State != Structs.StatePaused
-
-	State := Tidal.QueueInfo{
-
-		Playing: Playing,
-
-		GuildID: Guild.ID,
-
-		SongPosition: 0,
-
-		TotalPrevious: len(Guild.Queue.Previous),
-		TotalUpcoming: len(Guild.Queue.Upcoming),
-
-		Locale: Locale,
-
-	}
+	State := Guild.QueueInfo(Guild.Queue.State != Structs.StatePaused, 0, Locale)
 
 	Embed := Song.Embed(State)
 
@@ -164,7 +159,7 @@ func notifyCurrentSongWithMember(Guild *Structs.Guild, UserID snowflake.ID) {
 
 	_, ErrSend := Globals.DiscordClient.Rest.CreateMessage(Guild.Channels.Text,
 
-		discord.NewMessageCreate().AddEmbeds(Embed).AddActionRow(Song.Buttons(State)...),
+		discord.NewMessageCreate().AddEmbeds(Embed).AddComponents(Song.Components(State)...),
 	)
 
 	if ErrSend != nil {
@@ -185,22 +180,7 @@ func notifyPlayResult(Guild *Structs.Guild, Song *Tidal.Song, Pos int, UserID sn
 
 	Locale := Guild.Locale.Code()
 
-	Playing := Guild.Queue.State != Structs.StatePaused
-
-	State := Tidal.QueueInfo{
-
-		Playing: Playing,
-
-		GuildID: Guild.ID,
-
-		SongPosition: Pos,
-
-		TotalPrevious: len(Guild.Queue.Previous),
-		TotalUpcoming: len(Guild.Queue.Upcoming),
-
-		Locale: Locale,
-
-	}
+	State := Guild.QueueInfo(Guild.Queue.State != Structs.StatePaused, Pos, Locale)
 
 	Embed := Song.Embed(State)
 
@@ -212,7 +192,7 @@ func notifyPlayResult(Guild *Structs.Guild, Song *Tidal.Song, Pos int, UserID sn
 
 	_, ErrSend := Globals.DiscordClient.Rest.CreateMessage(Guild.Channels.Text,
 
-		discord.NewMessageCreate().AddEmbeds(Embed).AddActionRow(Song.Buttons(State)...),
+		discord.NewMessageCreate().AddEmbeds(Embed).AddComponents(Song.Components(State)...),
 	)
 
 	if ErrSend != nil {
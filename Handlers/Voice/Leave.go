@@ -1,11 +1,14 @@
 package Voice
 
 import (
+	"Synthara-Redux/Receive"
+	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
 
 	"github.com/disgoorg/snowflake/v2"
 )
 
+// Leave disconnects from voice; while something is playing it asks for a spoken yes first
 func Leave(GuildID, UserID snowflake.ID, _ string) {
 
 	Guild, _ := guildAndLocale(GuildID)
@@ -18,6 +21,36 @@ func Leave(GuildID, UserID snowflake.ID, _ string) {
 
 	Guild.ResetInactivityTimer()
 
+	if Guild.Queue.Current == nil {
+
+		leave(Guild, UserID)
+		return
+
+	}
+
+	Asked := Receive.Confirm(GuildID, UserID, voiceText(GuildID, "ConfirmLeave"), func(Confirmed bool) {
+
+		if !Confirmed {
+
+			voiceRespond(GuildID, "Stay")
+			return
+
+		}
+
+		leave(Guild, UserID)
+
+	})
+
+	if !Asked {
+
+		leave(Guild, UserID)
+
+	}
+
+}
+
+func leave(Guild *Structs.Guild, UserID snowflake.ID) {
+
 	Guild.Cleanup(true)
 
 	notifyLocalizedWithMember(Guild, UserID, "Commands.Leave.Success.Title", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Notifications", Utils.PRIMARY)
@@ -0,0 +1,61 @@
+package Voice
+
+import (
+	"fmt"
+
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Like adds the current song to the speaker's favorites
+func Like(GuildID, UserID snowflake.ID, _ string) {
+
+	Guild, _ := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return
+
+	}
+
+	Guild.ResetInactivityTimer()
+
+	Song := Guild.Queue.Current
+
+	if Song == nil {
+
+		voiceRespond(GuildID, "NothingPlaying")
+		return
+
+	}
+
+	User, ErrUser := Structs.GetUser(UserID.String())
+
+	if ErrUser != nil {
+
+		voiceRespond(GuildID, "SomethingWentWrong")
+		return
+
+	}
+
+	if User.IsLiked(Song.TidalID) {
+
+		voiceRespond(GuildID, "AlreadyLiked", Song.Title)
+		return
+
+	}
+
+	if ErrLike := User.Like(Song); ErrLike != nil {
+
+		Utils.Logger.Error("Voice", fmt.Sprintf("Error updating favorites for user %s: %s", User.DiscordID, ErrLike.Error()))
+		voiceRespond(GuildID, "LikeFailed")
+
+		return
+
+	}
+
+	voiceRespond(GuildID, "Liked", Song.Title)
+
+}
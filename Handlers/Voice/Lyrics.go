@@ -0,0 +1,83 @@
+package Voice
+
+import (
+	"Synthara-Redux/Handlers/Commands"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Lyrics reads out the line of the current song that is being sung right now
+func Lyrics(GuildID, UserID snowflake.ID, _ string) {
+
+	Guild, _ := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return
+
+	}
+
+	Guild.ResetInactivityTimer()
+
+	Song := Guild.Queue.Current
+
+	if Song == nil {
+
+		voiceRespond(GuildID, "NothingPlaying")
+		return
+
+	}
+
+	Found, ErrLyrics := Commands.SongLyrics(Song)
+
+	if ErrLyrics != nil {
+
+		voiceRespond(GuildID, "NoLyrics")
+		return
+
+	}
+
+	Position := Guild.Position()
+
+	Line := ""
+	Timed := false
+
+	for _, Entry := range Found.Lyrics {
+
+		if Entry.Time > 0 {
+
+			Timed = true
+
+		}
+
+		if int64(Entry.Time) > Position {
+
+			break
+
+		}
+
+		if Entry.Text != "" {
+
+			Line = Entry.Text
+
+		}
+
+	}
+
+	if !Timed {
+
+		voiceRespond(GuildID, "LyricsUntimed")
+		return
+
+	}
+
+	if Line == "" {
+
+		voiceRespond(GuildID, "NoLyricsLine")
+		return
+
+	}
+
+	voiceRespond(GuildID, "LyricsLine", Line)
+
+}
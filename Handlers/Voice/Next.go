@@ -2,6 +2,7 @@ package Voice
 
 import (
 	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
 
 	"github.com/disgoorg/snowflake/v2"
 )
@@ -24,12 +25,37 @@ func Next(GuildID, UserID snowflake.ID, _ string) {
 
 	Guild.ResetInactivityTimer()
 
+	Vote := Guild.RequestSkip(UserID, "")
+
+	if Vote.Denied {
+
+		notifyValidationEmbed(Guild, Validation.SkipVoteError(Vote, Locale))
+		voiceRespond(GuildID, "NoSkipPermission")
+
+		return
+
+	}
+
+	if Vote.NotListening {
+
+		voiceRespond(GuildID, "NotListening")
+		return
+
+	}
+
+	if !Vote.Skip {
+
+		voiceRespond(GuildID, "VoteCounted", Vote.Votes, Vote.Needed)
+		return
+
+	}
+
 	Advanced, Ended := Guild.Queue.Next(false)
 
 	if Ended {
 
 		notifyLocalizedWithMember(Guild, UserID, "Embeds.Notifications.QueueEnded.Title", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Notifications", Utils.PRIMARY)
-		voiceRespond(GuildID, "The queue has ended.")
+		voiceRespond(GuildID, "QueueEnded")
 
 		return
 
@@ -38,7 +64,7 @@ func Next(GuildID, UserID snowflake.ID, _ string) {
 	if !Advanced {
 
 		notifyLocalized(Guild, "Commands.Next.Error.NoNextSong.Title", "Commands.Next.Error.NoNextSong.Description", "Embeds.Categories.Error", Utils.ERROR)
-		voiceRespond(GuildID, "There's nothing next in the queue.")
+		voiceRespond(GuildID, "NothingNext")
 
 		return
 
@@ -0,0 +1,40 @@
+package Voice
+
+import (
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// NowPlaying answers "what's playing" out loud without posting to the text channel
+func NowPlaying(GuildID, UserID snowflake.ID, _ string) {
+
+	Guild, _ := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return
+
+	}
+
+	Guild.ResetInactivityTimer()
+
+	Song := Guild.Queue.Current
+
+	if Song == nil {
+
+		voiceRespond(GuildID, "NothingPlaying")
+		return
+
+	}
+
+	if len(Song.Artists) == 0 {
+
+		voiceRespond(GuildID, "ThisIs", Song.Title)
+		return
+
+	}
+
+	voiceRespond(GuildID, "ThisIsBy", Song.Title, strings.Join(Song.Artists, ", "))
+
+}
@@ -22,7 +22,7 @@ func Pause(GuildID, UserID snowflake.ID, _ string) {
 	if Guild.Queue.State != Structs.StatePlaying {
 
 		notifyLocalized(Guild, "Embeds.Errors.NoActiveSession.Title", "Embeds.Errors.NoActiveSession.Description", "Embeds.Categories.Error", Utils.ERROR)
-		voiceRespond(GuildID, "Playback is already paused.")
+		voiceRespond(GuildID, "AlreadyPaused")
 
 		return
 
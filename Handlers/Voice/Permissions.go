@@ -0,0 +1,53 @@
+package Voice
+
+import (
+	"Synthara-Redux/Receive"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Validation"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// commandCapabilities maps voice command verbs to the capability they require; next and seek are checked by vote-skip instead
+var commandCapabilities = map[string]string{
+
+	Receive.CommandVolume:  Structs.CapabilityVolume,
+	Receive.CommandSpeed:   Structs.CapabilityEffects,
+	Receive.CommandReverb:  Structs.CapabilityEffects,
+	Receive.CommandClear:   Structs.CapabilityClear,
+	Receive.CommandSave:    Structs.CapabilitySaveLoad,
+	Receive.CommandShuffle: Structs.CapabilityMove,
+
+}
+
+// Authorize checks a voice command against the guild's permission policy, announcing a denial
+func Authorize(GuildID, UserID snowflake.ID, Command string) bool {
+
+	Capability, Restricted := commandCapabilities[Command]
+
+	if !Restricted {
+
+		return true
+
+	}
+
+	Guild, Locale := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return true // handlers report the missing session themselves
+
+	}
+
+	if Embed := Validation.CapabilityError(GuildID, UserID, Capability, Locale); Embed != nil {
+
+		notifyValidationEmbed(Guild, Embed)
+		voiceRespond(GuildID, "NoPermission")
+
+		return false
+
+	}
+
+	return true
+
+}
@@ -10,6 +10,7 @@ import (
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
 
 	"github.com/disgoorg/snowflake/v2"
 )
@@ -33,7 +34,7 @@ func Play(GuildID, UserID snowflake.ID, Args string) {
 	if Args == "" {
 
 		notifyLocalized(Guild, "Commands.Play.Error.NoQuery.Title", "Commands.Play.Error.NoQuery.Description", "Embeds.Categories.Error", Utils.ERROR)
-		voiceRespond(GuildID, "What would you like me to play?")
+		voiceRespond(GuildID, "PlayWhat")
 
 		return
 
@@ -44,7 +45,7 @@ func Play(GuildID, UserID snowflake.ID, Args string) {
 	if !VoiceStateExists || VoiceState.ChannelID == nil {
 
 		notifyLocalized(Guild, "Commands.Play.Error.NotInVoiceChannel.Title", "Commands.Play.Error.NotInVoiceChannel.Description", "Embeds.Categories.Error", Utils.ERROR)
-		voiceRespond(GuildID, "You're not in a voice channel.")
+		voiceRespond(GuildID, "NotInVoice")
 
 		return
 
@@ -55,18 +56,33 @@ func Play(GuildID, UserID snowflake.ID, Args string) {
 	if ErrorConnecting != nil {
 
 		notify(Guild, Localizations.Get("Commands.Play.Error.FailedToConnect.Title", Locale), Localizations.GetFormat("Commands.Play.Error.FailedToConnect.Description", Locale, ErrorConnecting.Error()), Localizations.Get("Embeds.Categories.Error", Locale), Utils.ERROR)
-		voiceRespond(GuildID, "I couldn't join your voice channel.")
+		voiceRespond(GuildID, "JoinFailed")
 
 		return
 
 	}
 
+	Args, Position := ParsePlayPosition(Args)
+
+	if Capability := Position.Capability(); Capability != "" {
+
+		if Embed := Validation.CapabilityError(GuildID, UserID, Capability, Locale); Embed != nil {
+
+			notifyValidationEmbed(Guild, Embed)
+			voiceRespond(GuildID, "NoPermission")
+
+			return
+
+		}
+
+	}
+
 	URI, ErrRoute := APIs.Route(Args)
 
 	if ErrRoute != nil {
 
 		notify(Guild, Localizations.Get("Commands.Play.Error.InvalidInput.Title", Locale), Localizations.GetFormat("Commands.Play.Error.InvalidInput.Description", Locale, ErrRoute.Error()), Localizations.Get("Embeds.Categories.Error", Locale), Utils.ERROR)
-		voiceRespond(GuildID, "I couldn't find that.")
+		voiceRespond(GuildID, "NotFound")
 
 		return
 
@@ -74,12 +90,21 @@ func Play(GuildID, UserID snowflake.ID, Args string) {
 
 	Mention := fmt.Sprintf("<@%s>", UserID)
 
-	Song, Pos, ErrHandle := Guild.HandleURI(URI, Mention)
+	Song, Pos, ErrHandle := Guild.HandleURIAt(URI, Mention, Position)
+
+	if QuotaErr, IsQuota := ErrHandle.(*Structs.QuotaError); IsQuota {
+
+		notify(Guild, Localizations.Get("Embeds.Errors.Quota.Title", Locale), QuotaErr.Localized(Locale), Localizations.Get("Embeds.Categories.Error", Locale), Utils.ERROR)
+		voiceRespond(GuildID, "QuotaRejected", QuotaErr.Localized(Locale))
+
+		return
+
+	}
 
 	if ErrHandle != nil {
 
 		notify(Guild, Localizations.Get("Commands.Play.Error.FailedToHandle.Title", Locale), Localizations.GetFormat("Commands.Play.Error.FailedToHandle.Description", Locale, ErrHandle.Error()), Localizations.Get("Embeds.Categories.Error", Locale), Utils.ERROR)
-		voiceRespond(GuildID, "Something went wrong.")
+		voiceRespond(GuildID, "SomethingWentWrong")
 
 		return
 
@@ -89,15 +114,15 @@ func Play(GuildID, UserID snowflake.ID, Args string) {
 
 	if Pos == 0 {
 
-		voiceRespond(GuildID, "Now playing "+Song.Title+".")
+		voiceRespond(GuildID, "NowPlaying", Song.Title)
 
 	} else if Pos == 1 {
 
-		voiceRespond(GuildID, "Got it, "+Song.Title+" is next in the queue.")
+		voiceRespond(GuildID, "QueuedNext", Song.Title)
 
 	} else {
 
-		voiceRespond(GuildID, "Got it, "+Song.Title+" is "+strconv.Itoa(Pos)+" songs away.")
+		voiceRespond(GuildID, "QueuedAway", Song.Title, Pos)
 
 	}
 
@@ -1,8 +1,6 @@
 package Voice
 
 import (
-	"fmt"
-
 	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
 
@@ -13,17 +11,11 @@ import (
 
 func Speed(guildID, userID snowflake.ID, args string) {
 
-	runVoiceIntSetting(guildID, userID, args, ParseSpeedMilli, (*Structs.Guild).SetSpeed, func(g *Structs.Guild) int { return g.Features.SpeedMilli }, "Commands.Speed.Title",
-
-		func(g *Structs.Guild) string {
-
-			return fmt.Sprintf("Playback speed is %s.", Structs.FormatSpeedLabel(g.Features.SpeedMilli))
-
-		},
+	runVoiceIntSetting(guildID, userID, args, ParseSpeedMilli, (*Structs.Guild).SetSpeed, func(g *Structs.Guild) int { return g.Features.SpeedMilli }, "Commands.Speed.Title", "SpeedStatus", "SpeedSet",
 
-		func(g *Structs.Guild) string {
+		func(g *Structs.Guild) any {
 
-			return fmt.Sprintf("Playback speed set to %s.", Structs.FormatSpeedLabel(g.Features.SpeedMilli))
+			return Structs.FormatSpeedLabel(g.Features.SpeedMilli)
 
 		},
 
@@ -33,24 +25,18 @@ func Speed(guildID, userID snowflake.ID, args string) {
 
 func Reverb(guildID, userID snowflake.ID, args string) {
 
-	runVoiceIntSetting(guildID, userID, args, ParseReverbPercent, (*Structs.Guild).SetReverb, func(g *Structs.Guild) int { return g.Features.Reverb }, "Commands.Reverb.Title",
-
-		func(g *Structs.Guild) string {
-
-			return fmt.Sprintf("Reverb is %d percent.", g.Features.Reverb)
-
-		},
+	runVoiceIntSetting(guildID, userID, args, ParseReverbPercent, (*Structs.Guild).SetReverb, func(g *Structs.Guild) int { return g.Features.Reverb }, "Commands.Reverb.Title", "ReverbStatus", "ReverbSet",
 
-		func(g *Structs.Guild) string {
+		func(g *Structs.Guild) any {
 
-			return fmt.Sprintf("Reverb set to %d percent.", g.Features.Reverb)
+			return g.Features.Reverb
 
 		},
 
 	)
 }
 
-func runVoiceIntSetting(guildID, userID snowflake.ID, args string, parse func(string, int) (int, bool), apply func(*Structs.Guild, int) int, current func(*Structs.Guild) int, titleKey string, statusLine, confirmLine func(*Structs.Guild) string) {
+func runVoiceIntSetting(guildID, userID snowflake.ID, args string, parse func(string, int) (int, bool), apply func(*Structs.Guild, int) int, current func(*Structs.Guild) int, titleKey, statusKey, confirmKey string, spoken func(*Structs.Guild) any) {
 
 	guild, locale := guildAndLocale(guildID)
 
@@ -66,7 +52,7 @@ func runVoiceIntSetting(guildID, userID snowflake.ID, args string, parse func(st
 
 	if !ok {
 
-		voiceRespond(guildID, statusLine(guild))
+		voiceRespond(guildID, statusKey, spoken(guild))
 		return
 
 	}
@@ -74,6 +60,6 @@ func runVoiceIntSetting(guildID, userID snowflake.ID, args string, parse func(st
 	apply(guild, level)
 	notifyLocalizedWithMember(guild, userID, titleKey, "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
 
-	voiceRespond(guildID, confirmLine(guild))
+	voiceRespond(guildID, confirmKey, spoken(guild))
 
 }
@@ -0,0 +1,47 @@
+package Voice
+
+import (
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Remove drops the speaker's most recently queued upcoming song
+func Remove(GuildID, UserID snowflake.ID, _ string) {
+
+	Guild, Locale := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return
+
+	}
+
+	if !requireVoice(Guild, GuildID, UserID, Locale) {
+
+		return
+
+	}
+
+	Guild.ResetInactivityTimer()
+
+	for Index := len(Guild.Queue.Upcoming) - 1; Index >= 0; Index-- {
+
+		Song := Guild.Queue.Upcoming[Index]
+
+		if !Guild.IsRequestor(Song, UserID, "") || !Guild.Queue.Remove(Index) {
+
+			continue
+
+		}
+
+		notifyLocalizedWithMember(Guild, UserID, "Embeds.Voice.Removed.Title", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
+		voiceRespond(GuildID, "Removed", Song.Title)
+
+		return
+
+	}
+
+	voiceRespond(GuildID, "NothingToRemove")
+
+}
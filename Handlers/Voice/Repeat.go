@@ -30,29 +30,29 @@ func Repeat(GuildID, UserID snowflake.ID, Args string) {
 	Guild.Features.Repeat = Mode
 
 	var TitleKey string
-	var TTSResponse string
+	var ResponseKey string
 
 	switch Mode {
 
 	case Structs.RepeatOne:
 
 		TitleKey = "Commands.Repeat.One.Title"
-		TTSResponse = "Repeating this song."
+		ResponseKey = "RepeatOne"
 
 	case Structs.RepeatAll:
 
 		TitleKey = "Commands.Repeat.All.Title"
-		TTSResponse = "Repeating the queue."
+		ResponseKey = "RepeatAll"
 
 	default:
 
 		TitleKey = "Commands.Repeat.Off.Title"
-		TTSResponse = "Repeat is off."
+		ResponseKey = "RepeatOff"
 		Guild.Features.Repeat = Structs.RepeatOff
 
 	}
 
 	notifyLocalizedWithMember(Guild, UserID, TitleKey, "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
-	voiceRespond(GuildID, TTSResponse)
+	voiceRespond(GuildID, ResponseKey)
 
 }
@@ -0,0 +1,74 @@
+package Voice
+
+import (
+	"strings"
+
+	"Synthara-Redux/Globals"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Requester answers "who requested this" out loud
+func Requester(GuildID, UserID snowflake.ID, _ string) {
+
+	Guild, _ := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return
+
+	}
+
+	Guild.ResetInactivityTimer()
+
+	Song := Guild.Queue.Current
+
+	if Song == nil {
+
+		voiceRespond(GuildID, "NothingPlaying")
+		return
+
+	}
+
+	if Song.Internal.Suggested {
+
+		voiceRespond(GuildID, "PickedByAutoplay")
+		return
+
+	}
+
+	Name := requestorName(GuildID, Song.Internal.Requestor)
+
+	if Name == "" {
+
+		voiceRespond(GuildID, "RequestorUnknown")
+		return
+
+	}
+
+	voiceRespond(GuildID, "RequestedBy", Name)
+
+}
+
+// requestorName turns a stored requestor (a mention, an ID or a username) into a speakable display name
+func requestorName(GuildID snowflake.ID, Requestor string) string {
+
+	Raw := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(Requestor, "<@"), "!"), ">")
+
+	ID, ErrParse := snowflake.Parse(Raw)
+
+	if ErrParse != nil {
+
+		return Requestor
+
+	}
+
+	if Member, Cached := Globals.DiscordClient.Caches.Member(GuildID, ID); Cached {
+
+		return Member.EffectiveName()
+
+	}
+
+	return ""
+
+}
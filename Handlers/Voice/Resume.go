@@ -22,7 +22,7 @@ func Resume(GuildID, UserID snowflake.ID, _ string) {
 	if Guild.Queue.State != Structs.StatePaused {
 
 		notifyLocalized(Guild, "Embeds.Errors.NoActiveSession.Title", "Embeds.Errors.NoActiveSession.Description", "Embeds.Categories.Error", Utils.ERROR)
-		voiceRespond(GuildID, "There's nothing to resume.")
+		voiceRespond(GuildID, "NothingToResume")
 
 		return
 
@@ -0,0 +1,76 @@
+package Voice
+
+import (
+	"errors"
+
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Save stores the queue under the spoken name ("save this queue as road trip")
+func Save(GuildID, UserID snowflake.ID, Args string) {
+
+	Guild, Locale := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return
+
+	}
+
+	if !requireVoice(Guild, GuildID, UserID, Locale) {
+
+		return
+
+	}
+
+	Guild.ResetInactivityTimer()
+
+	Name, ErrName := Structs.NormalizeSavedQueueName(Args)
+
+	if errors.Is(ErrName, Structs.ErrSavedQueueNameTooLong) {
+
+		voiceRespond(GuildID, "NameTooLong")
+		return
+
+	}
+
+	if ErrName != nil {
+
+		voiceRespond(GuildID, "SaveName")
+		return
+
+	}
+
+	Snapshot, ErrSnapshot := Structs.SnapshotFromQueue(&Guild.Queue)
+
+	if ErrSnapshot != nil {
+
+		voiceRespond(GuildID, "NothingToSave")
+		return
+
+	}
+
+	if ErrSave := Structs.SaveGuildQueue(GuildID.String(), Name, Snapshot); ErrSave != nil {
+
+		if errors.Is(ErrSave, Structs.ErrSavedQueueLimit) {
+
+			voiceRespond(GuildID, "TooManySaved")
+			return
+
+		}
+
+		voiceRespond(GuildID, "SaveFailed")
+		return
+
+	}
+
+	SongCount := Structs.SavedQueueSongCount(Snapshot)
+
+	notify(Guild, Localizations.Get("Commands.Save.Success.Title", Locale), Localizations.GetFormat("Commands.Save.Success.Description", Locale, Name, SongCount, Localizations.Pluralize("Song", SongCount, Locale)), Localizations.Get("Embeds.Categories.Playback", Locale), Utils.PRIMARY)
+	voiceRespond(GuildID, "Saved", Name)
+
+}
@@ -0,0 +1,82 @@
+package Voice
+
+import (
+	"fmt"
+
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Utils"
+	"Synthara-Redux/Validation"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Seek moves within the current song ("jump to 1:30", "skip ahead 30 seconds", "rewind")
+func Seek(GuildID, UserID snowflake.ID, Args string) {
+
+	Guild, Locale := guildAndLocale(GuildID)
+
+	if Guild == nil {
+
+		return
+
+	}
+
+	if !requireVoice(Guild, GuildID, UserID, Locale) {
+
+		return
+
+	}
+
+	Guild.ResetInactivityTimer()
+
+	if Guild.Queue.Current == nil {
+
+		voiceRespond(GuildID, "NothingPlaying")
+		return
+
+	}
+
+	Target, OK := ParseSeekTarget(Args, Guild.Position())
+
+	if !OK {
+
+		voiceRespond(GuildID, "SeekWhere")
+		return
+
+	}
+
+	// Seeking skips audio everyone hears, so it follows the same rule as jumping ahead in the queue
+
+	Vote := Guild.RequestJump(UserID)
+
+	if Vote.VoteRequired {
+
+		voiceRespond(GuildID, "SeekVoteSkip")
+		return
+
+	}
+
+	if !Vote.Skip {
+
+		notifyValidationEmbed(Guild, Validation.SkipVoteError(Vote, Locale))
+		voiceRespond(GuildID, "NoPermission")
+
+		return
+
+	}
+
+	Position, ErrSeek := Guild.SeekTo(Target)
+
+	if ErrSeek != nil {
+
+		Utils.Logger.Error("Voice", fmt.Sprintf("Error seeking in guild %s: %s", GuildID, ErrSeek.Error()))
+		voiceRespond(GuildID, "SomethingWentWrong")
+
+		return
+
+	}
+
+	notifyLocalizedWithMember(Guild, UserID, "Embeds.Voice.Seeked.Title", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
+	voiceRespond(GuildID, "Seeked", Tidal.FormatDuration(int(Position/1000)))
+
+}
@@ -1,6 +1,7 @@
 package Voice
 
 import (
+	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -24,20 +25,38 @@ func Shuffle(GuildID, UserID snowflake.ID, Args string) {
 
 	Guild.ResetInactivityTimer()
 
-	Enabled := ParseShuffleEnabled(Args, Guild.Features.Shuffle)
+	Mode, Rest, HasMode := ParseShuffleMode(Args)
+
+	Enabled := ParseShuffleEnabled(Rest, Guild.Features.Shuffle)
+
+	if HasMode {
+
+		Guild.Features.ShuffleMode = Mode
+		Enabled = Rest == "" || Enabled // naming a mode alone turns shuffle on
+
+	}
 
 	Guild.Features.Shuffle = Enabled
 
+	if Enabled && Guild.Features.ShuffleMode == Structs.ShuffleSmart {
+
+		notifyLocalizedWithMember(Guild, UserID, "Commands.Shuffle.Enabled.SmartTitle", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
+		voiceRespond(GuildID, "SmartShuffleOn")
+
+		return
+
+	}
+
 	if Enabled {
 
 		notifyLocalizedWithMember(Guild, UserID, "Commands.Shuffle.Enabled.Title", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
-		voiceRespond(GuildID, "Shuffle is on.")
+		voiceRespond(GuildID, "ShuffleOn")
 
 		return
 
 	}
 
 	notifyLocalizedWithMember(Guild, UserID, "Commands.Shuffle.Disabled.Title", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
-	voiceRespond(GuildID, "Shuffle is off.")
+	voiceRespond(GuildID, "ShuffleOff")
 
 }
@@ -1,8 +1,6 @@
 package Voice
 
 import (
-	"fmt"
-
 	"Synthara-Redux/Utils"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -30,7 +28,7 @@ func Volume(GuildID, UserID snowflake.ID, Args string) {
 
 	if !OK {
 
-		voiceRespond(GuildID, fmt.Sprintf("Volume is %d percent.", Guild.Features.Volume))
+		voiceRespond(GuildID, "VolumeStatus", Guild.Features.Volume)
 		return
 
 	}
@@ -38,6 +36,6 @@ func Volume(GuildID, UserID snowflake.ID, Args string) {
 	Guild.SetVolume(Level)
 
 	notifyLocalizedWithMember(Guild, UserID, "Commands.Volume.Title", "Embeds.NowPlaying.AddedByMemberViaVoice", "Embeds.Categories.Playback", Utils.PRIMARY)
-	voiceRespond(GuildID, fmt.Sprintf("Volume set to %d percent.", Guild.Features.Volume))
+	voiceRespond(GuildID, "VolumeSet", Guild.Features.Volume)
 
 }
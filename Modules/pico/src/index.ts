@@ -2,12 +2,20 @@ import { accessSync } from "node:fs";
 import { dirname, join } from "node:path";
 import { fileURLToPath } from "node:url";
 
-import { opClose, opOpen, opPcm, readFrame, writeWake } from "./protocol.js";
+import { opClose, opConfigure, opOpen, opPcm, readFrame, writeWake } from "./protocol.js";
 import { StreamDetector } from "./streamDetector.js";
 
 const rootDir = dirname(fileURLToPath(import.meta.url));
 const modelPath = join(rootDir, "..", "model", "synthara.ppn");
-const sensitivity = 0.85; // higher = more sensitive, lower = less sensitive
+const defaultSensitivity = 0.85; // higher = more sensitive, lower = less sensitive
+
+// Per-stream wake word: a keyword file and sensitivity, set by the bot for guilds with a custom wake phrase
+interface StreamConfig {
+
+  keyword: string;
+  sensitivity: number;
+
+}
 
 try {
 
@@ -21,6 +29,7 @@ try {
 }
 
 const streams = new Map<string, StreamDetector>();
+const configs = new Map<string, StreamConfig>();
 
 const stdinChunks: Buffer[] = [];
 let stdinOffset = 0;
@@ -51,6 +60,63 @@ function pcmFrom(frame: Buffer): Int16Array {
   return samples;
 }
 
+function configFrom(frame: Buffer): StreamConfig {
+
+  const idLen = frame.readUInt16BE(1);
+  const payloadLen = frame.readUInt32BE(3 + idLen);
+
+  const start = 7 + idLen;
+  const config = { keyword: "", sensitivity: defaultSensitivity };
+
+  try {
+
+    const parsed = JSON.parse(frame.subarray(start, start + payloadLen).toString("utf8"));
+
+    if (typeof parsed.keyword === "string") {
+
+      config.keyword = parsed.keyword;
+
+    }
+
+    if (typeof parsed.sensitivity === "number" && parsed.sensitivity > 0 && parsed.sensitivity <= 1) {
+
+      config.sensitivity = parsed.sensitivity;
+
+    }
+
+  } catch {
+
+    process.stderr.write("pico: invalid configure payload\n");
+
+  }
+
+  return config;
+
+}
+
+function createDetector(id: string): StreamDetector {
+
+  const config = configs.get(id);
+
+  if (config && config.keyword !== "") {
+
+    try {
+
+      accessSync(config.keyword);
+      return new StreamDetector(config.keyword, config.sensitivity);
+
+    } catch {
+
+      process.stderr.write("pico: missing keyword " + config.keyword + ", using default\n");
+
+    }
+
+  }
+
+  return new StreamDetector(modelPath, config ? config.sensitivity : defaultSensitivity);
+
+}
+
 function openStream(id: string): void {
 
   if (streams.has(id)) {
@@ -59,7 +125,22 @@ function openStream(id: string): void {
 
   }
 
-  streams.set(id, new StreamDetector(modelPath, sensitivity));
+  streams.set(id, createDetector(id));
+
+}
+
+function configureStream(id: string, config: StreamConfig): void {
+
+  configs.set(id, config);
+
+  const detector = streams.get(id);
+
+  if (detector) {
+
+    detector.close();
+    streams.set(id, createDetector(id));
+
+  }
 
 }
 
@@ -74,6 +155,8 @@ function closeStream(id: string): void {
 
   }
 
+  configs.delete(id);
+
 }
 
 function feedStream(id: string, pcm: Int16Array): void {
@@ -82,7 +165,7 @@ function feedStream(id: string, pcm: Int16Array): void {
 
   if (!detector) {
 
-    detector = new StreamDetector(modelPath, sensitivity);
+    detector = createDetector(id);
     streams.set(id, detector);
 
   }
@@ -117,6 +200,11 @@ function handleFrame(frame: Buffer): void {
       closeStream(id);
       break;
 
+    case opConfigure:
+
+      configureStream(id, configFrom(frame));
+      break;
+
     default:
 
       break;
@@ -2,6 +2,13 @@ export const opOpen = 1;
 export const opPcm = 2;
 export const opClose = 3;
 export const opWake = 4;
+export const opConfigure = 5;
+
+function hasPayload(op: number): boolean {
+
+  return op === opPcm || op === opConfigure;
+
+}
 
 export function readFrame(chunks: Buffer[], offset: number): { frame: Buffer | null; offset: number } {
 
@@ -12,7 +19,7 @@ export function readFrame(chunks: Buffer[], offset: number): { frame: Buffer | n
   const op = flat[0];
   const idLen = flat.readUInt16BE(1);
 
-  if (op === opPcm) {
+  if (hasPayload(op)) {
 
     if (flat.length < 3 + idLen + 4) return { frame: null, offset };
 
@@ -0,0 +1,174 @@
+"""Local speech-to-text sidecar for Synthara-Redux voice commands (Vosk).
+
+Reads frames from stdin using the same layout as the Porcupine sidecar:
+    op (u8) | id length (u16 BE) | id | [payload length (u32 BE) | payload]
+Ops: 1 open (payload: language), 2 pcm (16 kHz mono PCM16-LE), 3 close, 5 finish.
+
+Writes one JSON event per line to stdout:
+    {"id": ..., "type": "transcript.created" | "transcript.partial" | "transcript.done" | "error", ...}
+"""
+
+import json
+import os
+import struct
+import sys
+
+from vosk import KaldiRecognizer, Model, SetLogLevel
+
+OP_OPEN = 1
+OP_PCM = 2
+OP_CLOSE = 3
+OP_FINISH = 5
+
+SAMPLE_RATE = 16000
+MODEL_DIR = os.environ.get("VOSK_MODEL_DIR", os.path.join(os.path.dirname(__file__), "model"))
+
+SetLogLevel(-1)
+
+models = {}
+streams = {}
+
+
+def emit(event):
+
+    sys.stdout.write(json.dumps(event) + "\n")
+    sys.stdout.flush()
+
+
+def model_for(language):
+
+    # Prefer model/<language>, fall back to the default model directory
+
+    path = os.path.join(MODEL_DIR, language)
+
+    if not os.path.isdir(path):
+
+        path = MODEL_DIR
+
+    if path not in models:
+
+        models[path] = Model(path)
+
+    return models[path]
+
+
+class Stream:
+
+    def __init__(self, stream_id, language):
+
+        self.id = stream_id
+        self.recognizer = KaldiRecognizer(model_for(language), SAMPLE_RATE)
+        self.finals = []
+        self.partial = ""
+
+    def feed(self, pcm):
+
+        if self.recognizer.AcceptWaveform(pcm):
+
+            text = json.loads(self.recognizer.Result()).get("text", "")
+
+            if text:
+
+                self.finals.append(text)
+
+            self.partial = ""
+            emit({"id": self.id, "type": "transcript.partial", "text": text, "is_final": True, "speech_final": True})
+            return
+
+        partial = json.loads(self.recognizer.PartialResult()).get("partial", "")
+
+        if partial and partial != self.partial:
+
+            self.partial = partial
+            emit({"id": self.id, "type": "transcript.partial", "text": partial})
+
+    def finish(self):
+
+        text = json.loads(self.recognizer.FinalResult()).get("text", "")
+
+        if text:
+
+            self.finals.append(text)
+
+        emit({"id": self.id, "type": "transcript.done", "text": " ".join(self.finals)})
+
+
+def read_exact(stream, size):
+
+    data = b""
+
+    while len(data) < size:
+
+        chunk = stream.read(size - len(data))
+
+        if not chunk:
+
+            return None
+
+        data += chunk
+
+    return data
+
+
+def handle(op, stream_id, payload):
+
+    if op == OP_OPEN:
+
+        try:
+
+            streams[stream_id] = Stream(stream_id, (payload or b"en").decode("utf-8"))
+            emit({"id": stream_id, "type": "transcript.created"})
+
+        except Exception as error:
+
+            emit({"id": stream_id, "type": "error", "message": str(error)})
+
+        return
+
+    current = streams.get(stream_id)
+
+    if current is None:
+
+        return
+
+    if op == OP_PCM and payload:
+
+        current.feed(payload)
+
+    elif op == OP_FINISH:
+
+        current.finish()
+        streams.pop(stream_id, None)
+
+    elif op == OP_CLOSE:
+
+        streams.pop(stream_id, None)
+
+
+def main():
+
+    source = sys.stdin.buffer
+
+    while True:
+
+        header = read_exact(source, 3)
+
+        if header is None:
+
+            return
+
+        op, id_length = struct.unpack(">BH", header)
+        stream_id = read_exact(source, id_length).decode("utf-8")
+        payload = None
+
+        if op in (OP_OPEN, OP_PCM):
+
+            (length,) = struct.unpack(">I", read_exact(source, 4))
+            payload = read_exact(source, length)
+
+        handle(op, stream_id, payload)
+
+
+if __name__ == "__main__":
+
+    main()
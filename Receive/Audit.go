@@ -0,0 +1,94 @@
+package Receive
+
+import (
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// VoiceCommandOutcome is how a dispatched voice command ended.
+type VoiceCommandOutcome string
+
+const (
+
+	OutcomeExecuted VoiceCommandOutcome = "executed"
+	OutcomeDenied VoiceCommandOutcome = "denied" // the authorizer refused it
+	OutcomeUnhandled VoiceCommandOutcome = "unhandled" // no handler registered for the verb
+	OutcomeFailed VoiceCommandOutcome = "failed" // the handler panicked
+
+)
+
+// VoiceCommandRecord describes one dispatched voice command for the audit log.
+type VoiceCommandRecord struct {
+
+	GuildID snowflake.ID
+	UserID snowflake.ID
+
+	Command string
+	Args string
+	Transcript string
+
+	Outcome VoiceCommandOutcome
+	Latency time.Duration // from the start of the capture until the handler returned
+
+	At time.Time
+
+}
+
+type VoiceCommandLogger func(Record VoiceCommandRecord)
+
+var (
+
+	voiceCommandLogMu sync.RWMutex
+	voiceCommandLogFn VoiceCommandLogger
+
+)
+
+func SetVoiceCommandLogger(fn VoiceCommandLogger) {
+
+	voiceCommandLogMu.Lock()
+	voiceCommandLogFn = fn
+	voiceCommandLogMu.Unlock()
+
+}
+
+// logVoiceCommand hands a record to the audit logger; users who opted out of voice commands are never recorded.
+func logVoiceCommand(GuildID, UserID snowflake.ID, Cmd ParsedCommand, Outcome VoiceCommandOutcome) {
+
+	voiceCommandLogMu.RLock()
+	fn := voiceCommandLogFn
+	voiceCommandLogMu.RUnlock()
+
+	if fn == nil || voiceCommandOptOut(UserID) {
+
+		return
+
+	}
+
+	Now := time.Now()
+	Latency := time.Duration(0)
+
+	if !Cmd.CapturedAt.IsZero() {
+
+		Latency = Now.Sub(Cmd.CapturedAt)
+
+	}
+
+	fn(VoiceCommandRecord{
+
+		GuildID: GuildID,
+		UserID: UserID,
+
+		Command: Cmd.Command,
+		Args: Cmd.Args,
+		Transcript: Cmd.Transcript,
+
+		Outcome: Outcome,
+		Latency: Latency,
+
+		At: Now,
+
+	})
+
+}
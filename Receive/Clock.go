@@ -0,0 +1,179 @@
+package Receive
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// sessionClock is the time source of a session; live sessions read the wall clock, replays can run on a virtual one.
+type sessionClock interface {
+
+	Now() time.Time
+	After(D time.Duration) <-chan time.Time
+	Ticker(D time.Duration) (<-chan time.Time, func()) // ticks every D, dropping ticks a slow reader misses, until stopped
+
+}
+
+type wallClock struct{}
+
+func (wallClock) Now() time.Time {
+
+	return time.Now()
+
+}
+
+func (wallClock) After(D time.Duration) <-chan time.Time {
+
+	return time.After(D)
+
+}
+
+func (wallClock) Ticker(D time.Duration) (<-chan time.Time, func()) {
+
+	Ticker := time.NewTicker(D)
+
+	return Ticker.C, Ticker.Stop
+
+}
+
+type fakeTimer struct {
+
+	at time.Time
+	ch chan time.Time
+
+}
+
+type fakeTicker struct {
+
+	next time.Time
+	every time.Duration
+	ch chan time.Time
+
+}
+
+// fakeClock only moves when advanced, firing every timer it passes, so a replay can run as fast as the pipeline allows.
+type fakeClock struct {
+
+	mu sync.Mutex
+
+	now time.Time
+	timers []fakeTimer
+	tickers []*fakeTicker
+	delivered int // ticks handed to a reader rather than absorbed by an unread one
+
+}
+
+func newFakeClock(Start time.Time) *fakeClock {
+
+	return &fakeClock{now: Start}
+
+}
+
+func (C *fakeClock) Now() time.Time {
+
+	C.mu.Lock()
+	defer C.mu.Unlock()
+
+	return C.now
+
+}
+
+func (C *fakeClock) After(D time.Duration) <-chan time.Time {
+
+	C.mu.Lock()
+	defer C.mu.Unlock()
+
+	Ch := make(chan time.Time, 1)
+
+	if D <= 0 {
+
+		Ch <- C.now
+		return Ch
+
+	}
+
+	C.timers = append(C.timers, fakeTimer{at: C.now.Add(D), ch: Ch})
+
+	return Ch
+
+}
+
+// Ticker fires on every Advance that passes a tick, without waiting for the reader to ask again as a chain of After calls
+// would; a virtual replay moves on faster than the reader's goroutine is scheduled.
+func (C *fakeClock) Ticker(D time.Duration) (<-chan time.Time, func()) {
+
+	C.mu.Lock()
+	defer C.mu.Unlock()
+
+	Ticker := &fakeTicker{next: C.now.Add(D), every: max(D, time.Millisecond), ch: make(chan time.Time, 1)}
+	C.tickers = append(C.tickers, Ticker)
+
+	Stop := func() {
+
+		C.mu.Lock()
+		defer C.mu.Unlock()
+
+		C.tickers = slices.DeleteFunc(C.tickers, func(T *fakeTicker) bool { return T == Ticker })
+
+	}
+
+	return Ticker.ch, Stop
+
+}
+
+func (C *fakeClock) ticksDelivered() int {
+
+	C.mu.Lock()
+	defer C.mu.Unlock()
+
+	return C.delivered
+
+}
+
+// Advance moves the clock forward by D and fires the timers and tickers that came due.
+func (C *fakeClock) Advance(D time.Duration) {
+
+	C.mu.Lock()
+	defer C.mu.Unlock()
+
+	C.now = C.now.Add(max(D, 0))
+
+	Pending := C.timers[:0]
+
+	for _, Timer := range C.timers {
+
+		if Timer.at.After(C.now) {
+
+			Pending = append(Pending, Timer)
+			continue
+
+		}
+
+		Timer.ch <- Timer.at // buffered and fired once, so this never blocks
+
+	}
+
+	C.timers = Pending
+
+	for _, Ticker := range C.tickers {
+
+		for !Ticker.next.After(C.now) {
+
+			select {
+
+			case Ticker.ch <- Ticker.next:
+
+				C.delivered++
+
+			default: // like time.Ticker, a tick nobody has read yet absorbs the ones after it
+
+			}
+
+			Ticker.next = Ticker.next.Add(Ticker.every)
+
+		}
+
+	}
+
+}
@@ -0,0 +1,206 @@
+package Receive
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const (
+
+	confirmAnswerYes = "yes"
+	confirmAnswerNo = "no"
+
+	confirmWindow = 10 * time.Second // how long the user has to start answering
+	confirmArmDelay = 750 * time.Millisecond // ignore the tail of the command that asked
+
+)
+
+// pendingConfirmation is a yes/no question waiting for the session's user to answer.
+type pendingConfirmation struct {
+
+	onAnswer func(Confirmed bool)
+
+	armedAt time.Time
+	deadline time.Time
+
+	once sync.Once
+
+}
+
+// Confirm asks the user to confirm an action out loud. While the question is open the user can answer
+// without the wake word; OnAnswer runs once with the result, or with false on timeout or a reply that is neither yes nor no.
+// It returns false when the user has no voice session to answer through, in which case OnAnswer is never called.
+func Confirm(GuildID, UserID snowflake.ID, Prompt string, OnAnswer func(Confirmed bool)) bool {
+
+	R := receiverFor(GuildID)
+
+	if R == nil {
+
+		return false
+
+	}
+
+	S := R.getSession(UserID)
+
+	if S == nil || S.closed.Load() {
+
+		return false
+
+	}
+
+	S.askConfirmation(Prompt, OnAnswer)
+
+	return true
+
+}
+
+// ParseConfirmation reads a yes/no answer from a transcript; the earliest answer word wins, so "no, go ahead" is a no.
+func ParseConfirmation(Text string, Locale string) (bool, bool) {
+
+	Vocabulary := vocabularyFor(Locale)
+
+	Tokens := strings.Fields(stripPunct(strings.ToLower(strings.TrimSpace(Text))))
+
+	Folded := make([]string, len(Tokens))
+
+	for i, Token := range Tokens {
+
+		Folded[i] = foldVoiceText(Token)
+
+	}
+
+	for i := range Tokens {
+
+		if P, Matched := matchAt(Vocabulary.Answers, Folded, i); Matched {
+
+			return P.Value == confirmAnswerYes, true
+
+		}
+
+		if P, _, Matched := matchUnspaced(Vocabulary.Answers, Tokens[i]); Matched {
+
+			return P.Value == confirmAnswerYes, true
+
+		}
+
+	}
+
+	return false, false
+
+}
+
+func (S *Session) askConfirmation(Prompt string, OnAnswer func(Confirmed bool)) {
+
+	Now := S.clock.Now()
+
+	P := &pendingConfirmation{
+
+		onAnswer: OnAnswer,
+
+		armedAt: Now.Add(confirmArmDelay),
+		deadline: Now.Add(confirmWindow),
+
+	}
+
+	S.confirmMu.Lock()
+
+	Previous := S.confirmation
+	S.confirmation = P
+
+	S.confirmMu.Unlock()
+
+	if Previous != nil {
+
+		S.resolveConfirmation(Previous, false)
+
+	}
+
+	emitFeedbackCue(S.GuildID, FeedbackCueConfirmPrompt)
+
+	if Prompt != "" {
+
+		EmitVoiceResponse(S.GuildID, Prompt)
+
+	}
+
+}
+
+// peekConfirmation returns the open question without taking it.
+func (S *Session) peekConfirmation() *pendingConfirmation {
+
+	S.confirmMu.Lock()
+	defer S.confirmMu.Unlock()
+
+	return S.confirmation
+
+}
+
+// takeConfirmation removes and returns the open question so exactly one caller answers it.
+func (S *Session) takeConfirmation() *pendingConfirmation {
+
+	S.confirmMu.Lock()
+	defer S.confirmMu.Unlock()
+
+	P := S.confirmation
+	S.confirmation = nil
+
+	return P
+
+}
+
+// answerConfirmation resolves the open question, if any.
+func (S *Session) answerConfirmation(Confirmed bool) {
+
+	if P := S.takeConfirmation(); P != nil {
+
+		S.resolveConfirmation(P, Confirmed)
+
+	}
+
+}
+
+func (S *Session) resolveConfirmation(P *pendingConfirmation, Confirmed bool) {
+
+	P.once.Do(func() {
+
+		if Confirmed {
+
+			emitFeedbackCue(S.GuildID, FeedbackCueConfirmAccept)
+
+		} else {
+
+			emitFeedbackCue(S.GuildID, FeedbackCueConfirmCancel)
+
+		}
+
+		if P.onAnswer == nil {
+
+			return
+
+		}
+
+		go func() {
+
+			defer func() {
+
+				if r := recover(); r != nil {
+
+					Utils.Logger.Error("Receive", fmt.Sprintf("Confirmation handler panic (user %s): %v", S.UserID, r))
+
+				}
+
+			}()
+
+			P.onAnswer(Confirmed)
+
+		}()
+
+	})
+
+}
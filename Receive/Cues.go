@@ -13,6 +13,9 @@ const (
 
 	FeedbackCueCaptureStart FeedbackCueKind = iota
 	FeedbackCueCaptureEnd // 1
+	FeedbackCueConfirmPrompt // 2
+	FeedbackCueConfirmAccept // 3
+	FeedbackCueConfirmCancel // 4
 
 )
 
@@ -24,6 +27,12 @@ type VoiceCommandOptOutChecker func(UserID snowflake.ID) bool
 
 type VoiceResponseHandler func(GuildID snowflake.ID, text string)
 
+// GuildLocaleResolver returns the Discord locale code of a guild, used to pick the voice command language
+type GuildLocaleResolver func(GuildID snowflake.ID) string
+
+// WakeWordResolver returns a guild's configured wake phrase and sensitivity
+type WakeWordResolver func(GuildID snowflake.ID) WakeWord
+
 var (
 
 	feedbackCueMu sync.RWMutex
@@ -38,6 +47,12 @@ var (
 	voiceResponseMu sync.RWMutex
 	voiceResponseFn VoiceResponseHandler
 
+	guildLocaleMu sync.RWMutex
+	guildLocaleFn GuildLocaleResolver
+
+	wakeWordMu sync.RWMutex
+	wakeWordFn WakeWordResolver
+
 )
 
 func SetFeedbackCueHandler(fn FeedbackCueHandler) {
@@ -72,6 +87,22 @@ func SetVoiceResponseHandler(fn VoiceResponseHandler) {
 
 }
 
+func SetGuildLocaleResolver(fn GuildLocaleResolver) {
+
+	guildLocaleMu.Lock()
+	guildLocaleFn = fn
+	guildLocaleMu.Unlock()
+
+}
+
+func SetWakeWordResolver(fn WakeWordResolver) {
+
+	wakeWordMu.Lock()
+	wakeWordFn = fn
+	wakeWordMu.Unlock()
+
+}
+
 func EmitVoiceResponse(GuildID snowflake.ID, text string) {
 
 	voiceResponseMu.RLock()
@@ -129,3 +160,35 @@ func emitCaptureDuck(GuildID snowflake.ID, Duck bool) {
 	}
 
 }
+
+func guildLocale(GuildID snowflake.ID) string {
+
+	guildLocaleMu.RLock()
+	fn := guildLocaleFn
+	guildLocaleMu.RUnlock()
+
+	if fn == nil {
+
+		return ""
+
+	}
+
+	return fn(GuildID)
+
+}
+
+func guildWakeWord(GuildID snowflake.ID) WakeWord {
+
+	wakeWordMu.RLock()
+	fn := wakeWordFn
+	wakeWordMu.RUnlock()
+
+	if fn == nil {
+
+		return WakeWord{}
+
+	}
+
+	return fn(GuildID)
+
+}
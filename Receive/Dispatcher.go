@@ -11,11 +11,16 @@ import (
 
 type CommandHandler func(GuildID, UserID snowflake.ID, Args string)
 
+// CommandAuthorizer decides whether a user may run a voice command; it is responsible for telling the user when not
+type CommandAuthorizer func(GuildID, UserID snowflake.ID, Command string) bool
+
 var (
 
 	commandRegistry = make(map[string]CommandHandler)
 	commandRegistryMu sync.RWMutex
 
+	commandAuthorizer CommandAuthorizer
+
 )
 
 // Register binds a handler to a voice command verb.
@@ -34,6 +39,25 @@ func Register(Command string, Handler CommandHandler) {
 
 }
 
+// SetAuthorizer installs the permission check run before every voice command.
+func SetAuthorizer(Authorizer CommandAuthorizer) {
+
+	commandRegistryMu.Lock()
+	defer commandRegistryMu.Unlock()
+
+	commandAuthorizer = Authorizer
+
+}
+
+func lookupAuthorizer() CommandAuthorizer {
+
+	commandRegistryMu.RLock()
+	defer commandRegistryMu.RUnlock()
+
+	return commandAuthorizer
+
+}
+
 func lookupHandler(Command string) CommandHandler {
 
 	commandRegistryMu.RLock()
@@ -43,6 +67,13 @@ func lookupHandler(Command string) CommandHandler {
 
 }
 
+// CommandDispatcher receives the commands a session parses; Dispatcher runs the registered handlers, replays only record them.
+type CommandDispatcher interface {
+
+	Dispatch(GuildID, UserID snowflake.ID, Cmd ParsedCommand)
+
+}
+
 type Dispatcher struct {
 
 	GuildID snowflake.ID
@@ -67,6 +98,7 @@ func (D *Dispatcher) Dispatch(GuildID, UserID snowflake.ID, Cmd ParsedCommand) {
 
 	if Handler == nil {
 
+		logVoiceCommand(GuildID, UserID, Cmd, OutcomeUnhandled)
 		return
 
 	}
@@ -78,12 +110,22 @@ func (D *Dispatcher) Dispatch(GuildID, UserID snowflake.ID, Cmd ParsedCommand) {
 			if r := recover(); r != nil {
 
 				Utils.Logger.Error("Receive", fmt.Sprintf("Voice handler panic (cmd=%s): %v", Cmd.Command, r))
+				logVoiceCommand(GuildID, UserID, Cmd, OutcomeFailed)
 
 			}
 
 		}()
 
+		if Authorizer := lookupAuthorizer(); Authorizer != nil && !Authorizer(GuildID, UserID, Cmd.Command) {
+
+			Utils.Logger.Info("Receive", fmt.Sprintf("Voice command %s denied for user %s in guild %s", Cmd.Command, UserID.String(), GuildID.String()))
+			logVoiceCommand(GuildID, UserID, Cmd, OutcomeDenied)
+			return
+
+		}
+
 		Handler(GuildID, UserID, Cmd.Args)
+		logVoiceCommand(GuildID, UserID, Cmd, OutcomeExecuted)
 
 	}()
 
@@ -2,6 +2,7 @@ package Receive
 
 import (
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -20,6 +21,14 @@ const (
 	CommandVolume = "volume"
 	CommandSpeed  = "speed"
 	CommandReverb = "reverb"
+	CommandNowPlaying = "nowplaying"
+	CommandRequester = "requester"
+	CommandRemove = "remove"
+	CommandClear = "clear"
+	CommandSave = "save"
+	CommandLike = "like"
+	CommandSeek = "seek"
+	CommandLyrics = "lyrics"
 
 )
 
@@ -29,20 +38,26 @@ type ParsedCommand struct {
 	Command string
 	Args string
 
-}
+	Transcript string // what the transcriber heard
+	CapturedAt time.Time // when the capture that heard it started
 
-func Parse(Text string) (ParsedCommand, bool) {
+}
 
-	Cleaned := strings.ToLower(strings.TrimSpace(Text))
+// Parse extracts a voice command from a transcript using the vocabulary of the guild's locale and its wake phrase.
+func Parse(Text string, Locale string, Wake WakeWord) (ParsedCommand, bool) {
 
-	Cleaned = stripPunct(Cleaned)
+	Vocabulary := vocabularyFor(Locale)
 
-	if Cleaned == "" {
+	if !transcriptFitsLanguage(Text, Vocabulary.Language) {
 
 		return ParsedCommand{}, false
 
 	}
 
+	Cleaned := strings.ToLower(strings.TrimSpace(Text))
+
+	Cleaned = Vocabulary.stripUnspacedWakePhrase(Wake, stripPunct(Cleaned))
+
 	Tokens := strings.Fields(Cleaned)
 
 	if len(Tokens) == 0 {
@@ -51,36 +66,46 @@ func Parse(Text string) (ParsedCommand, bool) {
 
 	}
 
-	PrefixIdx := -1
+	Folded := make([]string, len(Tokens))
 
-	for i := 0; i < len(Tokens); i++ {
+	for i, Token := range Tokens {
 
-		if fuzzyMatchSynthara(Tokens[i]) {
+		Folded[i] = foldVoiceText(Token)
 
-			PrefixIdx = i
-			break
+	}
 
-		}
+	PrefixIdx := Vocabulary.wakePhraseEnd(Wake, Tokens, Folded)
+
+	Tokens = Tokens[PrefixIdx+1:]
+	Folded = Folded[PrefixIdx+1:]
+
+	Cmd, Index, Args, OK := Vocabulary.findCommand(Tokens, Folded)
+
+	if !OK {
+
+		return ParsedCommand{}, false
 
 	}
 
-	var Rest []string
+	if Cmd == CommandSave {
 
-	if PrefixIdx >= 0 {
+		Args = Vocabulary.extractName(Args)
 
-		Rest = Tokens[PrefixIdx+1:]
+	} else if !CommandNeedsMultiWordArgs(Cmd) {
 
-	} else {
+		Args = Vocabulary.canonicalArgs(Args) // song names stay as spoken
 
-		Rest = Tokens
+		// Keywords said before the command still count: "smart shuffle", "turn up the volume", "sube el volumen"
 
-	}
+		for i := Index - 1; i >= 0; i-- {
 
-	Cmd, Args, OK := firstCommandInTokens(Rest)
+			if Keyword := Vocabulary.argumentKeyword(Folded[i]); Keyword != "" {
 
-	if !OK {
+				Args = Keyword + " " + Args
 
-		return ParsedCommand{}, false
+			}
+
+		}
 
 	}
 
@@ -92,19 +117,15 @@ func Parse(Text string) (ParsedCommand, bool) {
 
 	}
 
-	if !transcriptLooksEnglish(Text) {
-
-		return ParsedCommand{}, false
-
-	}
-
 	return ParsedCommand{
 
-		Prefix: "Synthara",
+		Prefix: Wake.name(),
 
 		Command: Cmd,
 		Args: Args,
 
+		Transcript: Text,
+
 	}, true
 
 }
@@ -113,7 +134,7 @@ func CommandClearsTrailingArgs(Command string) bool {
 
 	switch Command {
 
-	case CommandPause, CommandResume, CommandNext, CommandLast, CommandLeave:
+	case CommandPause, CommandResume, CommandNext, CommandLast, CommandLeave, CommandNowPlaying, CommandRequester, CommandRemove, CommandClear, CommandLike, CommandLyrics:
 
 		return true
 
@@ -130,7 +151,7 @@ func CommandNeedsMultiWordArgs(Command string) bool {
 
 	switch Command {
 
-	case CommandPlay:
+	case CommandPlay, CommandSave:
 
 		return true
 
@@ -146,11 +167,11 @@ func CommandDispatchesImmediately(Command, Args string) bool {
 
 	switch Command {
 
-	case CommandPause, CommandResume, CommandNext, CommandLast, CommandLeave, CommandShuffle, CommandRepeat, CommandReplay, CommandAutoplay:
+	case CommandPause, CommandResume, CommandNext, CommandLast, CommandLeave, CommandShuffle, CommandRepeat, CommandReplay, CommandAutoplay, CommandNowPlaying, CommandRequester, CommandRemove, CommandClear, CommandLike, CommandLyrics:
 
 		return true
 
-	case CommandVolume, CommandSpeed, CommandReverb:
+	case CommandVolume, CommandSpeed, CommandReverb, CommandSeek:
 
 		return strings.TrimSpace(Args) != "" // these commands can be dispatched immediately when an argument is present
 
@@ -162,7 +183,8 @@ func CommandDispatchesImmediately(Command, Args string) bool {
 
 }
 
-func transcriptLooksEnglish(Text string) bool {
+// transcriptFitsLanguage rejects transcripts in a script the guild's language doesn't use, which are usually STT hallucinations.
+func transcriptFitsLanguage(Text string, Language string) bool {
 
 	if strings.TrimSpace(Text) == "" {
 
@@ -170,6 +192,12 @@ func transcriptLooksEnglish(Text string) bool {
 
 	}
 
+	if Language == "zh" || Language == "ja" {
+
+		return true
+
+	}
+
 	for _, R := range Text {
 
 		if unicode.Is(unicode.Han, R) || unicode.Is(unicode.Hiragana, R) || unicode.Is(unicode.Katakana, R) || unicode.Is(unicode.Hangul, R) {
@@ -223,92 +251,6 @@ func fuzzyMatchSynthara(Token string) bool {
 
 }
 
-func firstCommandInTokens(Tokens []string) (string, string, bool) {
-
-	for i, Tok := range Tokens {
-
-		if Cmd := normalizeCommand(Tok); Cmd != "" {
-
-			Args := ""
-
-			if i+1 < len(Tokens) {
-
-				Args = strings.Join(Tokens[i+1:], " ")
-
-			}
-
-			return Cmd, strings.TrimSpace(Args), true
-
-		}
-
-	}
-
-	return "", "", false
-
-}
-
-func normalizeCommand(Token string) string {
-
-	switch Token {
-
-	case "play", "plays", "played", "enqueue", "add", "queue":
-
-		return CommandPlay
-
-	case "pause", "paused", "pausing":
-
-		return CommandPause
-
-	case "resume", "unpause", "continue":
-
-		return CommandResume
-
-	case "next", "skip", "forward":
-
-		return CommandNext
-
-	case "last", "previous", "back", "prev":
-
-		return CommandLast
-
-	case "leave", "disconnect", "dc", "quit":
-
-		return CommandLeave
-
-	case "shuffle", "shuffled", "shuffling":
-
-		return CommandShuffle
-
-	case "repeat", "loop", "looped":
-
-		return CommandRepeat
-
-	case "replay", "again":
-
-		return CommandReplay
-
-	case "autoplay", "auto", "radio":
-
-		return CommandAutoplay
-
-	case "volume", "vol", "loudness":
-
-		return CommandVolume
-
-	case "speed", "tempo", "rate":
-
-		return CommandSpeed
-
-	case "reverb", "echo", "room":
-
-		return CommandReverb
-
-	}
-
-	return ""
-
-}
-
 func stripPunct(S string) string {
 
 	var B strings.Builder
@@ -319,6 +261,10 @@ func stripPunct(S string) string {
 
 			B.WriteRune(R)
 
+		} else if R == '’' {
+
+			B.WriteRune('\'') // speech engines often emit typographic apostrophes
+
 		} else {
 
 			B.WriteRune(' ')
@@ -332,9 +278,11 @@ func stripPunct(S string) string {
 }
 
 // Levenshtein distance implementation adapted from https://en.wikipedia.org/wiki/Levenshtein_distance#Iterative_with_two_matrix_rows
-func levenshtein(A, B string) int {
+func levenshtein(a, b string) int {
+
+	A, B := []rune(a), []rune(b)
 
-	if A == B {
+	if a == b {
 
 		return 0
 
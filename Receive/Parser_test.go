@@ -0,0 +1,180 @@
+package Receive
+
+import "testing"
+
+func TestParseSynonyms(T *testing.T) {
+
+	Cases := []struct {
+
+		Locale string
+		Text string
+		Command string
+		Args string
+
+	}{
+
+		{"en-US", "Synthara, play never gonna give you up", CommandPlay, "never gonna give you up"},
+		{"en-US", "synthara skip", CommandNext, ""},
+		{"en-US", "hey synthara skip this song", CommandNext, ""},
+		{"en-US", "synthara go back", CommandLast, ""},
+		{"en-US", "synthara disconnect", CommandLeave, ""},
+		{"en-US", "synthara pausing", CommandPause, ""},
+		{"en-US", "cynthara pause", CommandPause, ""}, // misheard wake word
+		{"en-US", "synthara what's playing", CommandNowPlaying, ""},
+		{"en-US", "synthara rewind", CommandSeek, "back"},
+		{"en-US", "synthara save this queue as road trip", CommandSave, "road trip"},
+		{"en-US", "synthara turn it up", CommandVolume, "up"},
+		{"en-US", "synthara turn up the volume", CommandVolume, "up"},
+		{"en-US", "synthara smart shuffle", CommandShuffle, "smart"},
+		{"en-US", "jarvis pause", CommandPause, ""},
+
+		{"es-ES", "sintara pon despacito", CommandPlay, "despacito"},
+		{"es-ES", "sintara sube el volumen", CommandVolume, "up"},
+		{"es-ES", "sintara siguente", CommandNext, ""}, // one letter off
+		{"de", "synthara lautstärke lauter", CommandVolume, "up"},
+		{"de", "synthara nächstes lied", CommandNext, ""},
+		{"de", "synthara skip", CommandNext, ""}, // English works in every locale
+		{"fr", "synthara chanson suivante", CommandNext, ""},
+		{"it", "synthara alza il volume", CommandVolume, "up"},
+		{"pl", "syntara nastepny", CommandNext, ""}, // without the accent
+		{"ru", "синтара следующий трек", CommandNext, ""},
+		{"zh-CN", "辛塔拉播放周杰伦", CommandPlay, "周杰伦"},
+		{"ja", "シンセラ次", CommandNext, ""},
+
+	}
+
+	for _, Case := range Cases {
+
+		Parsed, OK := Parse(Case.Text, Case.Locale, WakeWord{})
+
+		if !OK || Parsed.Command != Case.Command || Parsed.Args != Case.Args {
+
+			T.Errorf("Parse(%q, %s) = %q %q (%t), want %q %q", Case.Text, Case.Locale, Parsed.Command, Parsed.Args, OK, Case.Command, Case.Args)
+
+		}
+
+	}
+
+}
+
+func TestParseNumberWords(T *testing.T) {
+
+	Cases := []struct {
+
+		Locale string
+		Text string
+		Command string
+		Args string
+
+	}{
+
+		// "one" is part of the vocabulary, so every locale's word for it reaches the handlers in English
+
+		{"en-US", "synthara repeat one", CommandRepeat, "one"},
+		{"es-ES", "sintara repite una", CommandRepeat, "one"},
+		{"fr", "synthara répète une", CommandRepeat, "one"},
+		{"de", "synthara wiederhole eins", CommandRepeat, "one"},
+		{"pl", "syntara powtórz jeden", CommandRepeat, "one"},
+		{"ru", "синтара повтори одну", CommandRepeat, "one"},
+		{"zh-CN", "辛塔拉单曲循环", CommandRepeat, "one"},
+
+		// Digits pass through for the handlers to read
+
+		{"en-US", "synthara volume 50", CommandVolume, "50"},
+		{"en-US", "synthara replay 2", CommandReplay, "2"},
+		{"en-US", "synthara jump to 1:30", CommandSeek, "1 30"},
+		{"en-US", "synthara skip ahead 30 seconds", CommandSeek, "forward 30 seconds"},
+		{"zh-CN", "辛塔拉音量50", CommandVolume, "50"},
+
+	}
+
+	for _, Case := range Cases {
+
+		Parsed, OK := Parse(Case.Text, Case.Locale, WakeWord{})
+
+		if !OK || Parsed.Command != Case.Command || Parsed.Args != Case.Args {
+
+			T.Errorf("Parse(%q, %s) = %q %q (%t), want %q %q", Case.Text, Case.Locale, Parsed.Command, Parsed.Args, OK, Case.Command, Case.Args)
+
+		}
+
+	}
+
+}
+
+func TestParseRejectsNearMisses(T *testing.T) {
+
+	Cases := []struct {
+
+		Locale string
+		Text string
+
+	}{
+
+		{"en-US", ""},
+		{"en-US", "synthara"},
+		{"en-US", "the weather is nice today"},
+		{"en-US", "synthara display the list"}, // "play" inside a word
+		{"en-US", "synthara player"},
+		{"en-US", "synthara paws"},
+		{"en-US", "synthara skipper"},
+		{"en-US", "synthara clearly"},
+		{"en-US", "synthara volumes"}, // English gets no fuzzy matching
+		{"en-US", "synthara replace it"},
+		{"en-US", "синтара следующий"}, // a script the guild's language doesn't use
+		{"en-US", "次"},
+		{"es-ES", "sintara pasta"},
+		{"es-ES", "sintara reproducciones"},
+		{"de", "synthara ausgang"},
+
+	}
+
+	for _, Case := range Cases {
+
+		if Parsed, OK := Parse(Case.Text, Case.Locale, WakeWord{}); OK {
+
+			T.Errorf("Parse(%q, %s) = %q %q, want no command", Case.Text, Case.Locale, Parsed.Command, Parsed.Args)
+
+		}
+
+	}
+
+}
+
+func TestParseConfirmation(T *testing.T) {
+
+	Cases := []struct {
+
+		Locale string
+		Text string
+		Confirmed bool
+		Answered bool
+
+	}{
+
+		{"en-US", "yes please", true, true},
+		{"en-US", "okay do it", true, true},
+		{"en-US", "no, go ahead", false, true}, // the earliest answer wins
+		{"es-ES", "sí claro", true, true},
+		{"de", "nein danke", false, true},
+		{"ja", "はい", true, true},
+		{"en-US", "yesterday", false, false},
+		{"en-US", "nobody", false, false},
+		{"en-US", "know", false, false},
+		{"en-US", "maybe", false, false},
+
+	}
+
+	for _, Case := range Cases {
+
+		Confirmed, Answered := ParseConfirmation(Case.Text, Case.Locale)
+
+		if Confirmed != Case.Confirmed || Answered != Case.Answered {
+
+			T.Errorf("ParseConfirmation(%q, %s) = %t %t, want %t %t", Case.Text, Case.Locale, Confirmed, Answered, Case.Confirmed, Case.Answered)
+
+		}
+
+	}
+
+}
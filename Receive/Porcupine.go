@@ -2,6 +2,7 @@ package Receive
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -22,6 +23,7 @@ const (
 	picoOpPCM = 2
 	picoOpClose = 3
 	picoOpWake = 4
+	picoOpConfigure = 5 // payload: JSON keyword path and sensitivity for one stream
 
 	defaultPicoDir = "./Modules/pico"
 
@@ -51,6 +53,13 @@ func WakeDetectorReady() bool {
 
 }
 
+type picoStreamConfig struct {
+
+	Keyword string `json:"keyword"`
+	Sensitivity float64 `json:"sensitivity"`
+
+}
+
 func streamID(GuildID, UserID snowflake.ID) string {
 
 	return fmt.Sprintf("%d:%d", GuildID, UserID)
@@ -92,16 +101,22 @@ func picoArgs(Dir string) []string {
 
 }
 
-func startPicoProcess() {
-
-	Dir := os.Getenv("PICO_DIR")
+func picoDir() string {
 
-	if Dir == "" {
+	if Dir := os.Getenv("PICO_DIR"); Dir != "" {
 
-		Dir = defaultPicoDir
+		return Dir
 
 	}
 
+	return defaultPicoDir
+
+}
+
+func startPicoProcess() {
+
+	Dir := picoDir()
+
 	Model := filepath.Join(Dir, "model", "synthara.ppn")
 
 	if _, ErrStat := os.Stat(Model); ErrStat != nil {
@@ -246,20 +261,15 @@ func drainPicoWake(Buf []byte) []byte {
 
 }
 
-func picoWriteFrame(Op byte, StreamID string, PCM []byte) error {
-
-	if !picoReady.Load() || picoStdin == nil {
-
-		return errors.New("porcupine sidecar not ready")
-
-	}
+// encodeStreamFrame builds a sidecar frame: op, uint16 id length, id, and for payload ops a uint32 length and the bytes.
+func encodeStreamFrame(Op byte, StreamID string, Payload []byte, HasPayload bool) []byte {
 
 	ID := []byte(StreamID)
 	FrameLen := 3 + len(ID)
 
-	if Op == picoOpPCM {
+	if HasPayload {
 
-		FrameLen += 4 + len(PCM)
+		FrameLen += 4 + len(Payload)
 
 	}
 
@@ -270,14 +280,28 @@ func picoWriteFrame(Op byte, StreamID string, PCM []byte) error {
 
 	copy(Frame[3:], ID)
 
-	if Op == picoOpPCM {
+	if HasPayload {
 
 		Off := 3 + len(ID)
-		binary.BigEndian.PutUint32(Frame[Off:Off+4], uint32(len(PCM)))
-		copy(Frame[Off+4:], PCM)
+		binary.BigEndian.PutUint32(Frame[Off:Off+4], uint32(len(Payload)))
+		copy(Frame[Off+4:], Payload)
+
+	}
+
+	return Frame
+
+}
+
+func picoWriteFrame(Op byte, StreamID string, PCM []byte) error {
+
+	if !picoReady.Load() || picoStdin == nil {
+
+		return errors.New("porcupine sidecar not ready")
 
 	}
 
+	Frame := encodeStreamFrame(Op, StreamID, PCM, Op == picoOpPCM || Op == picoOpConfigure)
+
 	picoWriteMu.Lock()
 	_, Err := picoStdin.Write(Frame)
 	picoWriteMu.Unlock()
@@ -308,6 +332,21 @@ func PicoCloseStream(GuildID, UserID snowflake.ID) error {
 
 }
 
+// PicoConfigureStream switches a stream to another keyword file and sensitivity; an empty keyword uses the default model.
+func PicoConfigureStream(GuildID, UserID snowflake.ID, Keyword string, Sensitivity float64) error {
+
+	Payload, ErrMarshal := json.Marshal(picoStreamConfig{Keyword: Keyword, Sensitivity: Sensitivity})
+
+	if ErrMarshal != nil {
+
+		return ErrMarshal
+
+	}
+
+	return picoWriteFrame(picoOpConfigure, streamID(GuildID, UserID), Payload)
+
+}
+
 // PicoFeedPCM sends 16 kHz mono PCM to the wake detector for one stream.
 func PicoFeedPCM(GuildID, UserID snowflake.ID, PCM []byte) error {
 
@@ -1,6 +1,7 @@
 package Receive
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -21,6 +22,7 @@ type Receiver struct {
 	dispatcher *Dispatcher
 
 	sessions map[snowflake.ID]*Session
+	talk talkState
 
 	mu sync.Mutex
 	closed bool
@@ -34,6 +36,13 @@ var (
 
 )
 
+var (
+
+	ErrReceiverUnavailable = errors.New("voice commands are not active in this guild")
+	ErrSessionUnavailable = errors.New("voice session unavailable for this user")
+
+)
+
 // VoiceCommandsRequested is true unless VOICE_COMMANDS=false.
 func VoiceCommandsRequested() bool {
 
@@ -41,7 +50,16 @@ func VoiceCommandsRequested() bool {
 
 }
 
-// IsEnabled reports whether voice capture should attach to a guild voice connection.
+// ReceiveWithoutWakeRequested is true with VOICE_RECEIVE=true.
+func ReceiveWithoutWakeRequested() bool {
+
+	return strings.EqualFold(os.Getenv("VOICE_RECEIVE"), "true")
+
+}
+
+// IsEnabled reports whether voice capture should attach to a guild voice connection. Receiving keeps the bot undeafened
+// and decoding everyone in the channel, so without the Porcupine wake detector, where only Listen (push-to-talk) and
+// talk-over need it, it takes VOICE_RECEIVE=true.
 func IsEnabled() bool {
 
 	if !VoiceCommandsRequested() {
@@ -50,7 +68,7 @@ func IsEnabled() bool {
 
 	}
 
-	return WakeDetectorReady()
+	return WakeDetectorReady() || ReceiveWithoutWakeRequested()
 
 }
 
@@ -114,6 +132,23 @@ func AttachReceiver(GuildID snowflake.ID, Conn voice.Conn) *Receiver {
 
 }
 
+// receiverFor returns the receiver attached to a guild's voice connection, if any.
+func receiverFor(GuildID snowflake.ID) *Receiver {
+
+	Val, Loaded := receiverRegistry.Load(GuildID)
+
+	if !Loaded {
+
+		return nil
+
+	}
+
+	R, _ := Val.(*Receiver)
+
+	return R
+
+}
+
 func routePicoWake(StreamID string) {
 
 	GuildID, UserID, OK := parseStreamID(StreamID)
@@ -124,23 +159,36 @@ func routePicoWake(StreamID string) {
 
 	}
 
-	Val, Loaded := receiverRegistry.Load(GuildID)
+	if R := receiverFor(GuildID); R != nil {
 
-	if !Loaded {
+		R.NotifyWake(UserID)
 
-		return
+	}
+
+}
+
+// Listen opens a capture window for one user without a wake word (push-to-talk), creating their session if they have not spoken yet.
+func Listen(GuildID, UserID snowflake.ID) error {
+
+	R := receiverFor(GuildID)
+
+	if R == nil {
+
+		return ErrReceiverUnavailable
 
 	}
 
-	R, OK := Val.(*Receiver)
+	Sess := R.getOrCreateSession(UserID)
 
-	if !OK {
+	if Sess == nil {
 
-		return
+		return ErrSessionUnavailable
 
 	}
 
-	R.NotifyWake(UserID)
+	Sess.NotifyListen()
+
+	return nil
 
 }
 
@@ -159,9 +207,15 @@ func (R *Receiver) NotifyWake(UserID snowflake.ID) {
 
 }
 
-// NotifySpeaking updates Discord VAD state for a user session.
+// NotifySpeaking updates Discord VAD state for a user session and the talk-over duck.
 func (R *Receiver) NotifySpeaking(UserID snowflake.ID, Active bool) {
 
+	if Active {
+
+		R.noteVoiced(UserID) // ducks before the first frame is decoded; the hang releases it if no speech follows
+
+	}
+
 	Sess := R.getSession(UserID)
 
 	if Sess != nil {
@@ -186,6 +240,7 @@ func (R *Receiver) getSession(UserID snowflake.ID) *Session {
 func (R *Receiver) Close() {
 
 	receiverRegistry.Delete(R.GuildID)
+	R.stopTalkOver()
 
 	R.mu.Lock()
 
@@ -243,6 +298,14 @@ func (R *Receiver) ReceiveOpusFrame(UserID snowflake.ID, Packet *voice.Packet) e
 
 	if Sess == nil {
 
+		// Without a session (opted out of voice commands) the client's own voice activity is all there is to go on
+
+		if len(Packet.Opus) > opusSilenceFrameBytes {
+
+			R.noteVoiced(UserID)
+
+		}
+
 		return nil
 
 	}
@@ -0,0 +1,1005 @@
+package Receive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"layeh.com/gopus"
+)
+
+const (
+
+	replayGuildID snowflake.ID = 1
+	replayUserID snowflake.ID = 2
+
+	replayFrameDuration = 20 * time.Millisecond
+	replaySpeakingHang = 5 // unvoiced frames a client keeps sending before its speaking indicator turns off
+
+	replayIdleTimeout = commandCaptureMax + transcribeHardTimeout
+	replaySettleTimeout = time.Second // how long a virtual replay waits, in real time, for the session to catch up
+
+)
+
+// replayConfirmedCommands are the commands whose live handlers ask for a spoken yes or no before acting.
+var replayConfirmedCommands = []string{CommandClear, CommandLeave}
+
+// sessionTracer observes a session's pipeline stages; only offline replays set one.
+type sessionTracer interface {
+
+	frameDecoded(Took time.Duration, Err error)
+	stage(Name string, Detail string)
+	ticked()
+
+}
+
+func (S *Session) traceStage(Name string, Detail string) {
+
+	if S.trace != nil {
+
+		S.trace.stage(Name, Detail)
+
+	}
+
+}
+
+func describeUpdate(Upd TranscriptUpdate) string {
+
+	Kind := "interim"
+
+	if Upd.SpeechFinal {
+
+		Kind = "speech final"
+
+	} else if Upd.IsFinal {
+
+		Kind = "final"
+
+	}
+
+	return fmt.Sprintf("%s %q", Kind, Upd.Text)
+
+}
+
+// ReplayOptions describes one offline run of the voice pipeline.
+type ReplayOptions struct {
+
+	Path string // a 16-bit PCM .wav, or an Ogg Opus capture (.ogg, .opus) of 20ms packets
+	Script string // scripted transcript; empty uses the configured STT backend
+
+	ListenAt time.Duration // when to open a push-to-talk capture; negative waits for the wake detector instead
+	Virtual bool // run on a virtual clock as fast as the session keeps up instead of in real time; needs a Script
+
+}
+
+// ReplayEvent is one pipeline stage, timed from the start of the replay.
+type ReplayEvent struct {
+
+	At time.Duration
+	Stage string
+	Detail string
+
+}
+
+// ReplayCommand is a command that reached the dispatcher.
+type ReplayCommand struct {
+
+	At time.Duration
+	Command ParsedCommand
+
+}
+
+// ReplayConfirmation is how a yes/no question asked after a command was answered.
+type ReplayConfirmation struct {
+
+	At time.Duration
+	Command string
+	Confirmed bool // false for a no, a reply that is neither, or no reply before the window closed
+
+}
+
+// ReplayReport is everything a replay observed.
+type ReplayReport struct {
+
+	Events []ReplayEvent
+	Commands []ReplayCommand
+	Confirmations []ReplayConfirmation
+
+	Frames int
+	VoicedFrames int
+	DecodeErrors int
+	DecodeTime time.Duration
+
+	Scripted bool
+	SentBytes int // audio the scripted transcriber received
+	GatedBytes int // of which silent chunks a streaming backend would have skipped
+
+}
+
+// replayRecorder is the dispatcher and tracer of a replayed session.
+type replayRecorder struct {
+
+	mu sync.Mutex
+
+	clock sessionClock
+	start time.Time
+	report ReplayReport
+	session *Session
+
+	decoded int
+	ticks int
+	pendingFinalize int
+	asking int // questions still waiting for an answer
+
+}
+
+func (R *replayRecorder) since() time.Duration {
+
+	return R.clock.Now().Sub(R.start).Round(time.Millisecond)
+
+}
+
+func (R *replayRecorder) stage(Name string, Detail string) {
+
+	R.mu.Lock()
+	defer R.mu.Unlock()
+
+	switch Name {
+
+	case "finalize":
+
+		R.pendingFinalize++
+
+	case "finalized":
+
+		R.pendingFinalize--
+
+	}
+
+	R.report.Events = append(R.report.Events, ReplayEvent{At: R.since(), Stage: Name, Detail: Detail})
+
+}
+
+func (R *replayRecorder) frameDecoded(Took time.Duration, Err error) {
+
+	R.mu.Lock()
+
+	R.report.DecodeTime += Took
+	R.decoded++
+
+	if Err != nil {
+
+		R.report.DecodeErrors++
+
+	}
+
+	R.mu.Unlock()
+
+}
+
+func (R *replayRecorder) ticked() {
+
+	R.mu.Lock()
+	R.ticks++
+	R.mu.Unlock()
+
+}
+
+func (R *replayRecorder) Dispatch(_, _ snowflake.ID, Cmd ParsedCommand) {
+
+	R.mu.Lock()
+	R.report.Commands = append(R.report.Commands, ReplayCommand{At: R.since(), Command: Cmd})
+	R.mu.Unlock()
+
+	Detail := Cmd.Command
+
+	if Cmd.Args != "" {
+
+		Detail += " " + Cmd.Args
+
+	}
+
+	if !Cmd.CapturedAt.IsZero() {
+
+		Detail += fmt.Sprintf(" (%d ms after the capture started)", R.clock.Now().Sub(Cmd.CapturedAt).Milliseconds())
+
+	}
+
+	R.stage("command", Detail)
+
+	if slices.Contains(replayConfirmedCommands, Cmd.Command) {
+
+		R.confirm(Cmd.Command)
+
+	}
+
+}
+
+// confirm asks the question the live handler of Command would, recording the answer instead of acting on it.
+func (R *replayRecorder) confirm(Command string) {
+
+	R.mu.Lock()
+	R.asking++
+	R.mu.Unlock()
+
+	R.stage("confirm", fmt.Sprintf("%s waits for a yes or no", Command))
+
+	R.session.askConfirmation("", func(Confirmed bool) {
+
+		R.stage("confirmed", fmt.Sprintf("%s answered %t", Command, Confirmed))
+
+		R.mu.Lock()
+
+		R.report.Confirmations = append(R.report.Confirmations, ReplayConfirmation{At: R.since(), Command: Command, Confirmed: Confirmed})
+		R.asking--
+
+		R.mu.Unlock()
+
+	})
+
+}
+
+func (R *replayRecorder) idle() bool {
+
+	R.mu.Lock()
+	defer R.mu.Unlock()
+
+	return R.pendingFinalize <= 0 && R.asking <= 0
+
+}
+
+// settle waits, in real time, until the session has decoded the frames pushed so far and handled the ticks delivered so far;
+// a virtual replay only moves its clock on once it has, so timeouts see the audio and the time they would have seen live.
+func (R *replayRecorder) settle(Pushed int, Ticks int) {
+
+	Deadline := time.Now().Add(replaySettleTimeout)
+
+	for time.Now().Before(Deadline) {
+
+		R.mu.Lock()
+		Decoded, Ticked := R.decoded, R.ticks
+		R.mu.Unlock()
+
+		if Decoded >= Pushed && Ticked >= Ticks {
+
+			return
+
+		}
+
+		time.Sleep(time.Millisecond)
+
+	}
+
+}
+
+// replayFrame is one 20ms Opus packet and whether the speaker was audible in it.
+type replayFrame struct {
+
+	opus []byte
+	voiced bool
+
+}
+
+// Replay feeds a recorded capture through a real Session, as Discord would deliver it, and reports every stage.
+// Commands are recorded instead of run, so nothing needs a guild or a voice connection.
+func Replay(Opts ReplayOptions) (ReplayReport, error) {
+
+	Frames, ErrRead := readReplayFrames(Opts.Path)
+
+	if ErrRead != nil {
+
+		return ReplayReport{}, ErrRead
+
+	}
+
+	var Script *scriptedTranscript
+
+	if Opts.Script != "" {
+
+		Loaded, ErrScript := loadTranscriptScript(Opts.Script)
+
+		if ErrScript != nil {
+
+			return ReplayReport{}, ErrScript
+
+		}
+
+		Script = Loaded
+
+	}
+
+	var Clock sessionClock = wallClock{}
+
+	if Opts.Virtual {
+
+		if Script == nil {
+
+			return ReplayReport{}, errors.New("a virtual replay needs a transcript script, since STT backends answer in real time")
+
+		}
+
+		Clock = newFakeClock(time.Now())
+
+	}
+
+	return replayFrames(Frames, Script, Opts.ListenAt, Clock)
+
+}
+
+// replayFrames feeds in-memory frames through a session paced by Clock: in real time on the wall clock, or as fast as the
+// session keeps up on a fake one. A nil Script transcribes with the configured STT backend.
+func replayFrames(Frames []replayFrame, Script *scriptedTranscript, ListenAt time.Duration, Clock sessionClock) (ReplayReport, error) {
+
+	OpenTranscriber := NewTranscriber
+	Recorder := &replayRecorder{clock: Clock}
+
+	if Script != nil {
+
+		Recorder.report.Scripted = true
+
+		OpenTranscriber = func(Parent context.Context, _ string) (Transcriber, error) {
+
+			return Script.open(Parent, Clock), nil
+
+		}
+
+	}
+
+	Recorder.start = Clock.Now()
+
+	Sess, ErrSession := newSession(replayGuildID, replayUserID, Recorder, OpenTranscriber, Recorder, Clock)
+
+	if ErrSession != nil {
+
+		return ReplayReport{}, ErrSession
+
+	}
+
+	defer Sess.Close()
+
+	Recorder.session = Sess
+
+	if ListenAt < 0 {
+
+		if !WakeDetectorReady() {
+
+			return ReplayReport{}, errors.New("the wake detector is unavailable; replay with a push-to-talk offset instead")
+
+		}
+
+		SetPicoWakeHandler(func(StreamID string) {
+
+			if GuildID, UserID, OK := parseStreamID(StreamID); OK && GuildID == replayGuildID && UserID == replayUserID {
+
+				Recorder.stage("wake", "detected")
+				Sess.NotifyWake()
+
+			}
+
+		})
+
+	}
+
+	// Wait moves the replay on to Until; a fake clock jumps there once the session has caught up
+
+	Pushed := 0
+
+	Wait := func(Until time.Time) {
+
+		<-Clock.After(Until.Sub(Clock.Now()))
+
+	}
+
+	if Fake, IsFake := Clock.(*fakeClock); IsFake {
+
+		Wait = func(Until time.Time) {
+
+			Recorder.settle(Pushed, Fake.ticksDelivered())
+			Fake.Advance(Until.Sub(Fake.Now()))
+
+		}
+
+	}
+
+	ListenFrame := int(ListenAt / replayFrameDuration)
+
+	Speaking := false
+	Quiet := 0
+
+	for Index, Frame := range Frames {
+
+		if ListenAt >= 0 && Index == ListenFrame {
+
+			Recorder.stage("listen", "push-to-talk")
+			Sess.NotifyListen()
+
+		}
+
+		// Clients stop sending audio shortly after the speaker goes quiet, and say so with a speaking event
+
+		if Frame.voiced {
+
+			Quiet = 0
+
+			if !Speaking {
+
+				Speaking = true
+				Sess.SetDiscordSpeaking(true)
+
+			}
+
+		} else if Speaking {
+
+			Quiet++
+
+			if Quiet > replaySpeakingHang {
+
+				Speaking = false
+				Sess.SetDiscordSpeaking(false)
+
+			}
+
+		}
+
+		if Speaking {
+
+			Sess.Push(Frame.opus)
+			Pushed++
+
+		}
+
+		if Frame.voiced {
+
+			Recorder.report.VoicedFrames++
+
+		}
+
+		Wait(Recorder.start.Add(time.Duration(Index+1) * replayFrameDuration))
+
+	}
+
+	Recorder.report.Frames = len(Frames)
+	Recorder.stage("input", "ended")
+
+	if Speaking {
+
+		Sess.SetDiscordSpeaking(false)
+
+	}
+
+	Deadline := Clock.Now().Add(replayIdleTimeout)
+
+	for Clock.Now().Before(Deadline) {
+
+		if Sess.state.Load() == stateListening && Recorder.idle() {
+
+			break
+
+		}
+
+		Wait(Clock.Now().Add(replayFrameDuration))
+
+	}
+
+	Recorder.mu.Lock()
+	defer Recorder.mu.Unlock()
+
+	if Script != nil {
+
+		Recorder.report.SentBytes, Recorder.report.GatedBytes = Script.audio()
+
+	}
+
+	return Recorder.report, nil
+
+}
+
+// readReplayFrames loads a capture as 20ms Opus packets.
+func readReplayFrames(Path string) ([]replayFrame, error) {
+
+	Data, ErrRead := os.ReadFile(Path)
+
+	if ErrRead != nil {
+
+		return nil, ErrRead
+
+	}
+
+	switch {
+
+	case bytes.HasPrefix(Data, []byte("RIFF")):
+
+		return wavReplayFrames(Data)
+
+	case bytes.HasPrefix(Data, []byte("OggS")):
+
+		return oggReplayFrames(Data)
+
+	default:
+
+		return nil, fmt.Errorf("%s is neither a WAV file nor an Ogg Opus capture", filepath.Base(Path))
+
+	}
+
+}
+
+// wavReplayFrames mixes a 16-bit PCM WAV down to mono and encodes it the way a Discord client would.
+func wavReplayFrames(Data []byte) ([]replayFrame, error) {
+
+	if len(Data) < 12 || string(Data[8:12]) != "WAVE" {
+
+		return nil, errors.New("not a RIFF WAVE file")
+
+	}
+
+	var Rate, Channels, Bits int
+	var Samples []byte
+
+	for Offset := 12; Offset+8 <= len(Data); {
+
+		ID := string(Data[Offset : Offset+4])
+		Size := int(binary.LittleEndian.Uint32(Data[Offset+4 : Offset+8]))
+		Body := Data[Offset+8 : min(Offset+8+Size, len(Data))]
+
+		switch ID {
+
+		case "fmt ":
+
+			if len(Body) < 16 || binary.LittleEndian.Uint16(Body[0:2]) != 1 {
+
+				return nil, errors.New("only uncompressed PCM WAV files can be replayed")
+
+			}
+
+			Channels = int(binary.LittleEndian.Uint16(Body[2:4]))
+			Rate = int(binary.LittleEndian.Uint32(Body[4:8]))
+			Bits = int(binary.LittleEndian.Uint16(Body[14:16]))
+
+		case "data":
+
+			Samples = Body
+
+		}
+
+		Offset += 8 + Size + Size%2
+
+	}
+
+	if Rate <= 0 || Channels <= 0 || Samples == nil {
+
+		return nil, errors.New("WAV file is missing its fmt or data chunk")
+
+	}
+
+	if Bits != 16 {
+
+		return nil, fmt.Errorf("unsupported WAV bit depth: %d", Bits)
+
+	}
+
+	// Mix down to mono; encoding resamples to 48 kHz by linear interpolation
+
+	Mono := make([]float64, len(Samples)/2/Channels)
+
+	for i := range Mono {
+
+		var Sum float64
+
+		for ch := 0; ch < Channels; ch++ {
+
+			Idx := (i*Channels + ch) * 2
+			Sum += float64(int16(binary.LittleEndian.Uint16(Samples[Idx : Idx+2])))
+
+		}
+
+		Mono[i] = Sum / float64(Channels)
+
+	}
+
+	if len(Mono) < 2 {
+
+		return nil, errors.New("WAV file holds no audio")
+
+	}
+
+	return encodeReplayFrames(Mono, Rate)
+
+}
+
+// encodeReplayFrames resamples mono samples at Rate to 48 kHz stereo and encodes them into 20ms Opus packets.
+func encodeReplayFrames(Mono []float64, Rate int) ([]replayFrame, error) {
+
+	Step := float64(Rate) / OpusSampleRate
+	Total := int(float64(len(Mono)-1) / Step)
+
+	Encoder, ErrEncoder := gopus.NewEncoder(OpusSampleRate, OpusChannels, gopus.Voip)
+
+	if ErrEncoder != nil {
+
+		return nil, ErrEncoder
+
+	}
+
+	Frames := []replayFrame{}
+	Stereo := make([]int16, OpusFrameSamples*OpusChannels)
+
+	for Start := 0; Start < Total; Start += OpusFrameSamples {
+
+		for i := 0; i < OpusFrameSamples; i++ {
+
+			Value := int16(0)
+
+			if Start+i < Total {
+
+				Pos := float64(Start+i) * Step
+				Idx := int(Pos)
+				Frac := Pos - float64(Idx)
+
+				Value = int16(Mono[Idx] + (Mono[Idx+1]-Mono[Idx])*Frac)
+
+			}
+
+			Stereo[i*2] = Value
+			Stereo[i*2+1] = Value
+
+		}
+
+		Packet, ErrEncode := Encoder.Encode(Stereo, OpusFrameSamples, 4000)
+
+		if ErrEncode != nil {
+
+			return nil, ErrEncode
+
+		}
+
+		Frames = append(Frames, replayFrame{opus: Packet, voiced: isVoicedFrame(Stereo)})
+
+	}
+
+	return Frames, nil
+
+}
+
+// oggReplayFrames extracts the Opus packets of an Ogg Opus capture, such as a per-speaker recording of a call.
+func oggReplayFrames(Data []byte) ([]replayFrame, error) {
+
+	Packets := [][]byte{}
+	Pending := []byte{}
+
+	for Offset := 0; Offset+27 <= len(Data); {
+
+		if string(Data[Offset:Offset+4]) != "OggS" {
+
+			return nil, fmt.Errorf("corrupt Ogg page at byte %d", Offset)
+
+		}
+
+		Segments := int(Data[Offset+26])
+		Table := Offset + 27
+
+		if Table+Segments > len(Data) {
+
+			break
+
+		}
+
+		Body := Table + Segments
+
+		for _, Lacing := range Data[Table : Table+Segments] {
+
+			End := min(Body+int(Lacing), len(Data))
+
+			Pending = append(Pending, Data[Body:End]...)
+			Body = End
+
+			if Lacing < 255 {
+
+				Packets = append(Packets, Pending)
+				Pending = []byte{}
+
+			}
+
+		}
+
+		Offset = Body
+
+	}
+
+	if len(Packets) < 2 || !bytes.HasPrefix(Packets[0], []byte("OpusHead")) {
+
+		return nil, errors.New("not an Ogg Opus capture")
+
+	}
+
+	Decoder, ErrDecoder := NewOpusDecoder()
+
+	if ErrDecoder != nil {
+
+		return nil, ErrDecoder
+
+	}
+
+	defer Decoder.Close()
+
+	Frames := []replayFrame{}
+
+	for _, Packet := range Packets[1:] {
+
+		if bytes.HasPrefix(Packet, []byte("OpusTags")) {
+
+			continue
+
+		}
+
+		PCM, ErrDecode := Decoder.Decode(Packet)
+
+		Frames = append(Frames, replayFrame{opus: Packet, voiced: ErrDecode == nil && isVoicedFrame(PCM)})
+
+	}
+
+	return Frames, nil
+
+}
+
+// scriptedEvent is one transcriber event from a transcript script.
+type scriptedEvent struct {
+
+	at time.Duration
+	env sttEnvelope
+
+}
+
+// scriptedTranscript stands in for the STT backend: each capture replays the timed events of its section, and captures past
+// the last section replay that one.
+type scriptedTranscript struct {
+
+	captures [][]scriptedEvent
+
+	mu sync.Mutex
+	opened int
+	sent int
+	gated int
+
+}
+
+// loadTranscriptScript reads a transcript script from a file.
+func loadTranscriptScript(Path string) (*scriptedTranscript, error) {
+
+	File, ErrOpen := os.Open(Path)
+
+	if ErrOpen != nil {
+
+		return nil, ErrOpen
+
+	}
+
+	defer File.Close()
+
+	return parseTranscriptScript(Path, File)
+
+}
+
+// parseTranscriptScript reads lines of "<seconds> <interim|final|speech> <text>", timed from when the capture opens; # starts a comment
+// and a "---" line starts the events of the next capture, such as the answer to a confirmation. Path only names the script in errors.
+func parseTranscriptScript(Path string, Reader io.Reader) (*scriptedTranscript, error) {
+
+	Script := &scriptedTranscript{captures: [][]scriptedEvent{nil}}
+	Scanner := bufio.NewScanner(Reader)
+	Line := 0
+
+	for Scanner.Scan() {
+
+		Line++
+		Text := strings.TrimSpace(Scanner.Text())
+
+		if Text == "" || strings.HasPrefix(Text, "#") {
+
+			continue
+
+		}
+
+		if Text == "---" {
+
+			Script.captures = append(Script.captures, nil)
+			continue
+
+		}
+
+		Fields := strings.SplitN(Text, " ", 3)
+
+		if len(Fields) < 2 {
+
+			return nil, fmt.Errorf("%s:%d: expected \"<seconds> <interim|final|speech> <text>\"", Path, Line)
+
+		}
+
+		Seconds, ErrParse := strconv.ParseFloat(Fields[0], 64)
+
+		if ErrParse != nil {
+
+			return nil, fmt.Errorf("%s:%d: bad time %q", Path, Line, Fields[0])
+
+		}
+
+		Event := scriptedEvent{at: time.Duration(Seconds * float64(time.Second))}
+
+		if len(Fields) == 3 {
+
+			Event.env.Text = strings.TrimSpace(Fields[2])
+
+		}
+
+		switch Fields[1] {
+
+		case "interim":
+
+		case "final":
+
+			Event.env.IsFinal = true
+
+		case "speech":
+
+			Event.env.IsFinal = true
+			Event.env.SpeechFinal = true
+
+		default:
+
+			return nil, fmt.Errorf("%s:%d: unknown event %q", Path, Line, Fields[1])
+
+		}
+
+		Last := len(Script.captures) - 1
+		Script.captures[Last] = append(Script.captures[Last], Event)
+
+	}
+
+	return Script, Scanner.Err()
+
+}
+
+func (S *scriptedTranscript) audio() (int, int) {
+
+	S.mu.Lock()
+	defer S.mu.Unlock()
+
+	return S.sent, S.gated
+
+}
+
+func (S *scriptedTranscript) open(Parent context.Context, Clock sessionClock) *scriptedTranscriber {
+
+	Ctx, Cancel := context.WithCancel(Parent)
+
+	S.mu.Lock()
+
+	Events := S.captures[min(S.opened, len(S.captures)-1)]
+	S.opened++
+
+	S.mu.Unlock()
+
+	T := &scriptedTranscriber{script: S, events: Events, clock: Clock, ctx: Ctx, cancel: Cancel, played: make(chan struct{})}
+
+	go T.play()
+
+	return T
+
+}
+
+// scriptedTranscriber plays a transcript script against one capture.
+type scriptedTranscriber struct {
+
+	transcriptState
+
+	script *scriptedTranscript
+	events []scriptedEvent
+	clock sessionClock
+
+	ctx context.Context
+	cancel context.CancelFunc
+
+	played chan struct{}
+	done sync.Once
+	closed bool
+	closedMu sync.Mutex
+
+}
+
+func (T *scriptedTranscriber) play() {
+
+	defer close(T.played)
+
+	Opened := T.clock.Now()
+
+	for _, Event := range T.events {
+
+		select {
+
+		case <-T.clock.After(Opened.Add(Event.at).Sub(T.clock.Now())):
+
+			T.absorbPartial(Event.env)
+
+		case <-T.ctx.Done():
+
+			return
+
+		}
+
+	}
+
+}
+
+// Send counts the audio and how much of it the silence gate of a streaming backend would have dropped.
+func (T *scriptedTranscriber) Send(PCM []byte) error {
+
+	if T.Done() {
+
+		return errTranscriberClosed
+
+	}
+
+	T.script.mu.Lock()
+
+	T.script.sent += len(PCM)
+
+	if isPCMSilent(PCM) {
+
+		T.script.gated += len(PCM)
+
+	}
+
+	T.script.mu.Unlock()
+
+	return nil
+
+}
+
+// Finalize lets the rest of the script play out, like a backend flushing its last words.
+func (T *scriptedTranscriber) Finalize() {
+
+	select {
+
+	case <-T.played:
+
+	case <-T.clock.After(transcribeDoneWait):
+
+	}
+
+	T.absorbDone("")
+	T.Close()
+
+}
+
+func (T *scriptedTranscriber) Done() bool {
+
+	T.closedMu.Lock()
+	defer T.closedMu.Unlock()
+
+	return T.closed
+
+}
+
+func (T *scriptedTranscriber) Close() {
+
+	T.done.Do(func() {
+
+		T.closedMu.Lock()
+		T.closed = true
+		T.closedMu.Unlock()
+
+		T.cancel()
+
+	})
+
+}
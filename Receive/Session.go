@@ -17,7 +17,7 @@ import (
 type transcriberOpenResult struct {
 
 	captureID uint64
-	transcriber *Transcriber
+	transcriber Transcriber
 
 	err error
 
@@ -44,6 +44,8 @@ const (
 
 	discordSpeakingMax = 10 * time.Second
 
+	sessionTickInterval = 100 * time.Millisecond
+
 	sttPreconnectMaxBytes = TargetSampleRate * 2 * 3
 
 )
@@ -53,14 +55,18 @@ type Session struct {
 	GuildID snowflake.ID
 	UserID snowflake.ID
 
-	dispatcher *Dispatcher
+	dispatcher CommandDispatcher
 	decoder *OpusDecoder
 
+	openTranscriberFn func(Parent context.Context, Language string) (Transcriber, error)
+	trace sessionTracer // nil outside offline replays
+	clock sessionClock
+
 	inbox chan []byte
 	inboxDrops atomic.Uint64
 
 	wakeCh chan struct{}
-	tickCh chan struct{}
+	listenCh chan struct{}
 
 	sttUpdates chan TranscriptUpdate
 	opusPreroll opusPreroll
@@ -71,7 +77,7 @@ type Session struct {
 	speakingSince atomic.Int64
 	lastSilentAt atomic.Int64
 
-	transcriber *Transcriber
+	transcriber Transcriber
 	transcriberReady chan transcriberOpenResult
 	captureID atomic.Uint64
 
@@ -89,6 +95,10 @@ type Session struct {
 	lastTranscriptChange atomic.Int64 // UnixNano; 0 = no change yet this capture
 	lastTranscriptText   string       // run-goroutine only
 
+	confirmMu sync.Mutex
+	confirmation *pendingConfirmation
+	confirmCapture bool // run-goroutine only; the current capture is answering a confirmation
+
 	ctx context.Context
 	cancel context.CancelFunc
 	closed atomic.Bool
@@ -97,7 +107,13 @@ type Session struct {
 
 }
 
-func NewSession(GuildID, UserID snowflake.ID, Dispatcher *Dispatcher) (*Session, error) {
+func NewSession(GuildID, UserID snowflake.ID, Dispatcher CommandDispatcher) (*Session, error) {
+
+	return newSession(GuildID, UserID, Dispatcher, NewTranscriber, nil, wallClock{})
+
+}
+
+func newSession(GuildID, UserID snowflake.ID, Dispatcher CommandDispatcher, OpenTranscriber func(context.Context, string) (Transcriber, error), Trace sessionTracer, Clock sessionClock) (*Session, error) {
 
 	Decoder, ErrDecoder := NewOpusDecoder()
 
@@ -117,9 +133,13 @@ func NewSession(GuildID, UserID snowflake.ID, Dispatcher *Dispatcher) (*Session,
 		dispatcher: Dispatcher,
 		decoder: Decoder,
 
+		openTranscriberFn: OpenTranscriber,
+		trace: Trace,
+		clock: Clock,
+
 		inbox: make(chan []byte, 256),
 		wakeCh: make(chan struct{}, 1),
-		tickCh: make(chan struct{}, 1),
+		listenCh: make(chan struct{}, 1),
 
 		opusPreroll: newOpusPreroll(prerollMaxFrames),
 		sttUpdates: make(chan TranscriptUpdate, 32),
@@ -134,18 +154,23 @@ func NewSession(GuildID, UserID snowflake.ID, Dispatcher *Dispatcher) (*Session,
 
 	S.state.Store(stateListening)
 
-	if ErrOpen := PicoOpenStream(GuildID, UserID); ErrOpen != nil {
+	if WakeDetectorReady() {
+
+		if ErrOpen := PicoOpenStream(GuildID, UserID); ErrOpen != nil {
+
+			Utils.Logger.Warn("Receive", fmt.Sprintf("Pico open stream: %s", ErrOpen.Error()))
 
-		Utils.Logger.Warn("Receive", fmt.Sprintf("Pico open stream: %s", ErrOpen.Error()))
+		} else if guildWakeWord(GuildID).customized() {
+
+			configureWake(GuildID, UserID)
+
+		}
 
 	}
 
 	S.wg.Add(1)
 	go S.run(Ctx)
 
-	S.wg.Add(1)
-	go S.timeoutLoop(Ctx)
-
 	return S, nil
 
 }
@@ -191,12 +216,31 @@ func (S *Session) NotifyWake() {
 
 }
 
+// NotifyListen starts a push-to-talk capture.
+func (S *Session) NotifyListen() {
+
+	if S.closed.Load() {
+
+		return
+
+	}
+
+	select {
+
+	case S.listenCh <- struct{}{}:
+
+	default:
+
+	}
+
+}
+
 func (S *Session) SetDiscordSpeaking(Active bool) {
 
 	Was := S.discordSpeaking.Load()
 	S.discordSpeaking.Store(Active)
 
-	Now := time.Now().UnixNano()
+	Now := S.clock.Now().UnixNano()
 
 	if Active && !Was {
 
@@ -229,6 +273,8 @@ func (S *Session) Close() {
 
 	S.wg.Wait()
 
+	S.answerConfirmation(false)
+
 	if S.transcriber != nil {
 
 		S.transcriber.Close()
@@ -261,6 +307,9 @@ func (S *Session) run(Ctx context.Context) {
 
 	}()
 
+	Ticks, StopTicks := S.clock.Ticker(sessionTickInterval)
+	defer StopTicks()
+
 	for {
 
 		select {
@@ -277,6 +326,12 @@ func (S *Session) run(Ctx context.Context) {
 
 			S.beginCapture()
 
+		case <-S.listenCh:
+
+			// The preroll holds speech from before the button press, not a wake word
+			S.opusPreroll.Clear()
+			S.beginCapture()
+
 		case Res := <-S.transcriberReady:
 
 			S.handleTranscriberReady(Res)
@@ -285,7 +340,15 @@ func (S *Session) run(Ctx context.Context) {
 
 			S.handleTranscriptUpdate(Upd)
 
-		case <-S.tickCh:
+		case <-Ticks:
+
+			// Timeouts run on this goroutine too, so they never race the capture state
+
+			if S.trace != nil {
+
+				S.trace.ticked()
+
+			}
 
 			S.checkTimeouts()
 
@@ -297,21 +360,52 @@ func (S *Session) run(Ctx context.Context) {
 
 func (S *Session) handleFrame(Opus []byte) {
 
+	DecodeStart := time.Now()
 	PCM, ErrDecode := S.decoder.Decode(Opus)
 
+	if S.trace != nil {
+
+		S.trace.frameDecoded(time.Since(DecodeStart), ErrDecode)
+
+	}
+
 	if ErrDecode != nil {
 
 		return
 
 	}
 
+	Voiced := isVoicedFrame(PCM)
+
+	if Voiced {
+
+		receiverFor(S.GuildID).noteVoiced(S.UserID)
+
+	}
+
 	switch S.state.Load() {
 
 	case stateListening:
 
 		S.opusPreroll.Push(Opus)
 
-		if !S.cooldownUntil.IsZero() && time.Now().Before(S.cooldownUntil) {
+		if !S.cooldownUntil.IsZero() && S.clock.Now().Before(S.cooldownUntil) {
+
+			return
+
+		}
+
+		// An open confirmation suspends the wake word: the next thing the user says is the answer. Silence and
+		// background noise still come through as frames, so only speech starts the capture
+
+		if P := S.peekConfirmation(); P != nil && Voiced && S.clock.Now().After(P.armedAt) {
+
+			S.beginCapture()
+			return
+
+		}
+
+		if !WakeDetectorReady() {
 
 			return
 
@@ -341,7 +435,7 @@ func (S *Session) beginCapture() {
 
 	captureID := S.captureID.Add(1)
 
-	S.captureStartedAt = time.Now()
+	S.captureStartedAt = S.clock.Now()
 	S.resetCaptureBuffers()
 	S.decoder.Reset()
 
@@ -370,6 +464,9 @@ func (S *Session) beginCapture() {
 	S.finalizing.Store(false)
 	S.dispatched.Store(false)
 	S.awaitingCommandTail = false
+	S.confirmCapture = S.peekConfirmation() != nil
+
+	S.traceStage("capture", "started")
 
 	emitFeedbackCue(S.GuildID, FeedbackCueCaptureStart)
 	emitCaptureDuck(S.GuildID, true)
@@ -383,6 +480,7 @@ func (S *Session) handleTranscriberReady(Res transcriberOpenResult) {
 	if Res.err != nil {
 
 		Utils.Logger.Error("Receive", fmt.Sprintf("Transcriber open failed: %s", Res.err.Error()))
+		S.traceStage("transcriber", Res.err.Error())
 
 		if S.state.Load() == stateCapturing && Res.captureID == S.captureID.Load() {
 
@@ -410,7 +508,7 @@ func (S *Session) handleTranscriberReady(Res transcriberOpenResult) {
 
 }
 
-func (S *Session) attachTranscriber(Trans *Transcriber) {
+func (S *Session) attachTranscriber(Trans Transcriber) {
 
 	if Trans == nil || Trans.Done() {
 
@@ -420,6 +518,7 @@ func (S *Session) attachTranscriber(Trans *Transcriber) {
 	}
 
 	S.transcriber = Trans
+	S.traceStage("transcriber", fmt.Sprintf("ready, %d ms of audio buffered", S.preCapture.Len()*1000/(TargetSampleRate*2)))
 
 	S.transcriber.SetOnUpdate(func(Upd TranscriptUpdate) {
 
@@ -435,7 +534,7 @@ func (S *Session) attachTranscriber(Trans *Transcriber) {
 
 	})
 
-	for _, PCM := range S.preCapture.DrainChunks(xaiPCMChunkBytes) {
+	for _, PCM := range S.preCapture.DrainChunks(sttPCMChunkBytes) {
 
 		_ = S.transcriber.Send(PCM)
 
@@ -451,7 +550,7 @@ func (S *Session) attachTranscriber(Trans *Transcriber) {
 
 func (S *Session) openTranscriber(captureID uint64) {
 
-	Trans, ErrTrans := NewTranscriber(S.ctx)
+	Trans, ErrTrans := S.openTranscriberFn(S.ctx, sttLanguageFor(guildLocale(S.GuildID)))
 
 	Res := transcriberOpenResult{captureID: captureID, transcriber: Trans, err: ErrTrans}
 
@@ -490,7 +589,7 @@ func (S *Session) runCapturing(PCM []int16) {
 
 	}
 
-	if time.Since(S.captureStartedAt) > commandCaptureMax+2*time.Second {
+	if S.clock.Now().Sub(S.captureStartedAt) > commandCaptureMax+2*time.Second {
 
 		S.finalizeCapture()
 		return
@@ -516,7 +615,7 @@ func (S *Session) speakingDuration() time.Duration {
 
 	}
 
-	return time.Duration(time.Now().UnixNano() - At)
+	return time.Duration(S.clock.Now().UnixNano() - At)
 
 }
 
@@ -530,8 +629,10 @@ func (S *Session) handleTranscriptUpdate(Upd TranscriptUpdate) {
 
 	if Upd.Text != "" && Upd.Text != S.lastTranscriptText {
 
+		S.traceStage("transcript", describeUpdate(Upd))
+
 		S.lastTranscriptText = Upd.Text
-		S.lastTranscriptChange.Store(time.Now().UnixNano())
+		S.lastTranscriptChange.Store(S.clock.Now().UnixNano())
 
 	}
 
@@ -546,7 +647,30 @@ func (S *Session) handleTranscriptUpdate(Upd TranscriptUpdate) {
 
 	}
 
-	Cmd, OK := Parse(Upd.Text)
+	if S.confirmCapture {
+
+		if Confirmed, Decided := ParseConfirmation(Upd.Text, guildLocale(S.GuildID)); Decided {
+
+			S.dispatched.Store(true)
+			S.answerConfirmation(Confirmed)
+			S.abortCapture()
+
+			return
+
+		}
+
+		if Upd.SpeechFinal {
+
+			S.finalizeCapture()
+
+		}
+
+		return
+
+	}
+
+	Cmd, OK := Parse(Upd.Text, guildLocale(S.GuildID), guildWakeWord(S.GuildID))
+	Cmd.CapturedAt = S.captureStartedAt
 
 	if !OK {
 
@@ -605,6 +729,8 @@ func (S *Session) dispatchCommand(Cmd ParsedCommand) {
 
 	}
 
+	S.traceStage("dispatch", "immediate")
+
 	if S.dispatcher != nil {
 
 		S.dispatcher.Dispatch(S.GuildID, S.UserID, Cmd)
@@ -613,7 +739,7 @@ func (S *Session) dispatchCommand(Cmd ParsedCommand) {
 
 }
 
-func (S *Session) ensureTranscriber(Timeout time.Duration) *Transcriber {
+func (S *Session) ensureTranscriber(Timeout time.Duration) Transcriber {
 
 	if S.transcriber != nil && !S.transcriber.Done() {
 
@@ -629,7 +755,7 @@ func (S *Session) ensureTranscriber(Timeout time.Duration) *Transcriber {
 
 		S.handleTranscriberReady(Res)
 
-	case <-time.After(Timeout):
+	case <-S.clock.After(Timeout):
 
 	}
 
@@ -645,10 +771,12 @@ func (S *Session) abortCapture() {
 
 	}
 
+	S.traceStage("capture", "aborted")
+
 	Trans := S.transcriber
 	S.transcriber = nil
 
-	go func(T *Transcriber) {
+	go func(T Transcriber) {
 
 		if T != nil {
 
@@ -670,11 +798,14 @@ func (S *Session) finalizeCapture() {
 
 	}
 
+	S.traceStage("finalize", fmt.Sprintf("after %d ms of audio", len(S.capturePCM)*1000/(TargetSampleRate*2)))
+
 	Trans := S.ensureTranscriber(transcribeReadyTimeout + time.Second)
 
 	if Trans == nil {
 
 		Utils.Logger.Warn("Receive", fmt.Sprintf("Capture ended with no transcriber (user %s)", S.UserID))
+		S.traceStage("finalized", "no transcriber")
 		S.endCapture()
 
 		return
@@ -684,16 +815,31 @@ func (S *Session) finalizeCapture() {
 	S.transcriber = nil
 	AlreadyDispatched := S.dispatched.Load()
 
-	go func(T *Transcriber, SkipDispatch bool) {
+	if S.confirmCapture && !AlreadyDispatched {
+
+		S.finalizeConfirmation(Trans)
+
+		return
+
+	}
+
+	CapturedAt := S.captureStartedAt
+
+	go func(T Transcriber, SkipDispatch bool) {
+
+		Outcome := "already dispatched"
 
 		defer func() {
 
 			if r := recover(); r != nil {
 
 				Utils.Logger.Error("Receive", fmt.Sprintf("finalizeCapture panic: %v", r))
+				Outcome = fmt.Sprintf("panic: %v", r)
 
 			}
 
+			S.traceStage("finalized", Outcome)
+
 		}()
 
 		if SkipDispatch {
@@ -708,18 +854,23 @@ func (S *Session) finalizeCapture() {
 
 		if Text == "" {
 
+			Outcome = "empty transcript"
 			return
 
 		}
 
-		Cmd, OK := Parse(Text)
+		Cmd, OK := Parse(Text, guildLocale(S.GuildID), guildWakeWord(S.GuildID))
 
 		if !OK {
 
+			Outcome = fmt.Sprintf("no command in %q", Text)
 			return
 
 		}
 
+		Outcome = fmt.Sprintf("parsed %q", Text)
+		Cmd.CapturedAt = CapturedAt
+
 		if S.dispatcher != nil {
 
 			S.dispatcher.Dispatch(S.GuildID, S.UserID, Cmd)
@@ -732,14 +883,50 @@ func (S *Session) finalizeCapture() {
 
 }
 
+// finalizeConfirmation reads the answer from the final transcript; anything but a clear yes cancels.
+func (S *Session) finalizeConfirmation(Trans Transcriber) {
+
+	P := S.takeConfirmation()
+
+	go func(T Transcriber) {
+
+		defer func() {
+
+			if r := recover(); r != nil {
+
+				Utils.Logger.Error("Receive", fmt.Sprintf("finalizeConfirmation panic: %v", r))
+
+			}
+
+		}()
+
+		T.Finalize()
+
+		Confirmed, _ := ParseConfirmation(T.Result(), guildLocale(S.GuildID))
+		S.traceStage("finalized", fmt.Sprintf("confirmation answered %t", Confirmed))
+
+		if P != nil {
+
+			S.resolveConfirmation(P, Confirmed)
+
+		}
+
+	}(Trans)
+
+	S.endCapture()
+
+}
+
 func (S *Session) endCapture() {
 
+	S.traceStage("capture", "ended")
+
 	S.state.Store(stateListening)
 	S.captureStartedAt = time.Time{}
 	S.resetCaptureBuffers()
 	S.finalizing.Store(false)
 	S.awaitingCommandTail = false
-	S.cooldownUntil = time.Now().Add(postCaptureCooldown)
+	S.cooldownUntil = S.clock.Now().Add(postCaptureCooldown)
 
 	if S.transcriber != nil {
 
@@ -756,7 +943,19 @@ func (S *Session) endCapture() {
 
 	}
 
-	emitFeedbackCue(S.GuildID, FeedbackCueCaptureEnd)
+	// A confirmation capture ends with the accept or cancel cue instead
+
+	if S.confirmCapture {
+
+		S.confirmCapture = false
+		S.answerConfirmation(false)
+
+	} else {
+
+		emitFeedbackCue(S.GuildID, FeedbackCueCaptureEnd)
+
+	}
+
 	emitCaptureDuck(S.GuildID, false)
 
 }
@@ -770,49 +969,21 @@ func (S *Session) resetCaptureBuffers() {
 
 }
 
-func (S *Session) timeoutLoop(Ctx context.Context) {
-
-	defer S.wg.Done()
-
-	Ticker := time.NewTicker(100 * time.Millisecond)
-	defer Ticker.Stop()
-
-	for {
-
-		select {
-
-		case <-Ctx.Done():
-
-			return
-
-		case <-Ticker.C:
-
-			// Deliver a tick to eliminate data races on transcriber, capturePCM, etc.
+func (S *Session) checkTimeouts() {
 
-			select {
+	if S.state.Load() != stateCapturing {
 
-				case S.tickCh <- struct{}{}:
+		if P := S.peekConfirmation(); P != nil && S.clock.Now().After(P.deadline) {
 
-				default:
-
-			}
+			S.answerConfirmation(false)
 
 		}
 
-	}
-
-}
-
-func (S *Session) checkTimeouts() {
-
-	if S.state.Load() != stateCapturing {
-
 		return
 
 	}
 
-	Now := time.Now()
-	CaptureAge := Now.Sub(S.captureStartedAt)
+	CaptureAge := S.clock.Now().Sub(S.captureStartedAt)
 
 	if S.speakingDuration() > discordSpeakingMax {
 
@@ -845,7 +1016,7 @@ func (S *Session) checkTimeouts() {
 
 		if At := S.lastTranscriptChange.Load(); At != 0 {
 
-			if time.Duration(time.Now().UnixNano()-At) >= tailSilenceTimeout {
+			if time.Duration(S.clock.Now().UnixNano()-At) >= tailSilenceTimeout {
 
 				S.finalizeCapture()
 
@@ -888,7 +1059,7 @@ func (S *Session) silenceSinceDiscordStopped() time.Duration {
 
 	}
 
-	return time.Duration(time.Now().UnixNano() - At)
+	return time.Duration(S.clock.Now().UnixNano() - At)
 
 }
 
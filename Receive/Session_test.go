@@ -0,0 +1,211 @@
+package Receive
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"Synthara-Redux/Globals/Localizations"
+)
+
+func TestMain(M *testing.M) {
+
+	// Localizations are read relative to the repository root, and the wake detector stays off without a model
+
+	if ErrChdir := os.Chdir(".."); ErrChdir != nil {
+
+		fmt.Fprintln(os.Stderr, ErrChdir)
+		os.Exit(1)
+
+	}
+
+	if ErrInit := Localizations.Initialize(); ErrInit != nil {
+
+		fmt.Fprintln(os.Stderr, ErrInit)
+		os.Exit(1)
+
+	}
+
+	os.Setenv("PICO_DIR", os.TempDir())
+
+	os.Exit(M.Run())
+
+}
+
+// speechFrames encodes Voiced of a loud tone followed by Quiet of silence, standing in for one spoken command.
+func speechFrames(T *testing.T, Voiced time.Duration, Quiet time.Duration) []replayFrame {
+
+	T.Helper()
+
+	Mono := make([]float64, int((Voiced+Quiet).Seconds()*OpusSampleRate))
+	Loud := int(Voiced.Seconds() * OpusSampleRate)
+
+	for i := 0; i < Loud; i++ {
+
+		Mono[i] = 8000 * math.Sin(2*math.Pi*220*float64(i)/OpusSampleRate)
+
+	}
+
+	Frames, ErrEncode := encodeReplayFrames(Mono, OpusSampleRate)
+
+	if ErrEncode != nil {
+
+		T.Fatalf("encoding frames: %s", ErrEncode)
+
+	}
+
+	return Frames
+
+}
+
+func TestPushToTalkDispatchesScriptedCommand(T *testing.T) {
+
+	Script, ErrScript := parseTranscriptScript("play.txt", strings.NewReader(`
+# the transcriber hears the command grow, then finalizes it
+0.4 interim play some
+0.9 speech play some jazz
+`))
+
+	if ErrScript != nil {
+
+		T.Fatalf("parsing script: %s", ErrScript)
+
+	}
+
+	Report, ErrReplay := replayFrames(speechFrames(T, 1500*time.Millisecond, 3*time.Second), Script, 0, newFakeClock(time.Unix(0, 0)))
+
+	if ErrReplay != nil {
+
+		T.Fatalf("replay: %s", ErrReplay)
+
+	}
+
+	if len(Report.Commands) != 1 {
+
+		T.Fatalf("dispatched %d commands, want 1; events: %v", len(Report.Commands), Report.Events)
+
+	}
+
+	Dispatched := Report.Commands[0].Command
+
+	if Dispatched.Command != CommandPlay || Dispatched.Args != "some jazz" {
+
+		T.Errorf("dispatched %q %q, want %q %q", Dispatched.Command, Dispatched.Args, CommandPlay, "some jazz")
+
+	}
+
+	if Report.VoicedFrames == 0 || Report.SentBytes == 0 {
+
+		T.Errorf("the session heard %d voiced frames and sent %d bytes to the transcriber, want both", Report.VoicedFrames, Report.SentBytes)
+
+	}
+
+	// On the virtual clock the command lands when the script says it was finalized, not when the test got around to it
+
+	if At := Report.Commands[0].At; At < 900*time.Millisecond || At > commandCaptureMax {
+
+		T.Errorf("dispatched at %s, want between 0.9s and %s", At, commandCaptureMax)
+
+	}
+
+}
+
+// confirmationReplay replays "clear", which asks for a yes or no, and then Answer, as separate utterances on a virtual clock.
+func confirmationReplay(T *testing.T, Script string, Answer time.Duration) ReplayReport {
+
+	T.Helper()
+
+	Parsed, ErrScript := parseTranscriptScript("confirm.txt", strings.NewReader(Script))
+
+	if ErrScript != nil {
+
+		T.Fatalf("parsing script: %s", ErrScript)
+
+	}
+
+	Frames := speechFrames(T, 1500*time.Millisecond, 1500*time.Millisecond)
+
+	if Answer > 0 {
+
+		Frames = append(Frames, speechFrames(T, Answer, 3*time.Second)...)
+
+	}
+
+	Report, ErrReplay := replayFrames(Frames, Parsed, 0, newFakeClock(time.Unix(0, 0)))
+
+	if ErrReplay != nil {
+
+		T.Fatalf("replay: %s", ErrReplay)
+
+	}
+
+	if len(Report.Commands) != 1 || Report.Commands[0].Command.Command != CommandClear {
+
+		T.Fatalf("dispatched %v, want only %q; events: %v", Report.Commands, CommandClear, Report.Events)
+
+	}
+
+	if len(Report.Confirmations) != 1 {
+
+		T.Fatalf("answered %d confirmations, want 1; events: %v", len(Report.Confirmations), Report.Events)
+
+	}
+
+	return Report
+
+}
+
+func TestConfirmationAnsweredWithoutWakeWord(T *testing.T) {
+
+	Report := confirmationReplay(T, `
+0.9 speech clear the queue
+---
+0.4 speech yes please
+`, time.Second)
+
+	Answer := Report.Confirmations[0]
+
+	if !Answer.Confirmed {
+
+		T.Errorf("the confirmation was refused, want it accepted; events: %v", Report.Events)
+
+	}
+
+	// The answer starts three seconds in, once the question is armed, and is read as soon as it is final
+
+	if Answer.At < 3*time.Second || Answer.At > 3*time.Second+commandCaptureMax {
+
+		T.Errorf("answered at %s, want shortly after the reply at 3s", Answer.At)
+
+	}
+
+}
+
+func TestConfirmationTimesOutOnVirtualClock(T *testing.T) {
+
+	Report := confirmationReplay(T, `
+0.9 speech clear the queue
+`, 0)
+
+	Answer := Report.Confirmations[0]
+
+	if Answer.Confirmed {
+
+		T.Errorf("an unanswered confirmation was accepted; events: %v", Report.Events)
+
+	}
+
+	// Nobody speaks again, so only the virtual clock passing the window can close the question
+
+	Asked := Report.Commands[0].At
+
+	if Answer.At < Asked+confirmWindow || Answer.At > Asked+confirmWindow+time.Second {
+
+		T.Errorf("timed out at %s, want %s after the question at %s", Answer.At, confirmWindow, Asked)
+
+	}
+
+}
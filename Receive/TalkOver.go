@@ -0,0 +1,187 @@
+package Receive
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"Synthara-Redux/Globals"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const (
+
+	talkOverSpeechRMS = 0.02 // frames quieter than this (of full scale) are breathing or background noise
+	talkOverHang = 350 * time.Millisecond // keeps the duck across pauses between words
+
+	opusSilenceFrameBytes = 3 // clients send a few of these comfort-noise frames after they stop talking
+
+)
+
+// TalkOverHandler is told when anyone in a guild's voice channel starts or stops talking.
+type TalkOverHandler func(GuildID snowflake.ID, Talking bool)
+
+var (
+
+	talkOverMu sync.RWMutex
+	talkOverFn TalkOverHandler
+
+)
+
+func SetTalkOverHandler(fn TalkOverHandler) {
+
+	talkOverMu.Lock()
+	talkOverFn = fn
+	talkOverMu.Unlock()
+
+}
+
+func emitTalkOver(GuildID snowflake.ID, Talking bool) {
+
+	talkOverMu.RLock()
+	fn := talkOverFn
+	talkOverMu.RUnlock()
+
+	if fn != nil {
+
+		fn(GuildID, Talking)
+
+	}
+
+}
+
+// talkState tracks whether any human in the channel is talking.
+type talkState struct {
+
+	mu sync.Mutex
+
+	talking bool
+	lastVoicedAt time.Time
+	timer *time.Timer
+
+}
+
+// noteVoiced marks speech from a user, starting the talk-over duck if nobody was talking.
+func (R *Receiver) noteVoiced(UserID snowflake.ID) {
+
+	if R == nil || isBotUser(R.GuildID, UserID) {
+
+		return
+
+	}
+
+	T := &R.talk
+
+	T.mu.Lock()
+
+	T.lastVoicedAt = time.Now()
+	Started := !T.talking
+	T.talking = true
+
+	if T.timer == nil {
+
+		T.timer = time.AfterFunc(talkOverHang, R.checkTalkOver)
+
+	} else if Started {
+
+		T.timer.Reset(talkOverHang)
+
+	}
+
+	T.mu.Unlock()
+
+	if Started {
+
+		emitTalkOver(R.GuildID, true)
+
+	}
+
+}
+
+// checkTalkOver ends the duck once nobody has spoken for the hang time.
+func (R *Receiver) checkTalkOver() {
+
+	T := &R.talk
+
+	T.mu.Lock()
+
+	if !T.talking {
+
+		T.mu.Unlock()
+		return
+
+	}
+
+	if Quiet := time.Since(T.lastVoicedAt); Quiet < talkOverHang {
+
+		T.timer.Reset(talkOverHang - Quiet)
+		T.mu.Unlock()
+
+		return
+
+	}
+
+	T.talking = false
+	T.mu.Unlock()
+
+	emitTalkOver(R.GuildID, false)
+
+}
+
+// stopTalkOver releases the duck when the receiver goes away.
+func (R *Receiver) stopTalkOver() {
+
+	T := &R.talk
+
+	T.mu.Lock()
+
+	Was := T.talking
+	T.talking = false
+
+	if T.timer != nil {
+
+		T.timer.Stop()
+
+	}
+
+	T.mu.Unlock()
+
+	if Was {
+
+		emitTalkOver(R.GuildID, false)
+
+	}
+
+}
+
+// isVoicedFrame reports whether a decoded frame is loud enough to be speech.
+func isVoicedFrame(PCM []int16) bool {
+
+	if len(PCM) == 0 {
+
+		return false
+
+	}
+
+	var Sum float64
+
+	for _, Sample := range PCM {
+
+		Value := float64(Sample) / 32768
+
+		Sum += Value * Value
+
+	}
+
+	return math.Sqrt(Sum/float64(len(PCM))) >= talkOverSpeechRMS
+
+}
+
+func isBotUser(GuildID, UserID snowflake.ID) bool {
+
+	Member, Exists := Globals.DiscordClient.Caches.Member(GuildID, UserID)
+
+	return Exists && Member.User.Bot
+
+}
@@ -2,44 +2,39 @@ package Receive
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
-	"net/url"
 	"os"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"Synthara-Redux/Utils"
-
-	"github.com/gorilla/websocket"
+	"Synthara-Redux/Globals/Localizations"
 )
 
 const (
 
-	sttLanguage = "en" // English only
-
 	sttLanguageEnv = "VOICE_STT_LANGUAGE"
 
-	xaiPCMChunkBytes = 3200 // 100ms PCM16 mono @ 16kHz.
+	sttPCMChunkBytes = 3200 // 100ms PCM16 mono @ 16kHz.
 
 	sttDebugEnv = "VOICE_STT_DEBUG"
 
+	sttBackendEnv = "VOICE_STT_BACKEND"
+
+	STTBackendXAI   = "xai"   // xAI streaming WebSocket (default)
+	STTBackendLocal = "local" // local engine sidecar, see TranscribeLocal.go
+
 	transcribeHardTimeout = 12 * time.Second
 	transcribeReadyTimeout = 5 * time.Second
 	transcribeDoneWait = 5 * time.Second
 
-	envSTTEndpointing = "VOICE_STT_ENDPOINTING_MS"
-
-	defaultEndpointing = 500 // silence gap in ms to finalize an utterance, per xAI recommendation
-
 	pcmSilenceThreshold = 256 // pcmSilenceThreshold is the per-sample absolute-value ceiling below which a PCM16 chunk is considered silent and is not forwarded to xAI.
 
 )
 
+var errTranscriberClosed = errors.New("transcriber closed")
+
 // TranscriptUpdate is delivered to the session on each STT partial.
 type TranscriptUpdate struct {
 
@@ -53,35 +48,26 @@ type TranscriptUpdate struct {
 // Called from the transcriber read loop.
 type OnTranscriptFunc func(TranscriptUpdate)
 
-// Transcriber owns a single xAI STT WebSocket session.
-type Transcriber struct {
-
-	conn *websocket.Conn
-	cancel context.CancelFunc
+// Transcriber is one streaming speech-to-text session for a single capture.
+type Transcriber interface {
 
-	ready chan struct{}
-	done chan struct{}
+	SetOnUpdate(Fn OnTranscriptFunc)
 
-	writeMu sync.Mutex
-	pcmBuf  *PCMBuffer
+	Send(PCM []byte) error // 16 kHz mono PCM16-LE
 
-	startOnce sync.Once
-	timeoutOnce sync.Once
-	closeOnce sync.Once
-	doneOnce sync.Once
-
-	textMu sync.Mutex
-	text string // committed: stitched, finalized utterances
-	utterance string // chunk-finals for the in-progress utterance (preview only)
-	interim string // volatile interim hypothesis for the current chunk
+	Finalize() // flushes audio and waits for the final transcript, then closes
+	Result() string
 
-	onUpdate   OnTranscriptFunc
-	onUpdateMu sync.Mutex
+	Done() bool
+	Close()
 
 }
 
+// sttEnvelope is the event shape shared by the xAI WebSocket and the local sidecar.
 type sttEnvelope struct {
 
+	ID string `json:"id,omitempty"` // local sidecar stream id
+
 	Type string `json:"type"`
 
 	Text string `json:"text,omitempty"`
@@ -92,88 +78,73 @@ type sttEnvelope struct {
 
 }
 
-// isPCMSilent reports whether every PCM16-LE sample in the chunk falls within the silence threshold.
-func isPCMSilent(PCM []byte) bool {
-
-	for i := 0; i+1 < len(PCM); i += 2 {
-
-		s := int16(uint16(PCM[i]) | uint16(PCM[i+1])<<8)
+// STTBackend returns the configured backend name.
+func STTBackend() string {
 
-		if s < -pcmSilenceThreshold || s > pcmSilenceThreshold {
+	Backend := strings.ToLower(strings.TrimSpace(os.Getenv(sttBackendEnv)))
 
-			return false
+	if Backend == "" {
 
-		}
+		return STTBackendXAI
 
 	}
 
-	return true
+	return Backend
 
 }
 
-// NewTranscriber dials xAI and waits for transcript.created.
-func NewTranscriber(Parent context.Context) (*Transcriber, error) {
+// NewTranscriber opens a transcriber for Language (ISO 639-1) on the configured backend.
+func NewTranscriber(Parent context.Context, Language string) (Transcriber, error) {
 
-	APIKey := os.Getenv("XAI_API_KEY")
+	switch STTBackend() {
 
-	if APIKey == "" {
+	case STTBackendXAI:
 
-		return nil, errors.New("XAI_API_KEY not set")
+		T, Err := newXAITranscriber(Parent, Language)
 
-	}
+		if Err != nil {
 
-	DialCtx, DialCancel := context.WithTimeout(Parent, 5*time.Second)
-	defer DialCancel()
+			return nil, Err
 
-	Headers := http.Header{}
-	Headers.Set("Authorization", "Bearer "+APIKey)
+		}
 
-	Conn, _, ErrDial := websocket.DefaultDialer.DialContext(DialCtx, xaiSTTWebSocketURL(), Headers)
+		return T, nil
 
-	if ErrDial != nil {
+	case STTBackendLocal:
 
-		return nil, fmt.Errorf("xai stt dial: %w", ErrDial)
+		T, Err := newLocalTranscriber(Parent, Language)
 
-	}
+		if Err != nil {
 
-	Ctx, Cancel := context.WithCancel(Parent)
+			return nil, Err
 
-	T := &Transcriber{
+		}
 
-		conn: Conn,
+		return T, nil
 
-		cancel: Cancel,
-		ready: make(chan struct{}),
-		done: make(chan struct{}),
+	default:
 
-		pcmBuf: NewPCMBuffer(xaiPCMChunkBytes * 4),
+		return nil, fmt.Errorf("unknown %s=%q", sttBackendEnv, STTBackend())
 
 	}
 
-	go T.readLoop(Ctx)
-
-	select {
-
-	case <-T.ready:
-
-	case <-time.After(transcribeReadyTimeout):
-
-		T.Close()
-		return nil, errors.New("xai stt never sent transcript.created")
-
-	case <-Ctx.Done():
+}
 
-		T.Close()
-		return nil, Ctx.Err()
+// transcriptState stitches partial and final events into the best transcript so far; shared by every backend.
+type transcriptState struct {
 
-	}
+	textMu sync.Mutex
+	text string // committed: stitched, finalized utterances
+	utterance string // chunk-finals for the in-progress utterance (preview only)
+	interim string // volatile interim hypothesis for the current chunk
 
-	return T, nil
+	onUpdate   OnTranscriptFunc
+	onUpdateMu sync.Mutex
 
 }
 
 // SetOnUpdate registers a callback for streaming transcript events.
-func (T *Transcriber) SetOnUpdate(Fn OnTranscriptFunc) {
+func (T *transcriptState) SetOnUpdate(Fn OnTranscriptFunc) {
 
 	T.onUpdateMu.Lock()
 	T.onUpdate = Fn
@@ -181,183 +152,14 @@ func (T *Transcriber) SetOnUpdate(Fn OnTranscriptFunc) {
 
 }
 
-// Send appends PCM and flushes in ~100ms chunks (xAI recommendation). Silent chunks are dropped.
-func (T *Transcriber) Send(PCM []byte) error {
-
-	if T == nil {
-
-		return errors.New("transcriber closed")
-
-	}
-
-	if len(PCM) == 0 {
-
-		return nil
-
-	}
-
-	select {
-
-		case <-T.done:
-
-			return errors.New("transcriber finished")
-
-		default:
-
-	}
-
-	T.writeMu.Lock()
-	defer T.writeMu.Unlock()
-
-	if T.conn == nil {
-
-		return errors.New("transcriber closed")
-
-	}
-
-	T.pcmBuf.Append(PCM)
-
-	for _, Chunk := range T.pcmBuf.DrainChunks(xaiPCMChunkBytes) {
-
-		if isPCMSilent(Chunk) {
-
-			continue
-
-		}
-
-		if Err := T.writePCM(Chunk); Err != nil {
-
-			return Err
-
-		}
-
-	}
-
-	return nil
-
-}
-
-func (T *Transcriber) writePCM(PCM []byte) error {
-
-	T.startHardTimeout()
-
-	T.conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
-
-	if Err := T.conn.WriteMessage(websocket.BinaryMessage, PCM); Err != nil {
-
-		return Err
-
-	}
-
-	return nil
-
-}
-
-func (T *Transcriber) startHardTimeout() {
-
-	T.timeoutOnce.Do(func() {
-
-		go func() {
-
-			select {
-
-			case <-time.After(transcribeHardTimeout):
-
-				Utils.Logger.Warn("Receive", "Transcriber: hard timeout reached, finalizing")
-				T.Finalize()
-
-			case <-T.done:
-
-			}
-
-		}()
-
-	})
-
-}
-
-func (T *Transcriber) Done() bool {
-
-	if T == nil {
-
-		return true
-
-	}
-
-	select {
-
-	case <-T.done:
-
-		return true
-
-	default:
-
-		return false
-
-	}
-
-}
-
-// Finalize flushes audio, sends audio.done, and waits for transcript.done.
-func (T *Transcriber) Finalize() {
-
-	T.startOnce.Do(func() {
-
-		T.writeMu.Lock()
-
-		if T.conn != nil {
-
-			for _, Chunk := range T.pcmBuf.DrainChunks(xaiPCMChunkBytes) {
-
-				if !isPCMSilent(Chunk) {
-
-					_ = T.writePCM(Chunk)
-
-				}
-
-			}
-
-			if Tail := T.pcmBuf.Remainder(); len(Tail) > 0 && !isPCMSilent(Tail) {
-
-				_ = T.writePCM(Tail)
-
-			}
-
-		}
-
-		if T.conn != nil {
-
-			T.conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
-			_ = T.conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"audio.done"}`))
-
-		}
-
-		T.writeMu.Unlock()
-
-	})
-
-	select {
-
-	case <-T.done:
-
-	case <-time.After(transcribeDoneWait):
-
-		Utils.Logger.Warn("Receive", "Transcriber: transcript.done not received in time")
-
-	}
-
-	T.Close()
-
-}
-
 // Result returns the best transcript text after Finalize.
-func (T *Transcriber) Result() string {
+func (T *transcriptState) Result() string {
 
 	return T.bestText()
 
 }
 
-func (T *Transcriber) bestText() string {
+func (T *transcriptState) bestText() string {
 
 	T.textMu.Lock()
 	defer T.textMu.Unlock()
@@ -366,104 +168,7 @@ func (T *Transcriber) bestText() string {
 
 }
 
-// joinSpace concatenates non-empty, trimmed parts with a single space.
-func joinSpace(Parts ...string) string {
-
-	Out := ""
-
-	for _, P := range Parts {
-
-		P = strings.TrimSpace(P)
-
-		if P == "" {
-
-			continue
-
-		}
-
-		if Out == "" {
-
-			Out = P
-
-		} else {
-
-			Out = Out + " " + P
-
-		}
-
-	}
-
-	return Out
-
-}
-
-// appendFinal appends Addition to Existing unless it is already the tail of Existing. Both are trimmed, and if Existing is empty, Addition is returned as-is.
-func appendFinal(Existing, Addition string) string {
-
-	Addition = strings.TrimSpace(Addition)
-
-	if Addition == "" {
-
-		return Existing
-
-	}
-
-	if Existing == "" {
-
-		return Addition
-
-	}
-
-	if strings.HasSuffix(Existing, Addition) {
-
-		return Existing
-
-	}
-
-	return Existing + " " + Addition
-
-}
-
-// Close shuts the WebSocket. Idempotent.
-func (T *Transcriber) Close() {
-
-	T.closeOnce.Do(func() {
-
-		if T.cancel != nil {
-
-			T.cancel()
-
-		}
-
-		T.writeMu.Lock()
-
-		if T.conn != nil {
-
-			_ = T.conn.Close()
-
-			T.conn = nil
-
-		}
-
-		T.writeMu.Unlock()
-
-		T.signalDone()
-
-	})
-
-}
-
-func (T *Transcriber) signalDone() {
-
-	T.doneOnce.Do(func() {
-
-		close(T.done)
-
-	})
-
-}
-
-func (T *Transcriber) emitUpdate(Upd TranscriptUpdate) {
+func (T *transcriptState) emitUpdate(Upd TranscriptUpdate) {
 
 	if Upd.Text == "" && !Upd.SpeechFinal {
 
@@ -483,7 +188,7 @@ func (T *Transcriber) emitUpdate(Upd TranscriptUpdate) {
 
 }
 
-func (T *Transcriber) absorbPartial(Env sttEnvelope) {
+func (T *transcriptState) absorbPartial(Env sttEnvelope) {
 
 	T.textMu.Lock()
 
@@ -535,187 +240,118 @@ func (T *Transcriber) absorbPartial(Env sttEnvelope) {
 
 }
 
-func (T *Transcriber) readLoop(Ctx context.Context) {
-
-	defer T.signalDone()
+// absorbDone applies the end-of-stream transcript; empty text folds any pending preview into the committed text.
+func (T *transcriptState) absorbDone(Text string) {
 
-	defer func() {
-
-		if r := recover(); r != nil {
-
-			Utils.Logger.Error("Receive", fmt.Sprintf("Transcriber readLoop panic: %v", r))
-
-		}
+	T.textMu.Lock()
 
-	}()
+	if Text != "" {
 
-	Conn := T.conn
+		T.text = strings.TrimSpace(Text)
 
-	if Conn == nil {
+	} else {
 
-		return
+		T.text = joinSpace(T.text, T.utterance, T.interim)
 
 	}
 
-	for {
-
-		if Ctx.Err() != nil {
+	T.utterance = ""
+	T.interim = ""
 
-			return
+	Best := T.text
 
-		}
+	T.textMu.Unlock()
 
-		Conn.SetReadDeadline(time.Now().Add(transcribeHardTimeout + 5*time.Second))
+	T.emitUpdate(TranscriptUpdate{Text: Best, IsFinal: true, SpeechFinal: true})
 
-		MessageType, Data, ErrRead := Conn.ReadMessage()
+}
 
-		if ErrRead != nil {
+// isPCMSilent reports whether every PCM16-LE sample in the chunk falls within the silence threshold.
+func isPCMSilent(PCM []byte) bool {
 
-			if Ctx.Err() == nil {
+	for i := 0; i+1 < len(PCM); i += 2 {
 
-				Utils.Logger.Warn("Receive", fmt.Sprintf("Transcriber read ended: %v", ErrRead))
+		s := int16(uint16(PCM[i]) | uint16(PCM[i+1])<<8)
 
-			}
+		if s < -pcmSilenceThreshold || s > pcmSilenceThreshold {
 
-			return
+			return false
 
 		}
 
-		if MessageType != websocket.TextMessage {
+	}
 
-			continue
+	return true
 
-		}
+}
 
-		var Env sttEnvelope
+// joinSpace concatenates non-empty, trimmed parts with a single space.
+func joinSpace(Parts ...string) string {
 
-		if ErrUnmarshal := json.Unmarshal(Data, &Env); ErrUnmarshal != nil {
+	Out := ""
 
-			if os.Getenv(sttDebugEnv) != "" {
+	for _, P := range Parts {
 
-				Utils.Logger.Warn("Receive", fmt.Sprintf("STT JSON unmarshal: %v raw=%q", ErrUnmarshal, string(Data)))
+		P = strings.TrimSpace(P)
 
-			}
+		if P == "" {
 
 			continue
 
 		}
 
-		if os.Getenv(sttDebugEnv) != "" {
-
-			Utils.Logger.Info("Receive", fmt.Sprintf("STT event: %s", string(Data)))
-
-		}
-
-		switch Env.Type {
-
-		case "transcript.created":
-
-			select {
-
-			case <-T.ready:
-
-			default:
-
-				close(T.ready)
-
-			}
-
-		case "transcript.partial":
-
-			T.absorbPartial(Env)
-
-		case "transcript.done":
-
-			T.textMu.Lock()
-
-			if Env.Text != "" {
-
-				// Authoritative full transcript after audio.done flush
-
-				T.text = strings.TrimSpace(Env.Text)
-
-			} else {
-
-				// Empty flush, so we fold any pending preview into the committed text
-
-				T.text = joinSpace(T.text, T.utterance, T.interim)
+		if Out == "" {
 
-				if os.Getenv(sttDebugEnv) != "" {
+			Out = P
 
-					Utils.Logger.Info("Receive", "STT transcript.done with empty text (WebSocket)")
+		} else {
 
-				}
+			Out = Out + " " + P
 
-			}
+		}
 
-			T.utterance = ""
-			T.interim = ""
+	}
 
-			Best := T.text
+	return Out
 
-			T.textMu.Unlock()
+}
 
-			T.emitUpdate(TranscriptUpdate{Text: Best, IsFinal: true, SpeechFinal: true})
-			return
+// appendFinal appends Addition to Existing unless it is already the tail of Existing. Both are trimmed, and if Existing is empty, Addition is returned as-is.
+func appendFinal(Existing, Addition string) string {
 
-		case "error":
+	Addition = strings.TrimSpace(Addition)
 
-			Utils.Logger.Error("Receive", fmt.Sprintf("xAI STT error: %s", Env.Message))
-			return
+	if Addition == "" {
 
-		}
+		return Existing
 
 	}
 
-}
-
-func sttLanguageValue() string {
-
-	if V := os.Getenv(sttLanguageEnv); V != "" {
+	if Existing == "" {
 
-		return V
+		return Addition
 
 	}
 
-	return sttLanguage
-
-}
+	if strings.HasSuffix(Existing, Addition) {
 
-func xaiSTTWebSocketURL() string {
+		return Existing
 
-	Lang := url.QueryEscape(sttLanguageValue())
-	Endpointing := strconv.Itoa(sttEndpointingMS())
+	}
 
-	return "wss://api.x.ai/v1/stt" +
-		"?sample_rate=16000" +
-		"&encoding=pcm" +
-		"&language=" + Lang +
-		"&interim_results=true" +
-		"&endpointing=" + Endpointing +
-		"&filler_words=false"
+	return Existing + " " + Addition
 
 }
 
-func sttEndpointingMS() int {
-
-	V := os.Getenv(envSTTEndpointing)
-
-	if V == "" {
+// sttLanguageFor picks the transcription language for a guild locale; VOICE_STT_LANGUAGE pins one language for every guild.
+func sttLanguageFor(Locale string) string {
 
-		return defaultEndpointing
-
-	}
-
-	Parsed, Err := strconv.Atoi(V)
-
-	if Err != nil || Parsed < 0 || Parsed > 5000 {
+	if V := os.Getenv(sttLanguageEnv); V != "" {
 
-		Utils.Logger.Warn("Receive", fmt.Sprintf("Invalid %s=%q, using %d", envSTTEndpointing, V, defaultEndpointing))
-		return defaultEndpointing
+		return V
 
 	}
 
-	return Parsed
+	return Localizations.Language(Locale) // the default locale's language when the guild locale is unknown
 
 }
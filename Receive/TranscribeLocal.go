@@ -0,0 +1,564 @@
+package Receive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"Synthara-Redux/Utils"
+)
+
+// The local backend speaks the Porcupine framing to a speech engine sidecar (whisper.cpp, Vosk, ...).
+// Frames in: open (payload: language)/pcm/finish/close per stream. Events out: one JSON sttEnvelope per line, tagged with the stream id.
+
+const (
+
+	sttLocalOpOpen = 1
+	sttLocalOpPCM = 2
+	sttLocalOpClose = 3
+	sttLocalOpFinish = 5 // end of audio; the sidecar replies with transcript.done
+
+	envSTTLocalAddr = "VOICE_STT_LOCAL_ADDR" // host:port or unix:/path of an already running engine
+	envSTTLocalCmd = "VOICE_STT_LOCAL_CMD" // command that starts the sidecar on stdin/stdout
+	envSTTLocalDir = "VOICE_STT_LOCAL_DIR"
+
+	defaultSTTLocalDir = "./Modules/stt"
+	defaultSTTLocalCmd = "python3 sidecar.py"
+
+	sttLocalRetryAfter = 10 * time.Second // spacing between restart attempts after the link drops
+
+)
+
+var (
+
+	sttLocalMu sync.Mutex // guards (re)connecting
+	sttLocalWriter io.WriteCloser // guarded by sttLocalWriteMu
+	sttLocalReady atomic.Bool
+	sttLocalLastAttempt time.Time
+	sttLocalLastErr error
+
+	sttLocalWriteMu sync.Mutex
+
+	sttLocalStreams sync.Map // stream id -> *localTranscriber
+	sttLocalSeq atomic.Uint64
+
+)
+
+// localTranscriber is one capture streamed to the shared local engine link.
+type localTranscriber struct {
+
+	transcriptState
+
+	id string
+
+	ready chan struct{}
+	done chan struct{}
+
+	writeMu sync.Mutex
+	pcmBuf  *PCMBuffer
+
+	startOnce sync.Once
+	timeoutOnce sync.Once
+	closeOnce sync.Once
+	readyOnce sync.Once
+	doneOnce sync.Once
+
+}
+
+// LocalSTTReady reports whether the local engine link is up, starting it if needed.
+func LocalSTTReady() bool {
+
+	return ensureLocalSTTLink() == nil
+
+}
+
+func ensureLocalSTTLink() error {
+
+	sttLocalMu.Lock()
+	defer sttLocalMu.Unlock()
+
+	if sttLocalReady.Load() {
+
+		return nil
+
+	}
+
+	if !sttLocalLastAttempt.IsZero() && time.Since(sttLocalLastAttempt) < sttLocalRetryAfter {
+
+		if sttLocalLastErr != nil {
+
+			return sttLocalLastErr
+
+		}
+
+		return errors.New("local stt engine unavailable")
+
+	}
+
+	sttLocalLastAttempt = time.Now()
+
+	var Reader io.Reader
+	var Writer io.WriteCloser
+	var Err error
+
+	if Addr := strings.TrimSpace(os.Getenv(envSTTLocalAddr)); Addr != "" {
+
+		Reader, Writer, Err = dialLocalSTT(Addr)
+
+	} else {
+
+		Reader, Writer, Err = startLocalSTTProcess()
+
+	}
+
+	sttLocalLastErr = Err
+
+	if Err != nil {
+
+		Utils.Logger.Warn("Receive", "Local STT engine unavailable: "+Err.Error())
+		return Err
+
+	}
+
+	sttLocalWriteMu.Lock()
+	sttLocalWriter = Writer
+	sttLocalWriteMu.Unlock()
+
+	sttLocalReady.Store(true)
+
+	go readLocalSTT(Reader)
+
+	Utils.Logger.Info("Receive", "Local STT engine connected.")
+
+	return nil
+
+}
+
+func dialLocalSTT(Addr string) (io.Reader, io.WriteCloser, error) {
+
+	Network := "tcp"
+
+	if Path, IsUnix := strings.CutPrefix(Addr, "unix:"); IsUnix {
+
+		Network, Addr = "unix", Path
+
+	}
+
+	Conn, Err := net.DialTimeout(Network, Addr, 5*time.Second)
+
+	if Err != nil {
+
+		return nil, nil, fmt.Errorf("local stt dial: %w", Err)
+
+	}
+
+	return Conn, Conn, nil
+
+}
+
+func startLocalSTTProcess() (io.Reader, io.WriteCloser, error) {
+
+	Dir := os.Getenv(envSTTLocalDir)
+
+	if Dir == "" {
+
+		Dir = defaultSTTLocalDir
+
+	}
+
+	CommandLine := os.Getenv(envSTTLocalCmd)
+
+	if CommandLine == "" {
+
+		CommandLine = defaultSTTLocalCmd
+
+	}
+
+	Fields := strings.Fields(CommandLine)
+
+	Cmd := exec.Command(Fields[0], Fields[1:]...)
+	Cmd.Dir = Dir
+	Cmd.Stderr = os.Stderr
+
+	Stdin, ErrStdin := Cmd.StdinPipe()
+
+	if ErrStdin != nil {
+
+		return nil, nil, ErrStdin
+
+	}
+
+	Stdout, ErrStdout := Cmd.StdoutPipe()
+
+	if ErrStdout != nil {
+
+		return nil, nil, ErrStdout
+
+	}
+
+	if ErrStart := Cmd.Start(); ErrStart != nil {
+
+		return nil, nil, fmt.Errorf("local stt start: %w", ErrStart)
+
+	}
+
+	go func() {
+
+		if ErrWait := Cmd.Wait(); ErrWait != nil && !errors.Is(ErrWait, os.ErrProcessDone) {
+
+			Utils.Logger.Warn("Receive", "Local STT sidecar exited: "+ErrWait.Error())
+
+		}
+
+	}()
+
+	return Stdout, Stdin, nil
+
+}
+
+// readLocalSTT routes sidecar events to their streams until the link drops, then fails every open stream.
+func readLocalSTT(Out io.Reader) {
+
+	defer func() {
+
+		if r := recover(); r != nil {
+
+			Utils.Logger.Error("Receive", fmt.Sprintf("Local STT reader panic: %v", r))
+
+		}
+
+		dropLocalSTTLink()
+
+	}()
+
+	Scanner := bufio.NewScanner(Out)
+	Scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+
+	for Scanner.Scan() {
+
+		var Env sttEnvelope
+
+		if ErrUnmarshal := json.Unmarshal(Scanner.Bytes(), &Env); ErrUnmarshal != nil {
+
+			if os.Getenv(sttDebugEnv) != "" {
+
+				Utils.Logger.Warn("Receive", fmt.Sprintf("Local STT JSON unmarshal: %v raw=%q", ErrUnmarshal, Scanner.Text()))
+
+			}
+
+			continue
+
+		}
+
+		if os.Getenv(sttDebugEnv) != "" {
+
+			Utils.Logger.Info("Receive", fmt.Sprintf("Local STT event: %s", Scanner.Text()))
+
+		}
+
+		Value, Exists := sttLocalStreams.Load(Env.ID)
+
+		if !Exists {
+
+			continue
+
+		}
+
+		Value.(*localTranscriber).handleEvent(Env)
+
+	}
+
+}
+
+func dropLocalSTTLink() {
+
+	sttLocalReady.Store(false)
+
+	sttLocalWriteMu.Lock()
+
+	if sttLocalWriter != nil {
+
+		_ = sttLocalWriter.Close()
+		sttLocalWriter = nil
+
+	}
+
+	sttLocalWriteMu.Unlock()
+
+	Utils.Logger.Warn("Receive", "Local STT engine link closed")
+
+	sttLocalStreams.Range(func(_, Value any) bool {
+
+		Value.(*localTranscriber).signalDone()
+		return true
+
+	})
+
+}
+
+func writeLocalSTTFrame(Op byte, StreamID string, Payload []byte) error {
+
+	if !sttLocalReady.Load() {
+
+		return errors.New("local stt engine not ready")
+
+	}
+
+	Frame := encodeStreamFrame(Op, StreamID, Payload, Op == sttLocalOpPCM || Op == sttLocalOpOpen)
+
+	sttLocalWriteMu.Lock()
+	defer sttLocalWriteMu.Unlock()
+
+	if sttLocalWriter == nil {
+
+		return errors.New("local stt engine not ready")
+
+	}
+
+	_, Err := sttLocalWriter.Write(Frame)
+
+	return Err
+
+}
+
+// newLocalTranscriber opens a stream for Language on the local engine and waits for transcript.created.
+func newLocalTranscriber(Parent context.Context, Language string) (*localTranscriber, error) {
+
+	if Err := ensureLocalSTTLink(); Err != nil {
+
+		return nil, Err
+
+	}
+
+	T := &localTranscriber{
+
+		id: strconv.FormatUint(sttLocalSeq.Add(1), 10),
+
+		ready: make(chan struct{}),
+		done: make(chan struct{}),
+
+		pcmBuf: NewPCMBuffer(sttPCMChunkBytes * 4),
+
+	}
+
+	sttLocalStreams.Store(T.id, T)
+
+	// The open frame carries the language as its payload so one sidecar can serve several languages
+
+	if Err := writeLocalSTTFrame(sttLocalOpOpen, T.id, []byte(Language)); Err != nil {
+
+		T.Close()
+		return nil, Err
+
+	}
+
+	select {
+
+	case <-T.ready:
+
+	case <-T.done:
+
+		T.Close()
+		return nil, errors.New("local stt stream closed before transcript.created")
+
+	case <-time.After(transcribeReadyTimeout):
+
+		T.Close()
+		return nil, errors.New("local stt never sent transcript.created")
+
+	case <-Parent.Done():
+
+		T.Close()
+		return nil, Parent.Err()
+
+	}
+
+	return T, nil
+
+}
+
+func (T *localTranscriber) handleEvent(Env sttEnvelope) {
+
+	switch Env.Type {
+
+	case "transcript.created":
+
+		T.readyOnce.Do(func() {
+
+			close(T.ready)
+
+		})
+
+	case "transcript.partial":
+
+		T.absorbPartial(Env)
+
+	case "transcript.done":
+
+		T.absorbDone(Env.Text)
+		T.signalDone()
+
+	case "error":
+
+		Utils.Logger.Error("Receive", fmt.Sprintf("Local STT error: %s", Env.Message))
+		T.signalDone()
+
+	}
+
+}
+
+// Send forwards PCM in ~100ms chunks. Unlike xAI, silence is kept so the engine can find utterance boundaries.
+func (T *localTranscriber) Send(PCM []byte) error {
+
+	if T == nil {
+
+		return errTranscriberClosed
+
+	}
+
+	if len(PCM) == 0 {
+
+		return nil
+
+	}
+
+	if T.Done() {
+
+		return errors.New("transcriber finished")
+
+	}
+
+	T.writeMu.Lock()
+	defer T.writeMu.Unlock()
+
+	T.pcmBuf.Append(PCM)
+
+	for _, Chunk := range T.pcmBuf.DrainChunks(sttPCMChunkBytes) {
+
+		T.startHardTimeout()
+
+		if Err := writeLocalSTTFrame(sttLocalOpPCM, T.id, Chunk); Err != nil {
+
+			return Err
+
+		}
+
+	}
+
+	return nil
+
+}
+
+func (T *localTranscriber) startHardTimeout() {
+
+	T.timeoutOnce.Do(func() {
+
+		go func() {
+
+			select {
+
+			case <-time.After(transcribeHardTimeout):
+
+				Utils.Logger.Warn("Receive", "Local transcriber: hard timeout reached, finalizing")
+				T.Finalize()
+
+			case <-T.done:
+
+			}
+
+		}()
+
+	})
+
+}
+
+func (T *localTranscriber) Done() bool {
+
+	if T == nil {
+
+		return true
+
+	}
+
+	select {
+
+	case <-T.done:
+
+		return true
+
+	default:
+
+		return false
+
+	}
+
+}
+
+// Finalize flushes buffered audio, sends finish, and waits for transcript.done.
+func (T *localTranscriber) Finalize() {
+
+	T.startOnce.Do(func() {
+
+		T.writeMu.Lock()
+
+		if Tail := T.pcmBuf.Remainder(); len(Tail) > 0 {
+
+			_ = writeLocalSTTFrame(sttLocalOpPCM, T.id, Tail)
+
+		}
+
+		_ = writeLocalSTTFrame(sttLocalOpFinish, T.id, nil)
+
+		T.writeMu.Unlock()
+
+	})
+
+	select {
+
+	case <-T.done:
+
+	case <-time.After(transcribeDoneWait):
+
+		Utils.Logger.Warn("Receive", "Local transcriber: transcript.done not received in time")
+
+	}
+
+	T.Close()
+
+}
+
+// Close releases the stream in the sidecar. Idempotent.
+func (T *localTranscriber) Close() {
+
+	T.closeOnce.Do(func() {
+
+		_ = writeLocalSTTFrame(sttLocalOpClose, T.id, nil)
+
+		sttLocalStreams.Delete(T.id)
+
+		T.signalDone()
+
+	})
+
+}
+
+func (T *localTranscriber) signalDone() {
+
+	T.doneOnce.Do(func() {
+
+		close(T.done)
+
+	})
+
+}
@@ -0,0 +1,468 @@
+package Receive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"Synthara-Redux/Utils"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+
+	envSTTEndpointing = "VOICE_STT_ENDPOINTING_MS"
+
+	defaultEndpointing = 500 // silence gap in ms to finalize an utterance, per xAI recommendation
+
+)
+
+// xaiTranscriber owns a single xAI STT WebSocket session.
+type xaiTranscriber struct {
+
+	transcriptState
+
+	conn *websocket.Conn
+	cancel context.CancelFunc
+
+	ready chan struct{}
+	done chan struct{}
+
+	writeMu sync.Mutex
+	pcmBuf  *PCMBuffer
+
+	startOnce sync.Once
+	timeoutOnce sync.Once
+	closeOnce sync.Once
+	doneOnce sync.Once
+
+}
+
+// newXAITranscriber dials xAI for Language and waits for transcript.created.
+func newXAITranscriber(Parent context.Context, Language string) (*xaiTranscriber, error) {
+
+	APIKey := os.Getenv("XAI_API_KEY")
+
+	if APIKey == "" {
+
+		return nil, errors.New("XAI_API_KEY not set")
+
+	}
+
+	DialCtx, DialCancel := context.WithTimeout(Parent, 5*time.Second)
+	defer DialCancel()
+
+	Headers := http.Header{}
+	Headers.Set("Authorization", "Bearer "+APIKey)
+
+	Conn, _, ErrDial := websocket.DefaultDialer.DialContext(DialCtx, xaiSTTWebSocketURL(Language), Headers)
+
+	if ErrDial != nil {
+
+		return nil, fmt.Errorf("xai stt dial: %w", ErrDial)
+
+	}
+
+	Ctx, Cancel := context.WithCancel(Parent)
+
+	T := &xaiTranscriber{
+
+		conn: Conn,
+
+		cancel: Cancel,
+		ready: make(chan struct{}),
+		done: make(chan struct{}),
+
+		pcmBuf: NewPCMBuffer(sttPCMChunkBytes * 4),
+
+	}
+
+	go T.readLoop(Ctx)
+
+	select {
+
+	case <-T.ready:
+
+	case <-time.After(transcribeReadyTimeout):
+
+		T.Close()
+		return nil, errors.New("xai stt never sent transcript.created")
+
+	case <-Ctx.Done():
+
+		T.Close()
+		return nil, Ctx.Err()
+
+	}
+
+	return T, nil
+
+}
+
+// Send appends PCM and flushes in ~100ms chunks (xAI recommendation). Silent chunks are dropped.
+func (T *xaiTranscriber) Send(PCM []byte) error {
+
+	if T == nil {
+
+		return errTranscriberClosed
+
+	}
+
+	if len(PCM) == 0 {
+
+		return nil
+
+	}
+
+	select {
+
+		case <-T.done:
+
+			return errors.New("transcriber finished")
+
+		default:
+
+	}
+
+	T.writeMu.Lock()
+	defer T.writeMu.Unlock()
+
+	if T.conn == nil {
+
+		return errTranscriberClosed
+
+	}
+
+	T.pcmBuf.Append(PCM)
+
+	for _, Chunk := range T.pcmBuf.DrainChunks(sttPCMChunkBytes) {
+
+		if isPCMSilent(Chunk) {
+
+			continue
+
+		}
+
+		if Err := T.writePCM(Chunk); Err != nil {
+
+			return Err
+
+		}
+
+	}
+
+	return nil
+
+}
+
+func (T *xaiTranscriber) writePCM(PCM []byte) error {
+
+	T.startHardTimeout()
+
+	T.conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
+
+	if Err := T.conn.WriteMessage(websocket.BinaryMessage, PCM); Err != nil {
+
+		return Err
+
+	}
+
+	return nil
+
+}
+
+func (T *xaiTranscriber) startHardTimeout() {
+
+	T.timeoutOnce.Do(func() {
+
+		go func() {
+
+			select {
+
+			case <-time.After(transcribeHardTimeout):
+
+				Utils.Logger.Warn("Receive", "Transcriber: hard timeout reached, finalizing")
+				T.Finalize()
+
+			case <-T.done:
+
+			}
+
+		}()
+
+	})
+
+}
+
+func (T *xaiTranscriber) Done() bool {
+
+	if T == nil {
+
+		return true
+
+	}
+
+	select {
+
+	case <-T.done:
+
+		return true
+
+	default:
+
+		return false
+
+	}
+
+}
+
+// Finalize flushes audio, sends audio.done, and waits for transcript.done.
+func (T *xaiTranscriber) Finalize() {
+
+	T.startOnce.Do(func() {
+
+		T.writeMu.Lock()
+
+		if T.conn != nil {
+
+			for _, Chunk := range T.pcmBuf.DrainChunks(sttPCMChunkBytes) {
+
+				if !isPCMSilent(Chunk) {
+
+					_ = T.writePCM(Chunk)
+
+				}
+
+			}
+
+			if Tail := T.pcmBuf.Remainder(); len(Tail) > 0 && !isPCMSilent(Tail) {
+
+				_ = T.writePCM(Tail)
+
+			}
+
+		}
+
+		if T.conn != nil {
+
+			T.conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
+			_ = T.conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"audio.done"}`))
+
+		}
+
+		T.writeMu.Unlock()
+
+	})
+
+	select {
+
+	case <-T.done:
+
+	case <-time.After(transcribeDoneWait):
+
+		Utils.Logger.Warn("Receive", "Transcriber: transcript.done not received in time")
+
+	}
+
+	T.Close()
+
+}
+
+// Close shuts the WebSocket. Idempotent.
+func (T *xaiTranscriber) Close() {
+
+	T.closeOnce.Do(func() {
+
+		if T.cancel != nil {
+
+			T.cancel()
+
+		}
+
+		T.writeMu.Lock()
+
+		if T.conn != nil {
+
+			_ = T.conn.Close()
+
+			T.conn = nil
+
+		}
+
+		T.writeMu.Unlock()
+
+		T.signalDone()
+
+	})
+
+}
+
+func (T *xaiTranscriber) signalDone() {
+
+	T.doneOnce.Do(func() {
+
+		close(T.done)
+
+	})
+
+}
+
+func (T *xaiTranscriber) readLoop(Ctx context.Context) {
+
+	defer T.signalDone()
+
+	defer func() {
+
+		if r := recover(); r != nil {
+
+			Utils.Logger.Error("Receive", fmt.Sprintf("Transcriber readLoop panic: %v", r))
+
+		}
+
+	}()
+
+	Conn := T.conn
+
+	if Conn == nil {
+
+		return
+
+	}
+
+	for {
+
+		if Ctx.Err() != nil {
+
+			return
+
+		}
+
+		Conn.SetReadDeadline(time.Now().Add(transcribeHardTimeout + 5*time.Second))
+
+		MessageType, Data, ErrRead := Conn.ReadMessage()
+
+		if ErrRead != nil {
+
+			if Ctx.Err() == nil {
+
+				Utils.Logger.Warn("Receive", fmt.Sprintf("Transcriber read ended: %v", ErrRead))
+
+			}
+
+			return
+
+		}
+
+		if MessageType != websocket.TextMessage {
+
+			continue
+
+		}
+
+		var Env sttEnvelope
+
+		if ErrUnmarshal := json.Unmarshal(Data, &Env); ErrUnmarshal != nil {
+
+			if os.Getenv(sttDebugEnv) != "" {
+
+				Utils.Logger.Warn("Receive", fmt.Sprintf("STT JSON unmarshal: %v raw=%q", ErrUnmarshal, string(Data)))
+
+			}
+
+			continue
+
+		}
+
+		if os.Getenv(sttDebugEnv) != "" {
+
+			Utils.Logger.Info("Receive", fmt.Sprintf("STT event: %s", string(Data)))
+
+		}
+
+		switch Env.Type {
+
+		case "transcript.created":
+
+			select {
+
+			case <-T.ready:
+
+			default:
+
+				close(T.ready)
+
+			}
+
+		case "transcript.partial":
+
+			T.absorbPartial(Env)
+
+		case "transcript.done":
+
+			// Authoritative full transcript after audio.done flush
+
+			if Env.Text == "" && os.Getenv(sttDebugEnv) != "" {
+
+				Utils.Logger.Info("Receive", "STT transcript.done with empty text (WebSocket)")
+
+			}
+
+			T.absorbDone(Env.Text)
+			return
+
+		case "error":
+
+			Utils.Logger.Error("Receive", fmt.Sprintf("xAI STT error: %s", Env.Message))
+			return
+
+		}
+
+	}
+
+}
+
+func xaiSTTWebSocketURL(Language string) string {
+
+	Lang := url.QueryEscape(Language)
+	Endpointing := strconv.Itoa(sttEndpointingMS())
+
+	return "wss://api.x.ai/v1/stt" +
+		"?sample_rate=16000" +
+		"&encoding=pcm" +
+		"&language=" + Lang +
+		"&interim_results=true" +
+		"&endpointing=" + Endpointing +
+		"&filler_words=false"
+
+}
+
+func sttEndpointingMS() int {
+
+	V := os.Getenv(envSTTEndpointing)
+
+	if V == "" {
+
+		return defaultEndpointing
+
+	}
+
+	Parsed, Err := strconv.Atoi(V)
+
+	if Err != nil || Parsed < 0 || Parsed > 5000 {
+
+		Utils.Logger.Warn("Receive", fmt.Sprintf("Invalid %s=%q, using %d", envSTTEndpointing, V, defaultEndpointing))
+		return defaultEndpointing
+
+	}
+
+	return Parsed
+
+}
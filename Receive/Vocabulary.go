@@ -0,0 +1,585 @@
+package Receive
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"Synthara-Redux/Globals/Localizations"
+)
+
+// Voice vocabularies live in the Localizations manifest under Voice.*, one comma separated synonym list per locale.
+// Every locale also accepts the English words, since plenty of people give commands in English regardless of server language.
+
+const voiceFuzzyMinRunes = 5 // shorter words are too easy to confuse to match loosely
+
+// voiceCommandKeys maps Voice.Commands.<Key> onto command verbs; Args are implied by the phrase itself ("rewind" seeks back).
+var voiceCommandKeys = []struct {
+
+	Key string
+	Command string
+	Args string
+
+}{
+
+	{"Play", CommandPlay, ""},
+	{"Pause", CommandPause, ""},
+	{"Resume", CommandResume, ""},
+	{"Next", CommandNext, ""},
+	{"Last", CommandLast, ""},
+	{"Leave", CommandLeave, ""},
+	{"Shuffle", CommandShuffle, ""},
+	{"Repeat", CommandRepeat, ""},
+	{"Replay", CommandReplay, ""},
+	{"Autoplay", CommandAutoplay, ""},
+	{"Volume", CommandVolume, ""},
+	{"Speed", CommandSpeed, ""},
+	{"Reverb", CommandReverb, ""},
+	{"NowPlaying", CommandNowPlaying, ""},
+	{"Requester", CommandRequester, ""},
+	{"Remove", CommandRemove, ""},
+	{"Clear", CommandClear, ""},
+	{"Save", CommandSave, ""},
+	{"Like", CommandLike, ""},
+	{"Seek", CommandSeek, ""},
+	{"SeekForward", CommandSeek, "forward"},
+	{"SeekBack", CommandSeek, "back"},
+	{"Lyrics", CommandLyrics, ""},
+
+}
+
+// voiceShortcutKeys maps Voice.Shortcuts.<Key> onto a command with implied arguments ("turn it up"); they only apply when no command word was said.
+var voiceShortcutKeys = []struct {
+
+	Key string
+	Command string
+	Args string
+
+}{
+
+	{"VolumeUp", CommandVolume, "up"},
+	{"VolumeDown", CommandVolume, "down"},
+
+}
+
+// voiceArgumentKeys maps Voice.Arguments.<Key> onto the English keywords the voice handlers understand.
+var voiceArgumentKeys = []struct {
+
+	Key string
+	Keyword string
+
+}{
+
+	{"On", "on"},
+	{"Off", "off"},
+	{"Up", "up"},
+	{"Down", "down"},
+	{"Smart", "smart"},
+	{"Random", "random"},
+	{"All", "all"},
+	{"One", "one"},
+	{"Normal", "normal"},
+	{"Max", "max"},
+	{"Forward", "forward"},
+	{"Back", "back"},
+	{"Start", "start"},
+	{"Minutes", "minutes"},
+	{"Seconds", "seconds"},
+
+}
+
+// voiceFuzzyTolerance is the edit distance allowed per language; inflected languages (Polish, Russian) get more room
+// for case endings, English needs none since its vocabulary already lists the inflections.
+var voiceFuzzyTolerance = map[string]int{
+
+	"es": 1,
+	"fr": 1,
+	"it": 1,
+	"de": 1,
+	"pl": 2,
+	"ru": 2,
+
+}
+
+// voiceFold strips the accents speech engines use inconsistently ("reproducción" / "reproduccion").
+var voiceFold = strings.NewReplacer(
+
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ą", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e", "ę", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ń", "n", "ç", "c", "ć", "c", "ł", "l", "ś", "s", "ß", "ss", "ź", "z", "ż", "z",
+	"ё", "е",
+
+)
+
+// voicePhrase is one synonym: a sequence of folded words, or a single run of text for scripts written without spaces.
+type voicePhrase struct {
+
+	Words []string
+	Value string
+	Args string // implied arguments
+
+	Unspaced bool
+
+}
+
+type voiceVocabulary struct {
+
+	Language string
+	Tolerance int
+
+	Commands []voicePhrase
+	Shortcuts []voicePhrase
+	Arguments []voicePhrase
+	WakeWords []voicePhrase
+	SaveAs []voicePhrase // connectors introducing a name: "save this queue as ..."
+	Answers []voicePhrase // yes/no replies to a spoken confirmation
+
+}
+
+var voiceVocabularies sync.Map // locale code -> *voiceVocabulary
+
+// vocabularyFor returns the parsed vocabulary for a Discord locale code, building it on first use.
+func vocabularyFor(Locale string) *voiceVocabulary {
+
+	if Locale == "" {
+
+		Locale = Localizations.Default
+
+	}
+
+	if Cached, Exists := voiceVocabularies.Load(Locale); Exists {
+
+		return Cached.(*voiceVocabulary)
+
+	}
+
+	Language := Localizations.Language(Locale)
+
+	V := &voiceVocabulary{
+
+		Language: Language,
+		Tolerance: voiceFuzzyTolerance[Language],
+
+	}
+
+	for _, Entry := range voiceCommandKeys {
+
+		Start := len(V.Commands)
+		V.Commands = appendVoicePhrases(V.Commands, "Voice.Commands."+Entry.Key, Locale, Entry.Command)
+
+		for i := Start; i < len(V.Commands); i++ {
+
+			V.Commands[i].Args = Entry.Args
+
+		}
+
+	}
+
+	for _, Entry := range voiceArgumentKeys {
+
+		V.Arguments = appendVoicePhrases(V.Arguments, "Voice.Arguments."+Entry.Key, Locale, Entry.Keyword)
+
+	}
+
+	for _, Entry := range voiceShortcutKeys {
+
+		Start := len(V.Shortcuts)
+		V.Shortcuts = appendVoicePhrases(V.Shortcuts, "Voice.Shortcuts."+Entry.Key, Locale, Entry.Command)
+
+		for i := Start; i < len(V.Shortcuts); i++ {
+
+			V.Shortcuts[i].Args = Entry.Args
+
+		}
+
+	}
+
+	V.WakeWords = appendVoicePhrases(nil, "Voice.WakeWords", Locale, "Synthara")
+	V.SaveAs = appendVoicePhrases(nil, "Voice.SaveAs", Locale, "")
+
+	V.Answers = appendVoicePhrases(nil, "Voice.Confirm.Yes", Locale, confirmAnswerYes)
+	V.Answers = appendVoicePhrases(V.Answers, "Voice.Confirm.No", Locale, confirmAnswerNo)
+
+	for _, Phrases := range [][]voicePhrase{V.Commands, V.Shortcuts, V.Arguments, V.WakeWords, V.SaveAs, V.Answers} {
+
+		// Longest first so "lecture automatique" wins over "automatique" and 随机播放 over 随机
+
+		sort.SliceStable(Phrases, func(I, J int) bool {
+
+			return phraseLength(Phrases[I]) > phraseLength(Phrases[J])
+
+		})
+
+	}
+
+	Actual, _ := voiceVocabularies.LoadOrStore(Locale, V)
+
+	return Actual.(*voiceVocabulary)
+
+}
+
+// appendVoicePhrases adds the synonyms stored at Path for Locale and for the default locale.
+func appendVoicePhrases(Phrases []voicePhrase, Path string, Locale string, Value string) []voicePhrase {
+
+	Seen := map[string]bool{}
+
+	for _, Code := range []string{Locale, Localizations.Default} {
+
+		List, Exists := Localizations.Lookup(Path, Code)
+
+		if !Exists {
+
+			continue
+
+		}
+
+		for _, Synonym := range strings.Split(List, ",") {
+
+			Words := strings.Fields(foldVoiceText(stripPunct(strings.ToLower(Synonym))))
+			Key := strings.Join(Words, " ")
+
+			if Key == "" || Seen[Key] {
+
+				continue
+
+			}
+
+			Seen[Key] = true
+
+			Phrases = append(Phrases, voicePhrase{Words: Words, Value: Value, Unspaced: isUnspacedScript(Key)})
+
+		}
+
+	}
+
+	return Phrases
+
+}
+
+func phraseLength(P voicePhrase) int {
+
+	return utf8.RuneCountInString(strings.Join(P.Words, " "))
+
+}
+
+func foldVoiceText(S string) string {
+
+	return voiceFold.Replace(S)
+
+}
+
+// isUnspacedScript reports whether text is Chinese or Japanese, which transcripts don't split into words.
+func isUnspacedScript(S string) bool {
+
+	for _, R := range S {
+
+		if unicode.Is(unicode.Han, R) || unicode.Is(unicode.Hiragana, R) || unicode.Is(unicode.Katakana, R) {
+
+			return true
+
+		}
+
+	}
+
+	return false
+
+}
+
+// matchAt returns the phrase spelled out by the folded tokens starting at Index.
+func matchAt(Phrases []voicePhrase, Folded []string, Index int) (voicePhrase, bool) {
+
+	for _, P := range Phrases {
+
+		if P.Unspaced || Index+len(P.Words) > len(Folded) {
+
+			continue
+
+		}
+
+		Matched := true
+
+		for Offset, Word := range P.Words {
+
+			if Folded[Index+Offset] != Word {
+
+				Matched = false
+				break
+
+			}
+
+		}
+
+		if Matched {
+
+			return P, true
+
+		}
+
+	}
+
+	return voicePhrase{}, false
+
+}
+
+// matchFuzzy returns the closest single-word phrase within the language's tolerance.
+func (V *voiceVocabulary) matchFuzzy(Phrases []voicePhrase, Token string) (voicePhrase, bool) {
+
+	if V.Tolerance == 0 || utf8.RuneCountInString(Token) < voiceFuzzyMinRunes {
+
+		return voicePhrase{}, false
+
+	}
+
+	Best, BestDistance := voicePhrase{}, V.Tolerance+1
+
+	for _, P := range Phrases {
+
+		if P.Unspaced || len(P.Words) != 1 || utf8.RuneCountInString(P.Words[0]) < voiceFuzzyMinRunes {
+
+			continue
+
+		}
+
+		if Distance := levenshtein(Token, P.Words[0]); Distance < BestDistance {
+
+			Best, BestDistance = P, Distance
+
+		}
+
+	}
+
+	return Best, BestDistance <= V.Tolerance
+
+}
+
+// matchUnspaced finds the earliest unspaced phrase inside Token, returning its byte offset.
+func matchUnspaced(Phrases []voicePhrase, Token string) (voicePhrase, int, bool) {
+
+	Best, BestOffset := voicePhrase{}, -1
+
+	for _, P := range Phrases {
+
+		if !P.Unspaced {
+
+			continue
+
+		}
+
+		if Offset := strings.Index(Token, P.Words[0]); Offset >= 0 && (BestOffset < 0 || Offset < BestOffset) {
+
+			Best, BestOffset = P, Offset
+
+		}
+
+	}
+
+	return Best, BestOffset, BestOffset >= 0
+
+}
+
+// isWakeWord reports whether a folded token is one of the locale's spellings of the wake word.
+func (V *voiceVocabulary) isWakeWord(Folded string) bool {
+
+	_, Matched := matchAt(V.WakeWords, []string{Folded}, 0)
+
+	return Matched
+
+}
+
+// stripUnspacedWakeWords blanks out wake words glued to the command in Chinese and Japanese transcripts.
+func (V *voiceVocabulary) stripUnspacedWakeWords(Text string) string {
+
+	for _, P := range V.WakeWords {
+
+		if P.Unspaced {
+
+			Text = strings.ReplaceAll(Text, P.Words[0], " ")
+
+		}
+
+	}
+
+	return Text
+
+}
+
+// findCommand locates the first command in the tokens: exact matches anywhere beat shortcuts, which beat fuzzy matches.
+// It returns the command, the index of its first token and the argument text that follows it.
+func (V *voiceVocabulary) findCommand(Tokens []string, Folded []string) (string, int, string, bool) {
+
+	for _, Phrases := range [][]voicePhrase{V.Commands, V.Shortcuts} {
+
+		for I := range Tokens {
+
+			if P, Matched := matchAt(Phrases, Folded, I); Matched {
+
+				return P.Value, I, joinSpace(P.Args, strings.Join(Tokens[I+len(P.Words):], " ")), true
+
+			}
+
+			if P, Offset, Matched := matchUnspaced(Phrases, Tokens[I]); Matched {
+
+				Head, Tail := "", Tokens[I][Offset+len(P.Words[0]):]
+
+				// Keywords glued on before the command count like spaced ones said before it ("单曲循环"); a song name keeps its text
+
+				if !CommandNeedsMultiWordArgs(P.Value) {
+
+					Head = V.unspacedKeywords(Tokens[I][:Offset])
+
+				}
+
+				return P.Value, I, joinSpace(P.Args, Head, Tail, strings.Join(Tokens[I+1:], " ")), true
+
+			}
+
+		}
+
+	}
+
+	for I := range Tokens {
+
+		if P, Matched := V.matchFuzzy(V.Commands, Folded[I]); Matched {
+
+			return P.Value, I, strings.Join(Tokens[I+1:], " "), true
+
+		}
+
+	}
+
+	return "", 0, "", false
+
+}
+
+// unspacedKeywords returns the English keywords of the unspaced argument phrases inside Text, or "".
+func (V *voiceVocabulary) unspacedKeywords(Text string) string {
+
+	Keywords := []string{}
+
+	for _, P := range V.Arguments {
+
+		if P.Unspaced && strings.Contains(Text, P.Words[0]) {
+
+			Keywords = append(Keywords, P.Value)
+
+		}
+
+	}
+
+	return strings.Join(Keywords, " ")
+
+}
+
+// argumentKeyword returns the English keyword for a single folded token, or "".
+func (V *voiceVocabulary) argumentKeyword(Folded string) string {
+
+	if P, Matched := matchAt(V.Arguments, []string{Folded}, 0); Matched {
+
+		return P.Value
+
+	}
+
+	if P, Matched := V.matchFuzzy(V.Arguments, Folded); Matched {
+
+		return P.Value
+
+	}
+
+	return ""
+
+}
+
+// canonicalArgs rewrites localized argument words ("sube", "aus", 调高) into the English keywords the handlers parse; other words pass through.
+func (V *voiceVocabulary) canonicalArgs(Args string) string {
+
+	for _, P := range V.Arguments {
+
+		if P.Unspaced {
+
+			Args = strings.ReplaceAll(Args, P.Words[0], " "+P.Value+" ")
+
+		}
+
+	}
+
+	Tokens := strings.Fields(Args)
+	Folded := make([]string, len(Tokens))
+
+	for I, Token := range Tokens {
+
+		Folded[I] = foldVoiceText(Token)
+
+	}
+
+	Out := make([]string, 0, len(Tokens))
+
+	for I := 0; I < len(Tokens); I++ {
+
+		if P, Matched := matchAt(V.Arguments, Folded, I); Matched {
+
+			Out = append(Out, P.Value)
+			I += len(P.Words) - 1
+
+			continue
+
+		}
+
+		if P, Matched := V.matchFuzzy(V.Arguments, Folded[I]); Matched {
+
+			Out = append(Out, P.Value)
+			continue
+
+		}
+
+		Out = append(Out, Tokens[I])
+
+	}
+
+	return strings.Join(Out, " ")
+
+}
+
+// extractName returns what follows the last "as"/"called" connector, or the whole text when none was said.
+func (V *voiceVocabulary) extractName(Args string) string {
+
+	Tokens := strings.Fields(Args)
+	Folded := make([]string, len(Tokens))
+
+	for I, Token := range Tokens {
+
+		Folded[I] = foldVoiceText(Token)
+
+	}
+
+	for I := len(Tokens) - 1; I >= 0; I-- {
+
+		if P, Matched := matchAt(V.SaveAs, Folded, I); Matched && I+len(P.Words) < len(Tokens) {
+
+			return strings.Join(Tokens[I+len(P.Words):], " ")
+
+		}
+
+	}
+
+	for _, P := range V.SaveAs {
+
+		if Index := strings.LastIndex(Args, P.Words[0]); P.Unspaced && Index >= 0 {
+
+			if Name := strings.TrimSpace(Args[Index+len(P.Words[0]):]); Name != "" {
+
+				return Name
+
+			}
+
+		}
+
+	}
+
+	return strings.TrimSpace(Args)
+
+}
@@ -0,0 +1,289 @@
+package Receive
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const (
+
+	DefaultWakePhrase = "Synthara"
+	DefaultWakeSensitivity = 0.85 // Porcupine sensitivity, 0 to 1; also scales how loosely transcripts may spell the phrase
+
+	maxWakePhraseWords = 3
+	maxWakePhraseRunes = 32
+
+)
+
+// WakeWord is a guild's wake phrase and detector sensitivity; the zero value means "Synthara" at the default sensitivity.
+type WakeWord struct {
+
+	Phrase string
+	Sensitivity float64
+
+}
+
+func (W WakeWord) isDefault() bool {
+
+	return W.Phrase == "" || strings.EqualFold(W.Phrase, DefaultWakePhrase)
+
+}
+
+// customized reports whether the detector needs configuring: a new stream listens for "Synthara" at the default sensitivity.
+func (W WakeWord) customized() bool {
+
+	return !W.isDefault() || W.sensitivity() != DefaultWakeSensitivity
+
+}
+
+func (W WakeWord) name() string {
+
+	if W.isDefault() {
+
+		return DefaultWakePhrase
+
+	}
+
+	return W.Phrase
+
+}
+
+func (W WakeWord) sensitivity() float64 {
+
+	if W.Sensitivity <= 0 || W.Sensitivity > 1 {
+
+		return DefaultWakeSensitivity
+
+	}
+
+	return W.Sensitivity
+
+}
+
+// NormalizeWakePhrase tidies a configured phrase, rejecting anything but one to three words of letters and digits.
+func NormalizeWakePhrase(Phrase string) (string, bool) {
+
+	Words := strings.Fields(Phrase)
+
+	if len(Words) == 0 || len(Words) > maxWakePhraseWords {
+
+		return "", false
+
+	}
+
+	Normalized := strings.Join(Words, " ")
+
+	if utf8.RuneCountInString(Normalized) > maxWakePhraseRunes {
+
+		return "", false
+
+	}
+
+	for _, R := range Normalized {
+
+		if R != ' ' && R != '-' && R != '\'' && !unicode.IsLetter(R) && !unicode.IsDigit(R) {
+
+			return "", false
+
+		}
+
+	}
+
+	return Normalized, true
+
+}
+
+// WakeKeywordPath is where the Porcupine keyword file for a phrase is expected: "Hey Jarvis" reads model/hey_jarvis.ppn.
+func WakeKeywordPath(Phrase string) string {
+
+	Name := strings.Join(strings.Fields(strings.ToLower(Phrase)), "_") + ".ppn"
+
+	return filepath.Join(picoDir(), "model", Name)
+
+}
+
+// WakeKeywordAvailable reports whether the wake detector can listen for a phrase; otherwise it keeps listening for "Synthara".
+func WakeKeywordAvailable(Phrase string) bool {
+
+	if (WakeWord{Phrase: Phrase}).isDefault() {
+
+		return true
+
+	}
+
+	_, ErrStat := os.Stat(WakeKeywordPath(Phrase))
+
+	return ErrStat == nil
+
+}
+
+// configureWake points a user's wake detector stream at the guild's keyword file and sensitivity.
+func configureWake(GuildID, UserID snowflake.ID) {
+
+	if !WakeDetectorReady() {
+
+		return
+
+	}
+
+	Wake := guildWakeWord(GuildID)
+	Keyword := ""
+
+	if !Wake.isDefault() && WakeKeywordAvailable(Wake.Phrase) {
+
+		if Abs, ErrAbs := filepath.Abs(WakeKeywordPath(Wake.Phrase)); ErrAbs == nil {
+
+			Keyword = Abs
+
+		}
+
+	}
+
+	if ErrConfigure := PicoConfigureStream(GuildID, UserID, Keyword, Wake.sensitivity()); ErrConfigure != nil {
+
+		Utils.Logger.Warn("Receive", fmt.Sprintf("Pico configure stream: %s", ErrConfigure.Error()))
+
+	}
+
+}
+
+// ReloadWakeWord applies a changed wake phrase or sensitivity to every open session in a guild.
+func ReloadWakeWord(GuildID snowflake.ID) {
+
+	R := receiverFor(GuildID)
+
+	if R == nil {
+
+		return
+
+	}
+
+	R.mu.Lock()
+
+	Users := make([]snowflake.ID, 0, len(R.sessions))
+
+	for UserID := range R.sessions {
+
+		Users = append(Users, UserID)
+
+	}
+
+	R.mu.Unlock()
+
+	for _, UserID := range Users {
+
+		configureWake(GuildID, UserID)
+
+	}
+
+}
+
+// wakePhraseWords splits a phrase the way transcripts are tokenized.
+func wakePhraseWords(Phrase string) []string {
+
+	return strings.Fields(foldVoiceText(stripPunct(strings.ToLower(Phrase))))
+
+}
+
+// wakeTokenClose allows a spelling slip per four letters at full sensitivity and none at low sensitivity.
+func wakeTokenClose(Token string, Word string, Sensitivity float64) bool {
+
+	if Token == Word {
+
+		return true
+
+	}
+
+	Tolerance := int(float64(utf8.RuneCountInString(Word)) * Sensitivity / 4)
+
+	return Tolerance > 0 && levenshtein(Token, Word) <= Tolerance
+
+}
+
+// wakePhraseEnd returns the index of the last token of the wake phrase in a transcript, or -1 when it was not said.
+func (V *voiceVocabulary) wakePhraseEnd(Wake WakeWord, Tokens []string, Folded []string) int {
+
+	if Wake.isDefault() {
+
+		for i := range Tokens {
+
+			if fuzzyMatchSynthara(Tokens[i]) || V.isWakeWord(Folded[i]) {
+
+				return i
+
+			}
+
+		}
+
+		return -1
+
+	}
+
+	Words := wakePhraseWords(Wake.Phrase)
+	Glued := strings.Join(Words, "")
+
+	for i := range Folded {
+
+		if i+len(Words) <= len(Folded) {
+
+			Matched := true
+
+			for Offset, Word := range Words {
+
+				if !wakeTokenClose(Folded[i+Offset], Word, Wake.sensitivity()) {
+
+					Matched = false
+					break
+
+				}
+
+			}
+
+			if Matched {
+
+				return i + len(Words) - 1
+
+			}
+
+		}
+
+		// "hey jarvis" often comes back as "heyjarvis"
+
+		if len(Words) > 1 && wakeTokenClose(Folded[i], Glued, Wake.sensitivity()) {
+
+			return i
+
+		}
+
+	}
+
+	return -1
+
+}
+
+// stripUnspacedWakePhrase removes a Chinese or Japanese wake phrase glued to the command.
+func (V *voiceVocabulary) stripUnspacedWakePhrase(Wake WakeWord, Text string) string {
+
+	if Wake.isDefault() {
+
+		return V.stripUnspacedWakeWords(Text)
+
+	}
+
+	if Phrase := strings.ToLower(Wake.Phrase); isUnspacedScript(Phrase) {
+
+		Text = strings.ReplaceAll(Text, Phrase, " ")
+
+	}
+
+	return Text
+
+}
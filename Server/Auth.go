@@ -193,6 +193,29 @@ func WebUserFromRequest(Request *http.Request) (string, bool) {
 
 }
 
+// WebUserIDFromRequest returns the authenticated Discord user ID for a request, if any.
+func WebUserIDFromRequest(Request *http.Request) (snowflake.ID, bool) {
+
+	Token := sessionTokenFromRequest(Request)
+
+	if Token == "" {
+
+		return 0, false
+
+	}
+
+	Session, OK := sessionFromToken(Token)
+
+	if !OK {
+
+		return 0, false
+
+	}
+
+	return Session.UserID, true
+
+}
+
 func setSessionCookie(Context *gin.Context, Token string) {
 
 	Secure := strings.HasPrefix(strings.ToLower(strings.TrimSpace(os.Getenv("DOMAIN"))), "https://")
@@ -0,0 +1,129 @@
+package Server
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"fmt"
+	"net/http"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gin-gonic/gin"
+)
+
+type FavoriteRequest struct {
+
+	ID      string `json:"ID"`      // guild whose queue holds the song, if any
+	TidalID int64  `json:"TidalID"`
+
+}
+
+// webUser resolves the signed in web user for personal endpoints, answering 401 when there is none
+func webUser(Context *gin.Context) (*Structs.User, bool) {
+
+	UserID, SignedIn := WebUserIDFromRequest(Context.Request)
+
+	if !SignedIn {
+
+		Context.JSON(http.StatusUnauthorized, gin.H{"Error": "Sign in required"})
+		return nil, false
+
+	}
+
+	User, ErrorFetching := Structs.GetUser(UserID.String())
+
+	if ErrorFetching != nil {
+
+		Context.JSON(http.StatusInternalServerError, gin.H{"Error": "Could not load your profile"})
+		return nil, false
+
+	}
+
+	return User, true
+
+}
+
+// HandleFavorites lists the Tidal IDs of the signed in user's liked songs
+func HandleFavorites(Context *gin.Context) {
+
+	User, OK := webUser(Context)
+
+	if !OK {
+
+		return
+
+	}
+
+	Liked := make([]int64, 0, len(User.Liked))
+
+	for _, Entry := range User.Liked {
+
+		Liked = append(Liked, Entry.Song.TidalID)
+
+	}
+
+	Context.JSON(http.StatusOK, gin.H{"Liked": Liked})
+
+}
+
+// HandleFavoriteToggle likes or unlikes a song for the signed in user
+func HandleFavoriteToggle(Context *gin.Context) {
+
+	var Request FavoriteRequest
+
+	if ErrorBinding := Context.ShouldBindJSON(&Request); ErrorBinding != nil || Request.TidalID == 0 {
+
+		Context.JSON(http.StatusBadRequest, gin.H{"Error": "TidalID is required"})
+		return
+
+	}
+
+	User, OK := webUser(Context)
+
+	if !OK {
+
+		return
+
+	}
+
+	var Song *Tidal.Song
+
+	if GuildID, ErrorParsing := snowflake.Parse(Request.ID); ErrorParsing == nil {
+
+		if Guild := Structs.GetGuild(GuildID, false); Guild != nil {
+
+			Song = Guild.Queue.FindSong(Request.TidalID)
+
+		}
+
+	}
+
+	if Song == nil {
+
+		Fetched, ErrorFetching := Tidal.GetSong(Request.TidalID)
+
+		if ErrorFetching != nil {
+
+			Context.JSON(http.StatusNotFound, gin.H{"Error": "Song not found"})
+			return
+
+		}
+
+		Song = &Fetched
+
+	}
+
+	Liked, ErrorToggling := User.ToggleLike(Song)
+
+	if ErrorToggling != nil {
+
+		Utils.Logger.Error("Favorites", fmt.Sprintf("Error updating favorites for user %s: %s", User.DiscordID, ErrorToggling.Error()))
+
+		Context.JSON(http.StatusInternalServerError, gin.H{"Error": "Could not update favorites"})
+		return
+
+	}
+
+	Context.JSON(http.StatusOK, gin.H{"TidalID": Song.TidalID, "Liked": Liked})
+
+}
@@ -10,6 +10,9 @@ var (
 	RateLimitAuthLogin *RateLimiter
 	RateLimitAuthCallback *RateLimiter
 	RateLimitAuthMe *RateLimiter
+	RateLimitStats *RateLimiter
+	RateLimitFavorites *RateLimiter
+	RateLimitPlaylists *RateLimiter
 
 )
 
@@ -21,5 +24,8 @@ func init() {
 	RateLimitAuthLogin = NewRateLimiter(20, time.Minute)
 	RateLimitAuthCallback = NewRateLimiter(20, time.Minute)
 	RateLimitAuthMe = NewRateLimiter(180, time.Minute)
+	RateLimitStats = NewRateLimiter(30, time.Minute)
+	RateLimitFavorites = NewRateLimiter(60, time.Minute)
+	RateLimitPlaylists = NewRateLimiter(60, time.Minute)
 
 }
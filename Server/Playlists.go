@@ -0,0 +1,271 @@
+package Server
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gin-gonic/gin"
+)
+
+type PlaylistRequest struct {
+
+	Action string `json:"Action"` // Create, Rename, Delete, Add, Remove, Move, Share, Unshare, Import, Load or Append
+
+	ID      string `json:"ID"` // guild used by Add, Load and Append
+	Name    string `json:"Name"`
+	NewName string `json:"NewName"`
+
+	TidalID int64  `json:"TidalID"`
+	Index   int    `json:"Index"` // zero-based
+	To      int    `json:"To"`
+	Code    string `json:"Code"`
+
+}
+
+// playlistStatus maps playlist errors to an HTTP status and message
+func playlistStatus(Error error) (int, string) {
+
+	switch {
+
+	case errors.Is(Error, Structs.ErrPlaylistNotFound), errors.Is(Error, Structs.ErrShareCodeNotFound):
+
+		return http.StatusNotFound, Error.Error()
+
+	case errors.Is(Error, Structs.ErrPlaylistExists):
+
+		return http.StatusConflict, Error.Error()
+
+	case errors.Is(Error, Structs.ErrSavedQueueNameEmpty), errors.Is(Error, Structs.ErrSavedQueueNameTooLong),
+		errors.Is(Error, Structs.ErrPlaylistLimit), errors.Is(Error, Structs.ErrPlaylistFull),
+		errors.Is(Error, Structs.ErrPlaylistEmpty), errors.Is(Error, Structs.ErrPlaylistPosition):
+
+		return http.StatusBadRequest, Error.Error()
+
+	default:
+
+		Utils.Logger.Error("Playlists", fmt.Sprintf("Error updating playlist: %s", Error.Error()))
+		return http.StatusInternalServerError, "Could not update playlist"
+
+	}
+
+}
+
+// playlistGuild resolves the guild a playlist is played into; the owner must be in its voice channel
+func playlistGuild(Context *gin.Context, GuildID string, UserID snowflake.ID) (*Structs.Guild, bool) {
+
+	ParsedID, ErrorParsing := snowflake.Parse(GuildID)
+
+	if ErrorParsing != nil {
+
+		Context.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid ID"})
+		return nil, false
+
+	}
+
+	Guild := Structs.GetGuild(ParsedID, false)
+
+	if Guild == nil {
+
+		Context.JSON(http.StatusNotFound, gin.H{"Error": "Guild not found"})
+		return nil, false
+
+	}
+
+	if WebControlsLocked(Guild.Features.Locked, Context.Request) {
+
+		Context.JSON(webControlsLockStatus(Guild.Features.Locked, Context.Request), gin.H{"Error": WebControlsLockMessage(Guild.Features.Locked, Context.Request)})
+		return nil, false
+
+	}
+
+	if VoiceState, InVoice := Utils.GetVoiceState(ParsedID, UserID); !InVoice || *VoiceState.ChannelID != Guild.Channels.Voice {
+
+		Context.JSON(http.StatusForbidden, gin.H{"Error": "Join the bot's voice channel to play your playlists"})
+		return nil, false
+
+	}
+
+	return Guild, true
+
+}
+
+// HandlePlaylists lists the signed in user's playlists
+func HandlePlaylists(Context *gin.Context) {
+
+	User, OK := webUser(Context)
+
+	if !OK {
+
+		return
+
+	}
+
+	Playlists, Error := Structs.ListPlaylists(User.DiscordID)
+
+	if Error != nil {
+
+		Status, Message := playlistStatus(Error)
+		Context.JSON(Status, gin.H{"Error": Message})
+		return
+
+	}
+
+	Context.JSON(http.StatusOK, gin.H{"Playlists": Playlists})
+
+}
+
+// HandlePlaylistAction applies one playlist action for the signed in user and returns the affected playlist
+func HandlePlaylistAction(Context *gin.Context) {
+
+	var Request PlaylistRequest
+
+	if ErrorBinding := Context.ShouldBindJSON(&Request); ErrorBinding != nil || Request.Action == "" {
+
+		Context.JSON(http.StatusBadRequest, gin.H{"Error": "Action is required"})
+		return
+
+	}
+
+	User, OK := webUser(Context)
+
+	if !OK {
+
+		return
+
+	}
+
+	UserID, _ := WebUserIDFromRequest(Context.Request)
+
+	var Playlist *Structs.UserPlaylist
+	var Error error
+
+	switch Request.Action {
+
+	case "Create":
+
+		Playlist, Error = Structs.CreatePlaylist(User.DiscordID, Request.Name, nil)
+
+	case "Rename":
+
+		Playlist, Error = Structs.RenamePlaylist(User.DiscordID, Request.Name, Request.NewName)
+
+	case "Delete":
+
+		Error = Structs.DeletePlaylist(User.DiscordID, Request.Name)
+
+	case "Import":
+
+		Playlist, Error = Structs.ImportPlaylist(User.DiscordID, Request.Code, Request.Name)
+
+	default:
+
+		Playlist, Error = Structs.GetPlaylist(User.DiscordID, Request.Name)
+
+		if Error != nil {
+
+			break
+
+		}
+
+		switch Request.Action {
+
+		case "Add":
+
+			var Song *Tidal.Song
+
+			if GuildID, ErrorParsing := snowflake.Parse(Request.ID); ErrorParsing == nil {
+
+				if Guild := Structs.GetGuild(GuildID, false); Guild != nil {
+
+					Song = Guild.Queue.FindSong(Request.TidalID)
+
+				}
+
+			}
+
+			if Song == nil {
+
+				Fetched, ErrorFetching := Tidal.GetSong(Request.TidalID)
+
+				if ErrorFetching != nil {
+
+					Context.JSON(http.StatusNotFound, gin.H{"Error": "Song not found"})
+					return
+
+				}
+
+				Song = &Fetched
+
+			}
+
+			_, Error = Playlist.AddSongs([]Tidal.Song{*Song})
+
+		case "Remove":
+
+			_, Error = Playlist.RemoveSong(Request.Index)
+
+		case "Move":
+
+			Error = Playlist.MoveSong(Request.Index, Request.To)
+
+		case "Share":
+
+			_, Error = Playlist.Share()
+
+		case "Unshare":
+
+			Error = Playlist.Unshare()
+
+		case "Load", "Append":
+
+			Guild, InGuild := playlistGuild(Context, Request.ID, UserID)
+
+			if !InGuild {
+
+				return
+
+			}
+
+			Requestor := fmt.Sprintf("<@%s>", UserID)
+
+			if Request.Action == "Append" {
+
+				_, _, Error = Guild.AppendPlaylist(Playlist, Requestor)
+				break
+
+			}
+
+			if !Guild.Allowed(UserID, Structs.CapabilitySaveLoad) {
+
+				Context.JSON(http.StatusForbidden, gin.H{"Error": "You are not allowed to load queues in this server"})
+				return
+
+			}
+
+			Error = Guild.LoadPlaylist(Playlist, Requestor)
+
+		default:
+
+			Context.JSON(http.StatusBadRequest, gin.H{"Error": "Unknown action"})
+			return
+
+		}
+
+	}
+
+	if Error != nil {
+
+		Status, Message := playlistStatus(Error)
+		Context.JSON(Status, gin.H{"Error": Message})
+		return
+
+	}
+
+	Context.JSON(http.StatusOK, gin.H{"Playlist": Playlist})
+
+}
@@ -14,6 +14,14 @@ func InitializeRoutes() {
 
 	Globals.WebServer.GET("/API/Suggestions", RateLimitMiddleware(RateLimitSuggestions), HandleSuggestions)
 
+	Globals.WebServer.GET("/API/Stats", RateLimitMiddleware(RateLimitStats), HandleStats)
+
+	Globals.WebServer.GET("/API/Favorites", RateLimitMiddleware(RateLimitFavorites), HandleFavorites)
+	Globals.WebServer.POST("/API/Favorites", RateLimitMiddleware(RateLimitFavorites), HandleFavoriteToggle)
+
+	Globals.WebServer.GET("/API/Playlists", RateLimitMiddleware(RateLimitPlaylists), HandlePlaylists)
+	Globals.WebServer.POST("/API/Playlists", RateLimitMiddleware(RateLimitPlaylists), HandlePlaylistAction)
+
 	Globals.WebServer.GET("/API/Auth/Login", RateLimitMiddleware(RateLimitAuthLogin), HandleAuthLogin)
 	Globals.WebServer.GET("/API/Auth/Callback", RateLimitMiddleware(RateLimitAuthCallback), HandleAuthCallback)
 	Globals.WebServer.GET("/API/Auth/Me", RateLimitMiddleware(RateLimitAuthMe), HandleAuthMe)
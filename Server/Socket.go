@@ -30,6 +30,7 @@ const (
 	OperationMove    = "Move"
 	OperationReplay  = "Replay"
 	OperationEnqueue = "Enqueue"
+	OperationShuffle = "Shuffle"
 )
 
 var Upgrader = websocket.Upgrader{
@@ -96,6 +97,8 @@ func HandleWSConnections(Context *gin.Context) {
 
 	}
 
+	SkipVotes, SkipVotesNeeded := Guild.SkipVotes()
+
 	InitialState := map[string]interface{}{
 
 		"Event": Structs.Event_Initial,
@@ -113,6 +116,11 @@ func HandleWSConnections(Context *gin.Context) {
 			"Authenticated":  WebAuthenticated(Context.Request),
 			"ControlsLocked": WebControlsLocked(Guild.Features.Locked, Context.Request),
 			"GuildLocked":    Guild.Features.Locked,
+
+			"SkipVotes":       SkipVotes,
+			"SkipVotesNeeded": SkipVotesNeeded,
+
+			"FairShare": Guild.Settings.Queue.FairShare,
 		},
 	}
 
@@ -153,6 +161,7 @@ func HandleWSMessage(Guild *Structs.Guild, Request *http.Request, Message map[st
 	}
 
 	Identifier := WebIdentifier{Name: WebUserForControls(Request)}
+	UserID, _ := WebUserIDFromRequest(Request)
 
 	if WebControlsLocked(Guild.Features.Locked, Request) {
 
@@ -183,6 +192,39 @@ func HandleWSMessage(Guild *Structs.Guild, Request *http.Request, Message map[st
 
 	case OperationNext:
 
+		Vote := Guild.RequestSkip(UserID, Identifier.Name)
+
+		if Vote.Denied {
+
+			SendWebCapabilityError(Guild, Structs.CapabilitySkip)
+			return
+
+		}
+
+		if Vote.NotListening {
+
+			Guild.Queue.SendToWebsockets("ERROR", map[string]interface{}{
+
+				"Message": "Join the voice channel to vote to skip.",
+
+			})
+
+			return
+
+		}
+
+		if !Vote.Skip {
+
+			if !Vote.AlreadyVoted {
+
+				SendWebOperationMessageWithVotes(Guild, Locale, Identifier, Vote.Votes, Vote.Needed)
+
+			}
+
+			return
+
+		}
+
 		Advanced, Ended := Guild.Queue.Next(true)
 
 		if Ended {
@@ -215,6 +257,27 @@ func HandleWSMessage(Guild *Structs.Guild, Request *http.Request, Message map[st
 
 		}
 
+		Jump := Guild.RequestJump(UserID)
+
+		if Jump.Denied {
+
+			SendWebCapabilityError(Guild, Structs.CapabilitySkip)
+			return
+
+		}
+
+		if Jump.VoteRequired {
+
+			Guild.Queue.SendToWebsockets("ERROR", map[string]interface{}{
+
+				"Message": "Only DJs can jump while vote-skip is on.",
+
+			})
+
+			return
+
+		}
+
 		Guild.Queue.Jump(int(Index))
 
 		if Guild.Queue.Current != nil {
@@ -233,6 +296,19 @@ func HandleWSMessage(Guild *Structs.Guild, Request *http.Request, Message map[st
 
 		}
 
+		if Index < 0 || int(Index) >= len(Guild.Queue.Upcoming) {
+
+			return
+
+		}
+
+		if !Guild.IsRequestor(Guild.Queue.Upcoming[int(Index)], UserID, Identifier.Name) && !Guild.Allowed(UserID, Structs.CapabilityRemove) {
+
+			SendWebCapabilityError(Guild, Structs.CapabilityRemove)
+			return
+
+		}
+
 		Guild.Queue.Remove(int(Index))
 
 		SendWebOperationMessage(Guild, "Web.Operations.Remove.Title", "Web.Operations.Remove.Description", Locale, Identifier)
@@ -248,6 +324,13 @@ func HandleWSMessage(Guild *Structs.Guild, Request *http.Request, Message map[st
 
 		}
 
+		if !Guild.Allowed(UserID, Structs.CapabilityMove) {
+
+			SendWebCapabilityError(Guild, Structs.CapabilityMove)
+			return
+
+		}
+
 		Guild.Queue.Move(int(FromIndex), int(ToIndex))
 
 		SendWebOperationMessage(Guild, "Web.Operations.Move.Title", "Web.Operations.Move.Description", Locale, Identifier)
@@ -270,6 +353,22 @@ func HandleWSMessage(Guild *Structs.Guild, Request *http.Request, Message map[st
 
 		}
 
+	case OperationShuffle:
+
+		Mode, _ := Message["Mode"].(string)
+
+		if !Guild.Allowed(UserID, Structs.CapabilityMove) {
+
+			SendWebCapabilityError(Guild, Structs.CapabilityMove)
+			return
+
+		}
+
+		Guild.Queue.ShuffleUpcomingWith(Structs.ParseShuffleMode(Mode))
+		Guild.Queue.Functions.Updated(&Guild.Queue)
+
+		SendWebOperationMessage(Guild, "Web.Operations.Shuffle.Title", "Web.Operations.Shuffle.Description", Locale, Identifier)
+
 	case OperationEnqueue:
 
 		TidalID, Ok := Message["TidalID"].(float64)
@@ -280,8 +379,35 @@ func HandleWSMessage(Guild *Structs.Guild, Request *http.Request, Message map[st
 
 		}
 
+		Position := Structs.PositionEnd
+
+		if Value, HasPosition := Message["Position"].(string); HasPosition {
+
+			Position = Structs.ParsePosition(Value)
+
+		}
+
+		if Capability := Position.Capability(); Capability != "" && !Guild.Allowed(UserID, Capability) {
+
+			SendWebCapabilityError(Guild, Capability)
+			return
+
+		}
+
 		URI := fmt.Sprintf("Synthara-Redux:Song:%d", int64(TidalID))
-		SongFound, _, ErrorHandling := Guild.HandleURI(URI, Identifier.Name)
+		SongFound, _, ErrorHandling := Guild.HandleURIAt(URI, Identifier.Name, Position)
+
+		if QuotaErr, IsQuota := ErrorHandling.(*Structs.QuotaError); IsQuota {
+
+			Guild.Queue.SendToWebsockets("ERROR", map[string]interface{}{
+
+				"Message": QuotaErr.Localized(Locale),
+
+			})
+
+			return
+
+		}
 
 		if ErrorHandling != nil {
 
@@ -305,6 +431,19 @@ func HandleWSMessage(Guild *Structs.Guild, Request *http.Request, Message map[st
 
 }
 
+// SendWebCapabilityError tells web clients that an operation was denied by the guild's permission policy
+func SendWebCapabilityError(Guild *Structs.Guild, Capability string) {
+
+	Locale := Guild.Locale.Code()
+
+	Guild.Queue.SendToWebsockets("ERROR", map[string]interface{}{
+
+		"Message": Localizations.GetFormat("Embeds.Errors.CapabilityDenied.Description", Locale, Localizations.Get("Common.Capabilities."+Capability, Locale)),
+
+	})
+
+}
+
 // SendWebOperationMessage sends a notification to Discord for web operations
 func SendWebOperationMessage(Guild *Structs.Guild, TitleKey string, DescKey string, Locale string, Identifier WebIdentifier) {
 
@@ -331,6 +470,32 @@ func SendWebOperationMessage(Guild *Structs.Guild, TitleKey string, DescKey stri
 
 }
 
+// SendWebOperationMessageWithVotes announces a skip vote cast from the web UI
+func SendWebOperationMessageWithVotes(Guild *Structs.Guild, Locale string, Identifier WebIdentifier, Votes int, Needed int) {
+
+	if Guild.Channels.Text == 0 {
+
+		return // No text channel set
+
+	}
+
+	go func() {
+
+		Description := Localizations.GetFormat("Web.Operations.VoteSkip.Description", Locale, Identifier.Name, Votes, Needed)
+
+		_, _ = Globals.DiscordClient.Rest.CreateMessage(Guild.Channels.Text, discord.NewMessageCreate().
+			AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+
+				Title:       Localizations.Get("Commands.Next.Vote.Registered.Title", Locale),
+				Author:      Localizations.Get("Embeds.Categories.Notifications", Locale),
+				Description: Description,
+
+			})))
+
+	}()
+
+}
+
 // SendWebOperationMessageWithSong sends a notification with song name
 func SendWebOperationMessageWithSong(Guild *Structs.Guild, TitleKey string, DescKey string, Locale string, Identifier WebIdentifier, SongTitle string) {
 
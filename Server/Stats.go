@@ -0,0 +1,148 @@
+package Server
+
+import (
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gin-gonic/gin"
+)
+
+type StatsResponse struct {
+
+	Scope  string `json:"scope"`            // "server" or "me"
+	Period string `json:"period,omitempty"` // set unless Year is
+	Year   int    `json:"year,omitempty"`   // set for a Wrapped recap
+
+	Stats *Structs.PlayStats `json:"stats"`
+
+}
+
+// withRequestorNames swaps requestor IDs for cached display names, leaving the shared stats untouched; without Members the requestors are left out entirely
+func withRequestorNames(Stats *Structs.PlayStats, GuildID snowflake.ID, Members bool) *Structs.PlayStats {
+
+	Named := *Stats
+	Named.TopRequestors = make([]Structs.PlayStatsEntry, 0, len(Stats.TopRequestors))
+
+	if !Members {
+
+		return &Named
+
+	}
+
+	for _, Entry := range Stats.TopRequestors {
+
+		if UserID, ErrorParsing := snowflake.Parse(Entry.ID); ErrorParsing == nil {
+
+			if Member, Cached := Globals.DiscordClient.Caches.Member(GuildID, UserID); Cached {
+
+				Entry.Name = Member.EffectiveName()
+
+			}
+
+		}
+
+		Named.TopRequestors = append(Named.TopRequestors, Entry)
+
+	}
+
+	return &Named
+
+}
+
+// HandleStats serves play log aggregates for the dashboard of an active queue; ?Scope=me needs a signed in user and ?Year= returns a Wrapped recap.
+// Top requestors are only shown to signed in members of the guild
+func HandleStats(Context *gin.Context) {
+
+	GuildID, ErrorParsing := snowflake.Parse(Context.Query("ID"))
+
+	if ErrorParsing != nil {
+
+		Context.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid ID"})
+		return
+
+	}
+
+	if Structs.GetGuild(GuildID, false) == nil {
+
+		Context.JSON(http.StatusNotFound, gin.H{"Error": "Guild not found"})
+		return
+
+	}
+
+	ViewerID, SignedIn := WebUserIDFromRequest(Context.Request)
+
+	Member := false
+
+	if SignedIn {
+
+		_, Member = Globals.DiscordClient.Caches.Member(GuildID, ViewerID)
+
+	}
+
+	Response := StatsResponse{Scope: "server", Period: Context.DefaultQuery("Period", Structs.StatsPeriodMonth)}
+	Query := Structs.PlayStatsQuery{GuildID: GuildID.String()}
+
+	if YearParam := Context.Query("Year"); YearParam != "" {
+
+		Year, ErrorParsingYear := strconv.Atoi(YearParam)
+
+		if ErrorParsingYear != nil || Year < 2000 || Year > 9999 {
+
+			Context.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid Year"})
+			return
+
+		}
+
+		Query = Structs.WrappedQuery(GuildID.String(), Year)
+		Response.Year, Response.Period = Year, ""
+
+	} else {
+
+		Since, ErrorParsingPeriod := Structs.PeriodStart(Response.Period)
+
+		if ErrorParsingPeriod != nil {
+
+			Context.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid Period"})
+			return
+
+		}
+
+		Query.Since = Since
+
+	}
+
+	if Context.Query("Scope") == "me" {
+
+		if !SignedIn {
+
+			Context.JSON(http.StatusUnauthorized, gin.H{"Error": "Sign in to see your stats"})
+			return
+
+		}
+
+		Query.UserID = ViewerID.String()
+		Response.Scope = "me"
+
+	}
+
+	Stats, ErrorAggregating := Structs.CachedPlayStats(Query)
+
+	if ErrorAggregating != nil {
+
+		Utils.Logger.Error("Stats", fmt.Sprintf("Error aggregating play log for guild %s: %s", Query.GuildID, ErrorAggregating.Error()))
+
+		Context.JSON(http.StatusInternalServerError, gin.H{"Error": "Could not load stats"})
+		return
+
+	}
+
+	Response.Stats = withRequestorNames(Stats, GuildID, Member)
+
+	Context.JSON(http.StatusOK, Response)
+
+}
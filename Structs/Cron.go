@@ -0,0 +1,277 @@
+package Structs
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrCronSyntax = errors.New("invalid cron expression")
+
+// cronAliases maps the common shorthands onto their five-field form
+var cronAliases = map[string]string{
+
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+
+}
+
+// CronSchedule is a parsed five-field cron expression (minute hour day-of-month month day-of-week)
+type CronSchedule struct {
+
+	Minutes  uint64
+	Hours    uint64
+	Days     uint64
+	Months   uint64
+	Weekdays uint64
+
+	AnyDay     bool // day-of-month was "*", so only the weekday field restricts days
+	AnyWeekday bool // day-of-week was "*", so only the day-of-month field restricts days
+
+}
+
+// ParseCron parses a standard five-field cron expression or one of the @ aliases
+func ParseCron(Expression string) (*CronSchedule, error) {
+
+	Expression = strings.TrimSpace(strings.ToLower(Expression))
+
+	if Alias, Exists := cronAliases[Expression]; Exists {
+
+		Expression = Alias
+
+	}
+
+	Fields := strings.Fields(Expression)
+
+	if len(Fields) != 5 {
+
+		return nil, ErrCronSyntax
+
+	}
+
+	Bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	Sets := [5]uint64{}
+
+	for Index, Field := range Fields {
+
+		Set, Error := parseCronField(Field, Bounds[Index][0], Bounds[Index][1])
+
+		if Error != nil {
+
+			return nil, Error
+
+		}
+
+		Sets[Index] = Set
+
+	}
+
+	// 7 is an alias for Sunday
+
+	if Sets[4]&(1<<7) != 0 {
+
+		Sets[4] |= 1
+
+	}
+
+	return &CronSchedule{
+
+		Minutes:    Sets[0],
+		Hours:      Sets[1],
+		Days:       Sets[2],
+		Months:     Sets[3],
+		Weekdays:   Sets[4],
+		AnyDay:     Fields[2] == "*",
+		AnyWeekday: Fields[4] == "*",
+
+	}, nil
+
+}
+
+// parseCronField turns "*", "a", "a-b", "*/n", "a-b/n" and comma lists into a bit set
+func parseCronField(Field string, Min int, Max int) (uint64, error) {
+
+	var Set uint64
+
+	for _, Part := range strings.Split(Field, ",") {
+
+		Step := 1
+
+		if Range, StepText, HasStep := strings.Cut(Part, "/"); HasStep {
+
+			Parsed, Error := strconv.Atoi(StepText)
+
+			if Error != nil || Parsed <= 0 {
+
+				return 0, ErrCronSyntax
+
+			}
+
+			Part = Range
+			Step = Parsed
+
+		}
+
+		Start, End := Min, Max
+
+		if Part != "*" {
+
+			From, To, IsRange := strings.Cut(Part, "-")
+
+			Parsed, Error := strconv.Atoi(From)
+
+			if Error != nil {
+
+				return 0, ErrCronSyntax
+
+			}
+
+			Start, End = Parsed, Parsed
+
+			if IsRange {
+
+				if End, Error = strconv.Atoi(To); Error != nil {
+
+					return 0, ErrCronSyntax
+
+				}
+
+			} else if Step > 1 {
+
+				End = Max // "5/15" means every 15 starting at 5
+
+			}
+
+		}
+
+		if Start < Min || End > Max || Start > End {
+
+			return 0, ErrCronSyntax
+
+		}
+
+		for Value := Start; Value <= End; Value += Step {
+
+			Set |= 1 << uint(Value)
+
+		}
+
+	}
+
+	return Set, nil
+
+}
+
+// matchesDay applies cron's rule that a restricted day-of-month and day-of-week are OR'd together
+func (C *CronSchedule) matchesDay(Moment time.Time) bool {
+
+	DayMatch := C.Days&(1<<uint(Moment.Day())) != 0
+	WeekdayMatch := C.Weekdays&(1<<uint(Moment.Weekday())) != 0
+
+	switch {
+
+		case C.AnyDay && C.AnyWeekday:
+
+			return true
+
+		case C.AnyDay:
+
+			return WeekdayMatch
+
+		case C.AnyWeekday:
+
+			return DayMatch
+
+		default:
+
+			return DayMatch || WeekdayMatch
+
+	}
+
+}
+
+// Next returns the first matching minute strictly after After, evaluated in Location
+func (C *CronSchedule) Next(After time.Time, Location *time.Location) (time.Time, bool) {
+
+	Moment := After.In(Location).Truncate(time.Minute).Add(time.Minute)
+	Limit := Moment.AddDate(5, 0, 0) // impossible dates such as 31 February never match
+
+	for Moment.Before(Limit) {
+
+		if C.Months&(1<<uint(Moment.Month())) == 0 {
+
+			Moment = time.Date(Moment.Year(), Moment.Month()+1, 1, 0, 0, 0, 0, Location)
+			continue
+
+		}
+
+		if !C.matchesDay(Moment) {
+
+			Moment = time.Date(Moment.Year(), Moment.Month(), Moment.Day()+1, 0, 0, 0, 0, Location)
+			continue
+
+		}
+
+		if C.Hours&(1<<uint(Moment.Hour())) == 0 {
+
+			Moment = time.Date(Moment.Year(), Moment.Month(), Moment.Day(), Moment.Hour()+1, 0, 0, 0, Location)
+			continue
+
+		}
+
+		if C.Minutes&(1<<uint(Moment.Minute())) == 0 {
+
+			Moment = Moment.Add(time.Minute)
+			continue
+
+		}
+
+		return Moment, true
+
+	}
+
+	return time.Time{}, false
+
+}
+
+// ShortestGap returns the smallest interval between the next Samples runs, used to reject overly frequent schedules
+func (C *CronSchedule) ShortestGap(From time.Time, Location *time.Location, Samples int) time.Duration {
+
+	Shortest := time.Duration(0)
+	Previous, Found := C.Next(From, Location)
+
+	for range Samples {
+
+		if !Found {
+
+			break
+
+		}
+
+		Upcoming, Exists := C.Next(Previous, Location)
+
+		if !Exists {
+
+			break
+
+		}
+
+		if Gap := Upcoming.Sub(Previous); Shortest == 0 || Gap < Shortest {
+
+			Shortest = Gap
+
+		}
+
+		Previous = Upcoming
+
+	}
+
+	return Shortest
+
+}
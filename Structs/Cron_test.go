@@ -0,0 +1,265 @@
+package Structs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// bits builds a cron field bit set from its values
+func bits(Values ...int) uint64 {
+
+	var Set uint64
+
+	for _, Value := range Values {
+
+		Set |= 1 << uint(Value)
+
+	}
+
+	return Set
+
+}
+
+func TestParseCronField(T *testing.T) {
+
+	Cases := []struct {
+
+		Field    string
+		Min, Max int
+		Want     uint64
+
+	}{
+
+		{"*", 0, 5, bits(0, 1, 2, 3, 4, 5)},
+		{"7", 0, 59, bits(7)},
+		{"1-5", 0, 23, bits(1, 2, 3, 4, 5)},
+		{"*/15", 0, 59, bits(0, 15, 30, 45)},
+		{"5/15", 0, 59, bits(5, 20, 35, 50)},
+		{"1-10/3", 0, 23, bits(1, 4, 7, 10)},
+		{"1,3,5", 1, 31, bits(1, 3, 5)},
+		{"1-3,10,20-22", 1, 31, bits(1, 2, 3, 10, 20, 21, 22)},
+		{"0-6/2,1", 0, 7, bits(0, 1, 2, 4, 6)},
+
+	}
+
+	for _, Case := range Cases {
+
+		Got, Error := parseCronField(Case.Field, Case.Min, Case.Max)
+
+		if Error != nil {
+
+			T.Errorf("parseCronField(%q) failed: %s", Case.Field, Error)
+			continue
+
+		}
+
+		if Got != Case.Want {
+
+			T.Errorf("parseCronField(%q) = %b, want %b", Case.Field, Got, Case.Want)
+
+		}
+
+	}
+
+}
+
+func TestParseCron(T *testing.T) {
+
+	Schedule, Error := ParseCron("@weekly")
+
+	if Error != nil {
+
+		T.Fatalf("ParseCron(@weekly) failed: %s", Error)
+
+	}
+
+	if Schedule.Minutes != bits(0) || Schedule.Hours != bits(0) || Schedule.Weekdays != bits(0) || !Schedule.AnyDay || Schedule.AnyWeekday {
+
+		T.Errorf("ParseCron(@weekly) = %+v, want midnight on Sundays", Schedule)
+
+	}
+
+	Schedule, Error = ParseCron("0 9 * * 7")
+
+	if Error != nil {
+
+		T.Fatalf("ParseCron(0 9 * * 7) failed: %s", Error)
+
+	}
+
+	if Schedule.Weekdays&bits(0) == 0 {
+
+		T.Errorf("day-of-week 7 = %b, want Sunday (0) included", Schedule.Weekdays)
+
+	}
+
+	Invalid := []string{
+
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/-5 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"1-x * * * *",
+		"1,,2 * * * *",
+		"@fortnightly",
+
+	}
+
+	for _, Expression := range Invalid {
+
+		if _, Error := ParseCron(Expression); !errors.Is(Error, ErrCronSyntax) {
+
+			T.Errorf("ParseCron(%q) = %v, want ErrCronSyntax", Expression, Error)
+
+		}
+
+	}
+
+}
+
+func TestCronNext(T *testing.T) {
+
+	At := func(Text string) time.Time {
+
+		Moment, Error := time.ParseInLocation(ScheduleTimeLayout, Text, time.UTC)
+
+		if Error != nil {
+
+			T.Fatalf("bad test time %q: %s", Text, Error)
+
+		}
+
+		return Moment
+
+	}
+
+	// 1 March 2026 is a Sunday, 1 April a Wednesday
+
+	Cases := []struct {
+
+		Name       string
+		Expression string
+		After      string
+		Want       string // empty when nothing ever matches
+
+	}{
+
+		{"next minute", "* * * * *", "2026-03-02 09:00", "2026-03-02 09:01"},
+		{"strictly after", "0 9 * * *", "2026-03-02 09:00", "2026-03-03 09:00"},
+		{"later today", "30 18 * * *", "2026-03-02 09:00", "2026-03-02 18:30"},
+		{"weekday", "0 9 * * 1", "2026-03-01 10:00", "2026-03-02 09:00"},
+		{"weekday range", "0 9 * * 1-5", "2026-03-06 10:00", "2026-03-09 09:00"},
+		{"minute step", "*/20 * * * *", "2026-03-02 09:41", "2026-03-02 10:00"},
+		{"hour list", "15 8,20 * * *", "2026-03-02 09:00", "2026-03-02 20:15"},
+		{"day-of-week only", "0 0 * * 5", "2026-04-11 00:00", "2026-04-17 00:00"},
+		{"day-of-month only", "0 0 13 * *", "2026-04-11 00:00", "2026-04-13 00:00"},
+		{"day-of-month or day-of-week", "0 0 13 * 5", "2026-04-11 00:00", "2026-04-13 00:00"},
+		{"day-of-week or day-of-month", "0 0 13 * 5", "2026-04-13 00:00", "2026-04-17 00:00"},
+		{"month rollover", "30 23 31 * *", "2026-04-01 00:00", "2026-05-31 23:30"},
+		{"month restriction", "0 12 1 6,9 *", "2026-06-01 12:00", "2026-09-01 12:00"},
+		{"year rollover", "0 0 1 1 *", "2026-06-15 00:00", "2027-01-01 00:00"},
+		{"leap day", "0 12 29 2 *", "2026-03-01 00:00", "2028-02-29 12:00"},
+		{"impossible date", "0 0 31 2 *", "2026-03-01 00:00", ""},
+
+	}
+
+	for _, Case := range Cases {
+
+		Schedule, Error := ParseCron(Case.Expression)
+
+		if Error != nil {
+
+			T.Errorf("%s: ParseCron(%q) failed: %s", Case.Name, Case.Expression, Error)
+			continue
+
+		}
+
+		Got, Found := Schedule.Next(At(Case.After), time.UTC)
+
+		if Case.Want == "" {
+
+			if Found {
+
+				T.Errorf("%s: Next(%q) = %s, want no match", Case.Name, Case.Expression, Got.Format(ScheduleTimeLayout))
+
+			}
+
+			continue
+
+		}
+
+		if !Found || !Got.Equal(At(Case.Want)) {
+
+			T.Errorf("%s: Next(%q) after %s = %s (%t), want %s", Case.Name, Case.Expression, Case.After, Got.Format(ScheduleTimeLayout), Found, Case.Want)
+
+		}
+
+	}
+
+}
+
+func TestCronNextTruncatesSeconds(T *testing.T) {
+
+	Schedule, _ := ParseCron("* * * * *")
+
+	Got, _ := Schedule.Next(time.Date(2026, 3, 2, 9, 0, 30, 0, time.UTC), time.UTC)
+
+	if Want := time.Date(2026, 3, 2, 9, 1, 0, 0, time.UTC); !Got.Equal(Want) {
+
+		T.Errorf("Next after 09:00:30 = %s, want %s", Got, Want)
+
+	}
+
+}
+
+func TestCronShortestGap(T *testing.T) {
+
+	From := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	Cases := []struct {
+
+		Expression string
+		Want       time.Duration
+
+	}{
+
+		{"*/20 * * * *", 20 * time.Minute},
+		{"0 9,10 * * *", time.Hour},
+		{"0 0 * * 1,3", 48 * time.Hour},
+		{"0 0 1 * *", 28 * 24 * time.Hour}, // February
+		{"0 0 31 2 *", 0},                  // never runs
+
+	}
+
+	for _, Case := range Cases {
+
+		Schedule, Error := ParseCron(Case.Expression)
+
+		if Error != nil {
+
+			T.Errorf("ParseCron(%q) failed: %s", Case.Expression, Error)
+			continue
+
+		}
+
+		if Got := Schedule.ShortestGap(From, time.UTC, 12); Got != Case.Want {
+
+			T.Errorf("ShortestGap(%q) = %s, want %s", Case.Expression, Got, Case.Want)
+
+		}
+
+	}
+
+}
@@ -0,0 +1,157 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals"
+	"sort"
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// fairInsertIndex returns where a song from Requestor goes so that requestors take turns; each requestor's own songs keep their order
+func (Q *Queue) fairInsertIndex(Requestor string) int {
+
+	Requestor = requestorKey(Requestor)
+
+	Seen := make(map[string]int)
+
+	if Q.Current != nil {
+
+		Seen[requestorKey(Q.Current.Internal.Requestor)] = 1 // the playing song counts as the requestor's turn
+
+	}
+
+	Round := Seen[Requestor]
+
+	for _, Song := range Q.Upcoming {
+
+		if requestorKey(Song.Internal.Requestor) == Requestor {
+
+			Round++
+
+		}
+
+	}
+
+	Index := 0
+
+	for i, Song := range Q.Upcoming {
+
+		Key := requestorKey(Song.Internal.Requestor)
+
+		SongRound := Seen[Key]
+		Seen[Key]++
+
+		if SongRound <= Round {
+
+			Index = i + 1
+
+		}
+
+	}
+
+	return Index
+
+}
+
+// FairOrder returns the songs interleaved round-robin by requestor, keeping each requestor's own order
+func FairOrder(Current *Tidal.Song, Songs []*Tidal.Song) []*Tidal.Song {
+
+	type rankedSong struct {
+
+		Song  *Tidal.Song
+		Round int
+		Index int
+
+	}
+
+	Seen := make(map[string]int)
+
+	if Current != nil {
+
+		Seen[requestorKey(Current.Internal.Requestor)] = 1
+
+	}
+
+	Ranked := make([]rankedSong, len(Songs))
+
+	for i, Song := range Songs {
+
+		Key := requestorKey(Song.Internal.Requestor)
+
+		Ranked[i] = rankedSong{Song: Song, Round: Seen[Key], Index: i}
+		Seen[Key]++
+
+	}
+
+	sort.SliceStable(Ranked, func(a, b int) bool {
+
+		return Ranked[a].Round < Ranked[b].Round
+
+	})
+
+	Ordered := make([]*Tidal.Song, len(Ranked))
+
+	for i, Entry := range Ranked {
+
+		Ordered[i] = Entry.Song
+
+	}
+
+	return Ordered
+
+}
+
+// requestorKey groups songs by the user behind them, whether the requestor was stored as a mention or a bare user ID
+func requestorKey(Requestor string) string {
+
+	if UserID, IsUser := RequestorUserID(Requestor); IsUser {
+
+		return UserID.String()
+
+	}
+
+	return Requestor
+
+}
+
+// Rebalance re-interleaves the upcoming songs when fair-share mode is enabled
+func (Q *Queue) Rebalance() {
+
+	Q.Upcoming = FairOrder(Q.Current, Q.Upcoming)
+	Q.Functions.Updated(Q)
+
+}
+
+// fairShare reports whether the queue's guild has fair-share mode enabled
+func (Q *Queue) fairShare() bool {
+
+	Guild := GetGuild(Q.ParentID, false)
+
+	return Guild != nil && Guild.Settings.Queue.FairShare
+
+}
+
+// RequestorName resolves a song's requestor (a mention, user ID or web username) to a display name
+func (G *Guild) RequestorName(Song *Tidal.Song) string {
+
+	Requestor := Song.Internal.Requestor
+
+	UserID, ErrorParsing := snowflake.Parse(strings.TrimSuffix(strings.TrimPrefix(Requestor, "<@"), ">"))
+
+	if ErrorParsing != nil {
+
+		return Requestor
+
+	}
+
+	if Member, Exists := Globals.DiscordClient.Caches.Member(G.ID, UserID); Exists {
+
+		return Member.EffectiveName()
+
+	}
+
+	return Requestor
+
+}
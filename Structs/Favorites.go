@@ -0,0 +1,349 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs"
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+
+	MaxLikedSongs     = 1000
+	FavoritesPageSize = 10
+
+	ForYouSeedCount = 3  // liked songs whose Tidal mixes are blended
+	ForYouMixSize   = 25
+
+)
+
+var (
+
+	ErrNoLikedSongs = errors.New("no liked songs")
+	ErrForYouEmpty  = errors.New("could not build a for you mix")
+
+)
+
+// LikedSong is a song explicitly liked by a user; the snapshot lets favorites be queued without refetching
+type LikedSong struct {
+
+	Song    Tidal.Song `bson:"song"`
+	LikedAt time.Time  `bson:"liked_at"`
+
+}
+
+// IsLiked reports whether the user has liked a song
+func (U *User) IsLiked(TidalID int64) bool {
+
+	for _, Liked := range U.Liked {
+
+		if Liked.Song.TidalID == TidalID {
+
+			return true
+
+		}
+
+	}
+
+	return false
+
+}
+
+// LikedSongs returns the user's liked songs, most recently liked first
+func (U *User) LikedSongs() []Tidal.Song {
+
+	Songs := make([]Tidal.Song, 0, len(U.Liked))
+
+	for Index := len(U.Liked) - 1; Index >= 0; Index-- {
+
+		Songs = append(Songs, U.Liked[Index].Song)
+
+	}
+
+	return Songs
+
+}
+
+// Like adds a song to the user's favorites; the oldest likes are dropped past MaxLikedSongs
+func (U *User) Like(Song *Tidal.Song) error {
+
+	if Song == nil || Song.TidalID == 0 || U.IsLiked(Song.TidalID) {
+
+		return nil
+
+	}
+
+	Entry := LikedSong{Song: *CloneSong(Song), LikedAt: time.Now().UTC()}
+
+	U.Liked = append(U.Liked, Entry)
+
+	if len(U.Liked) > MaxLikedSongs {
+
+		U.Liked = U.Liked[len(U.Liked)-MaxLikedSongs:]
+
+	}
+
+	Collection := Globals.Database.Collection("Users")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Update := bson.M{
+
+		"$push": bson.M{
+
+			"liked": bson.M{"$each": bson.A{Entry}, "$slice": -MaxLikedSongs},
+
+		},
+
+	}
+
+	_, UpdateError := Collection.UpdateOne(Context, bson.M{"_id": U.DiscordID}, Update, options.Update().SetUpsert(true))
+
+	return UpdateError
+
+}
+
+// Unlike removes a song from the user's favorites
+func (U *User) Unlike(TidalID int64) error {
+
+	Kept := make([]LikedSong, 0, len(U.Liked))
+
+	for _, Liked := range U.Liked {
+
+		if Liked.Song.TidalID != TidalID {
+
+			Kept = append(Kept, Liked)
+
+		}
+
+	}
+
+	U.Liked = Kept
+
+	Collection := Globals.Database.Collection("Users")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Update := bson.M{
+
+		"$pull": bson.M{
+
+			"liked": bson.M{"song.tidalid": TidalID},
+
+		},
+
+	}
+
+	_, UpdateError := Collection.UpdateOne(Context, bson.M{"_id": U.DiscordID}, Update)
+
+	return UpdateError
+
+}
+
+// ToggleLike likes a song, or unlikes it if it was already liked; it returns whether the song is now liked
+func (U *User) ToggleLike(Song *Tidal.Song) (bool, error) {
+
+	if U.IsLiked(Song.TidalID) {
+
+		return false, U.Unlike(Song.TidalID)
+
+	}
+
+	return true, U.Like(Song)
+
+}
+
+// songFromURI fetches the Tidal song behind a song URI
+func songFromURI(URI string) (Tidal.Song, error) {
+
+	Type, ID, ErrorParsing := APIs.ParseURI(URI)
+
+	if ErrorParsing != nil {
+
+		return Tidal.Song{}, ErrorParsing
+
+	}
+
+	TidalID, ErrorParsingID := strconv.ParseInt(ID, 10, 64)
+
+	if Type != APIs.URITypeTidalSong || ErrorParsingID != nil {
+
+		return Tidal.Song{}, fmt.Errorf("not a Tidal song URI: %s", URI)
+
+	}
+
+	return Tidal.GetSong(TidalID)
+
+}
+
+// FavoriteSongs returns what the Favorites URI queues: the liked library, or the most played songs for users who have not liked anything yet
+func (U *User) FavoriteSongs() []Tidal.Song {
+
+	if len(U.Liked) > 0 {
+
+		return U.LikedSongs()
+
+	}
+
+	Songs := []Tidal.Song{}
+
+	for _, URI := range U.GetTopFavorites(10) {
+
+		if Song, ErrorResolving := songFromURI(URI); ErrorResolving == nil {
+
+			Songs = append(Songs, Song)
+
+		}
+
+	}
+
+	return Songs
+
+}
+
+// GenerateForYouMix blends the Tidal mixes of a few random liked songs into a mix of songs the user has not liked yet; the first seed's mix becomes MostRecentMix
+func (U *User) GenerateForYouMix() ([]Tidal.Song, error) {
+
+	if len(U.Liked) == 0 {
+
+		return nil, ErrNoLikedSongs
+
+	}
+
+	Seeds := make([]Tidal.Song, 0, ForYouSeedCount)
+
+	for _, Index := range rand.Perm(len(U.Liked)) {
+
+		if len(Seeds) >= ForYouSeedCount {
+
+			break
+
+		}
+
+		Seed := U.Liked[Index].Song
+
+		if Seed.MixID == "" {
+
+			Fetched, ErrorFetching := Tidal.GetSong(Seed.TidalID)
+
+			if ErrorFetching != nil || Fetched.MixID == "" {
+
+				continue
+
+			}
+
+			Seed = Fetched
+
+		}
+
+		Seeds = append(Seeds, Seed)
+
+	}
+
+	Mixes := [][]Tidal.Song{}
+
+	for _, Seed := range Seeds {
+
+		Items, ErrorFetching := Tidal.FetchMixItems(Seed.MixID)
+
+		if ErrorFetching == nil && len(Items) > 0 {
+
+			Mixes = append(Mixes, Items)
+
+		}
+
+	}
+
+	if len(Mixes) == 0 {
+
+		return nil, ErrForYouEmpty
+
+	}
+
+	// Interleave the mixes so every seed is represented near the top
+
+	Seen := map[int64]bool{}
+	Mix := []Tidal.Song{}
+
+	for Round := 0; len(Mix) < ForYouMixSize; Round++ {
+
+		Added := false
+
+		for _, Items := range Mixes {
+
+			if Round >= len(Items) {
+
+				continue
+
+			}
+
+			Added = true
+			Song := Items[Round]
+
+			if Seen[Song.TidalID] || U.IsLiked(Song.TidalID) || len(Mix) >= ForYouMixSize {
+
+				continue
+
+			}
+
+			Seen[Song.TidalID] = true
+			Mix = append(Mix, Song)
+
+		}
+
+		if !Added {
+
+			break
+
+		}
+
+	}
+
+	if len(Mix) == 0 {
+
+		return nil, ErrForYouEmpty
+
+	}
+
+	U.SetMostRecentMix(Seeds[0].MixID)
+
+	return Mix, nil
+
+}
+
+// FindSong returns a song with the given Tidal ID from anywhere in the queue, or nil
+func (Q *Queue) FindSong(TidalID int64) *Tidal.Song {
+
+	if Q.Current != nil && Q.Current.TidalID == TidalID {
+
+		return Q.Current
+
+	}
+
+	for _, Songs := range [][]*Tidal.Song{Q.Upcoming, Q.Previous, Q.Suggestions} {
+
+		for _, Song := range Songs {
+
+			if Song != nil && Song.TidalID == TidalID {
+
+				return Song
+
+			}
+
+		}
+
+	}
+
+	return nil
+
+}
@@ -1,11 +1,7 @@
 package Structs
 
 import (
-	"Synthara-Redux/APIs"
-	"Synthara-Redux/APIs/Apple"
-	"Synthara-Redux/APIs/Spotify"
 	"Synthara-Redux/APIs/Tidal"
-	"Synthara-Redux/APIs/YouTube"
 	"Synthara-Redux/Audio"
 	"Synthara-Redux/Globals"
 	"Synthara-Redux/Globals/Icons"
@@ -16,7 +12,6 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
-	"strconv"
 	"sync"
 	"time"
 
@@ -42,6 +37,8 @@ type Guild struct {
 
 	Features Features `json:"features"`
 
+	Settings GuildSettings `json:"-"`
+
 	VoiceConnection voice.Conn `json:"-"`
 	StreamerMutex   sync.Mutex `json:"-"`
 
@@ -76,6 +73,7 @@ type Features struct {
 
 	Repeat   int  `json:"repeat"`
 	Shuffle  bool `json:"shuffle"`
+	ShuffleMode string `json:"shuffle_mode"`
 	Autoplay bool `json:"autoplay"`
 	Locked   bool `json:"locked"`
 	Volume     int `json:"volume"`
@@ -91,6 +89,15 @@ type GuildInternal struct {
 	InactivityTimer *time.Timer `json:"-"`
 	InactivityMutex sync.Mutex  `json:"-"`
 
+	AloneTimer *time.Timer `json:"-"` // Armed while no listeners remain in the voice channel
+	AutoPaused bool        `json:"-"` // Playback was paused by the alone detector, not a user
+
+	NowPlayingMessage snowflake.ID `json:"-"` // Last now-playing message, edited to show skip votes
+
+	SkipVotes     map[snowflake.ID]bool `json:"-"`
+	SkipVoteSong  *Tidal.Song           `json:"-"` // Song the current votes apply to
+	SkipVoteMutex sync.Mutex            `json:"-"`
+
 }
 
 // NewGuild Creates a new Guild instance
@@ -132,6 +139,7 @@ func NewGuild(ID snowflake.ID, Locale discord.Locale) *Guild {
 
 			Repeat:   RepeatOff,
 			Shuffle:  false,
+			ShuffleMode: ShuffleRandom,
 			Autoplay: false,
 			Locked:   false,
 			Volume:     DefaultVolume,
@@ -139,6 +147,8 @@ func NewGuild(ID snowflake.ID, Locale discord.Locale) *Guild {
 			Reverb:     DefaultReverb,
 		},
 
+		Settings: LoadGuildSettings(ID.String()),
+
 		VoiceConnection: nil,
 
 		Internal: GuildInternal{
@@ -261,15 +271,19 @@ func (G *Guild) Connect(VoiceChannelID snowflake.ID, TextChannelID snowflake.ID)
 		G.VoiceMixer = Mixer
 		VoiceConnection.SetOpusFrameProvider(Mixer)
 
+		G.applyTalkOverPolicy(Mixer)
+
 	}
 
 	if VoiceCommandsActive {
 
 		G.VoiceReceiver = Receive.AttachReceiver(G.ID, VoiceConnection)
 
-	} else if Receive.VoiceCommandsRequested() {
+		if !Receive.WakeDetectorReady() {
 
-		Utils.Logger.Warn("Guild", fmt.Sprintf("Voice commands requested for guild %s but Porcupine wake detector is unavailable; receiver not attached", G.ID))
+			Utils.Logger.Info("Guild", fmt.Sprintf("Porcupine wake detector is unavailable; voice commands in guild %s are push-to-talk only", G.ID))
+
+		}
 
 	}
 
@@ -277,7 +291,7 @@ func (G *Guild) Connect(VoiceChannelID snowflake.ID, TextChannelID snowflake.ID)
 
 }
 
-// PlayFeedbackCue plays capture start/end feedback through the guild voice mixer.
+// PlayFeedbackCue plays capture and confirmation feedback through the guild voice mixer.
 func (G *Guild) PlayFeedbackCue(Kind Receive.FeedbackCueKind) {
 
 	var Cue Audio.CueKind
@@ -292,6 +306,18 @@ func (G *Guild) PlayFeedbackCue(Kind Receive.FeedbackCueKind) {
 
 			Cue = Audio.CueCaptureEnd
 
+		case Receive.FeedbackCueConfirmPrompt:
+
+			Cue = Audio.CueConfirmPrompt
+
+		case Receive.FeedbackCueConfirmAccept:
+
+			Cue = Audio.CueConfirmAccept
+
+		case Receive.FeedbackCueConfirmCancel:
+
+			Cue = Audio.CueConfirmCancel
+
 		default:
 
 			return
@@ -314,7 +340,7 @@ func (G *Guild) PlayFeedbackCue(Kind Receive.FeedbackCueKind) {
 
 }
 
-// PlayVoiceResponse generates TTS audio for text and plays it through the guild voice mixer.
+// PlayVoiceResponse generates TTS audio for text in the guild locale voice and plays it through the guild voice mixer.
 func (G *Guild) PlayVoiceResponse(text string) {
 
 	G.StreamerMutex.Lock()
@@ -327,7 +353,7 @@ func (G *Guild) PlayVoiceResponse(text string) {
 
 	}
 
-	Frames, Err := Audio.GenerateTTS(text)
+	Frames, Err := Audio.GenerateTTS(text, G.Locale.Code())
 
 	if Err != nil {
 
@@ -367,6 +393,58 @@ func (G *Guild) SetVoiceCaptureDucked(Ducked bool) {
 
 }
 
+// SetTalkingOver ducks music while anyone in the channel talks, when the guild has talk-over enabled.
+func (G *Guild) SetTalkingOver(Talking bool) {
+
+	G.StreamerMutex.Lock()
+
+	Mixer := G.VoiceMixer
+
+	G.StreamerMutex.Unlock()
+
+	if Mixer == nil {
+
+		return
+
+	}
+
+	Mixer.SetTalkOver(Talking && G.Settings.TalkOver.Enabled)
+
+}
+
+// ApplyTalkOverPolicy passes the guild's talk-over depth and timing to the voice mixer.
+func (G *Guild) ApplyTalkOverPolicy() {
+
+	G.StreamerMutex.Lock()
+
+	Mixer := G.VoiceMixer
+
+	G.StreamerMutex.Unlock()
+
+	G.applyTalkOverPolicy(Mixer)
+
+}
+
+func (G *Guild) applyTalkOverPolicy(Mixer *Audio.MixerProvider) {
+
+	if Mixer == nil {
+
+		return
+
+	}
+
+	Policy := G.Settings.TalkOver
+
+	Mixer.SetTalkOverShape(float32(Policy.Depth)/100, time.Duration(Policy.AttackMs)*time.Millisecond, time.Duration(Policy.ReleaseMs)*time.Millisecond)
+
+	if !Policy.Enabled {
+
+		Mixer.SetTalkOver(false)
+
+	}
+
+}
+
 // Disconnect Closes the existing voice connection; if none exists, returns an error
 func (G *Guild) Disconnect(CloseConn bool) error {
 
@@ -398,8 +476,9 @@ func (G *Guild) Cleanup(CloseConn bool) error {
 
 	G.Internal.Disconnecting = true // Marks as disconnecting early to prevent re-entrancy
 
-	// Stop inactivity timer if present
+	// Stop inactivity and alone timers if present
 	G.StopInactivityTimer()
+	G.StopAloneTimer()
 
 	// Removes immediately from guild store so no new operations re-acquire this guild
 
@@ -407,6 +486,8 @@ func (G *Guild) Cleanup(CloseConn bool) error {
 	delete(GuildStore, G.ID)
 	GuildStoreMutex.Unlock()
 
+	G.Queue.endPlay(true) // cut short by the disconnect; logged before the session's progress is lost
+
 	// Stop playback session if present
 
 	if G.Queue.PlaybackSession != nil {
@@ -455,6 +536,9 @@ func (G *Guild) Cleanup(CloseConn bool) error {
 	G.Queue.Upcoming = nil
 	G.Queue.Suggestions = nil
 
+	G.Queue.ClearHistory()
+	G.Queue.Radio.Reset()
+
 	// We should tear down the voice receiver before the underlying connection
 
 	if G.VoiceReceiver != nil {
@@ -511,7 +595,7 @@ func (G *Guild) Cleanup(CloseConn bool) error {
 
 }
 
-// StartInactivityTimer starts or resets the inactivity timer
+// StartInactivityTimer starts or resets the inactivity timer; does nothing in 24/7 mode
 func (G *Guild) StartInactivityTimer() {
 
 	G.Internal.InactivityMutex.Lock()
@@ -521,18 +605,19 @@ func (G *Guild) StartInactivityTimer() {
 	if G.Internal.InactivityTimer != nil {
 
 		G.Internal.InactivityTimer.Stop()
+		G.Internal.InactivityTimer = nil
 
 	}
 
-	// Determine timeout duration based on AutoPlay setting
-	Duration := 1 * time.Hour
-
-	if G.Features.Autoplay {
+	if G.Settings.Inactivity.AlwaysOn {
 
-		Duration = 3 * time.Hour
+		return
 
 	}
 
+	// Determine timeout duration based on the guild policy and AutoPlay setting
+	Duration := G.InactivityTimeout()
+
 	Utils.Logger.Info("Guild", fmt.Sprintf("Starting inactivity timer for guild %s with duration: %s", G.ID.String(), Duration.String()))
 
 	// Create new timer
@@ -543,15 +628,6 @@ func (G *Guild) StartInactivityTimer() {
 		// Get guild locale for translations
 		Locale := G.Locale.Code()
 
-		// Determine duration string for message
-		DurationKey := "Common.OneHour"
-
-		if G.Features.Autoplay {
-
-			DurationKey = "Common.ThreeHours"
-
-		}
-
 		// Send notification message before disconnecting
 		go func() {
 
@@ -565,7 +641,7 @@ func (G *Guild) StartInactivityTimer() {
 
 					Title:       Localizations.Get("Embeds.Notifications.InactivityDisconnect.Title", Locale),
 					Author:      Localizations.Get("Embeds.Categories.Notifications", Locale),
-					Description: Localizations.GetFormat("Embeds.Notifications.InactivityDisconnect.Description", Locale, Localizations.Get(DurationKey, Locale)),
+					Description: Localizations.GetFormat("Embeds.Notifications.InactivityDisconnect.Description", Locale, FormatPolicyDuration(Duration, Locale)),
 				})).
 				AddActionRow(DisconnectButton))
 
@@ -595,16 +671,8 @@ func (G *Guild) ResetInactivityTimer() {
 
 		G.Internal.InactivityTimer.Stop()
 
-		// Determine timeout duration based on AutoPlay setting
-		Duration := 1 * time.Hour
-
-		if G.Features.Autoplay {
-
-			Duration = 3 * time.Hour
-
-		}
-
-		G.Internal.InactivityTimer.Reset(Duration)
+		// Determine timeout duration based on the guild policy and AutoPlay setting
+		G.Internal.InactivityTimer.Reset(G.InactivityTimeout())
 
 	}
 
@@ -630,753 +698,242 @@ func (G *Guild) StopInactivityTimer() {
 // RouteURI takes a Synthara-Redux URI string and handles adding/playing the content. Returns the song, its position in the queue, and any error
 func (G *Guild) HandleURI(URI string, Requestor string) (*Tidal.Song, int, error) {
 
-	Type, ID, ErrorParsing := APIs.ParseURI(URI)
-
-	if ErrorParsing != nil {
-
-		return nil, -1, ErrorParsing
-
-	}
-
-	var PosAdded int
-	var SongFound *Tidal.Song
-
-	switch Type {
-
-	// Plain-text search
-
-	case APIs.URITypeNone:
-
-		SearchResults, SearchErr := Tidal.SearchSongs(ID)
-
-		if SearchErr != nil || len(SearchResults) == 0 {
-
-			return nil, -1, errors.New("no search results found")
-
-		}
-
-		SongFound = &SearchResults[0]
-
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-	// Tidal (Default)
-
-	case APIs.URITypeTidalSong:
-
-		// Internal Tidal song ID - fetch directly by ID
-
-		TidalID, ParseErr := strconv.ParseInt(ID, 10, 64)
-
-		if ParseErr != nil {
-
-			return nil, -1, fmt.Errorf("invalid Tidal ID: %s", ID)
-
-		}
-
-		FetchedSong, FetchErr := Tidal.GetSong(TidalID)
-
-		if FetchErr != nil {
-
-			return nil, -1, FetchErr
-
-		}
-
-		SongFound = &FetchedSong
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-	case APIs.URITypeTidalAlbum:
-
-		// Tidal album - fetch all tracks
-
-		AlbumID, ParseErr := strconv.ParseInt(ID, 10, 64)
-
-		if ParseErr != nil {
-
-			return nil, -1, fmt.Errorf("invalid Tidal album ID: %s", ID)
-
-		}
-
-		AlbumTracks, FetchErr := Tidal.FetchAlbumTracks(AlbumID)
-
-		if FetchErr != nil || len(AlbumTracks) == 0 {
-
-			return nil, -1, errors.New("could not fetch album tracks")
-
-		}
-
-		SongFound = &AlbumTracks[0]
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-		// Add rest of tracks in background
-
-		if len(AlbumTracks) > 1 {
-
-			go func() {
-
-				for _, Song := range AlbumTracks[1:] {
-
-					SongCopy := Song
-					G.Queue.Add(&SongCopy, Requestor)
-
-				}
-
-				Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
-
-					Title: Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-					Author: Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-					Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromAlbum", G.Locale.Code(), len(AlbumTracks), Localizations.Pluralize("Song", len(AlbumTracks), G.Locale.Code()), AlbumTracks[0].Album),
-
-				})))
-
-			}()
-
-		}
-
-	case APIs.URITypeFavorites:
-
-		User, UserErr := GetUser(ID) // ID from URI is UserID
-
-		if UserErr != nil {
-
-			return nil, -1, UserErr
-
-		}
-
-		FavURIs := User.GetTopFavorites(10)
-
-		if len(FavURIs) == 0 {
-			return nil, -1, errors.New("no favorites found")
-		}
-
-		Songs := []Tidal.Song{}
-
-		for _, favURI := range FavURIs {
-
-			// Parse and resolve each favorite
-
-			Type, ResID, pErr := APIs.ParseURI(favURI)
-
-			if pErr == nil && Type == APIs.URITypeTidalSong {
-
-				tid, _ := strconv.ParseInt(ResID, 10, 64)
-
-				if s, err := Tidal.GetSong(tid); err == nil {
-
-					Songs = append(Songs, s)
-
-				}
-
-			}
-
-		}
-
-		if len(Songs) == 0 {
-
-			return nil, -1, errors.New("could not resolve favorites")
-
-		}
-
-		// Set Playlist Metadata
-
-		PlaylistMeta := Tidal.PlaylistMeta{
-
-			Name: "Favorites",
-			Platform: "System",
-
-			Total: len(Songs),
-
-			ID: "favorites:" + ID,
-
-		}
-
-		for i := range Songs {
-
-			Songs[i].Internal.Playlist = PlaylistMeta
-			Songs[i].Internal.Playlist.Index = i
-
-		}
-
-		SongFound = &Songs[0]
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-		if len(Songs) > 1 {
-
-			go func() {
-
-				for _, Song := range Songs[1:] {
-
-					SongCopy := Song
-					G.Queue.Add(&SongCopy, Requestor)
-
-				}
-
-			}()
-
-		}
-
-	case APIs.URITypeSuggestions:
-
-		User, UserErr := GetUser(ID)
-
-		if UserErr != nil {
-
-			return nil, -1, UserErr
-
-		}
-
-		if User.MostRecentMix == "" {
-
-			return nil, -1, errors.New("no recent mix found")
-
-		}
-
-		Songs, FetchErr := Tidal.FetchMixItems(User.MostRecentMix)
-
-		if FetchErr != nil || len(Songs) == 0 {
-
-			return nil, -1, errors.New("could not fetch suggestions")
-
-		}
-
-		if len(Songs) > 10 {
-
-			Songs = Songs[:10]
-
-		}
-
-		// Set Playlist Metadata
-		PlaylistMeta := Tidal.PlaylistMeta{
-
-			Name: "Suggestions",
-			Platform: "System",
-
-			Total: len(Songs),
-
-			ID: "suggestions:" + ID,
-
-		}
-
-		for i := range Songs {
-
-			Songs[i].Internal.Playlist = PlaylistMeta
-			Songs[i].Internal.Playlist.Index = i
-
-		}
-
-		SongFound = &Songs[0]
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-		if len(Songs) > 1 {
-
-			go func() {
-
-				for _, Song := range Songs[1:] {
-
-					SongCopy := Song
-					G.Queue.Add(&SongCopy, Requestor)
-
-				}
-
-			}()
-
-		}
-
-	case APIs.URITypeTidalPlaylist:
-
-		// Tidal playlist
-
-		PlaylistID, ParseErr := strconv.ParseInt(ID, 10, 64)
-
-		if ParseErr != nil {
-
-			return nil, -1, fmt.Errorf("invalid Tidal playlist ID: %s", ID)
-
-		}
-
-		PlaylistTracks, FetchErr := Tidal.FetchPlaylistTracks(PlaylistID)
-
-		if FetchErr != nil || len(PlaylistTracks) == 0 {
-
-			return nil, -1, errors.New("could not fetch playlist tracks")
-
-		}
-
-		SongFound = &PlaylistTracks[0]
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-		// Add rest of tracks in background
-
-		if len(PlaylistTracks) > 1 {
-
-			go func() {
-
-				for _, Song := range PlaylistTracks[1:] {
-
-					SongCopy := Song
-
-					G.Queue.Add(&SongCopy, Requestor)
-
-				}
+	return G.HandleURIAt(URI, Requestor, PositionEnd)
 
-				Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
-
-					Title: Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-					Author: Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-					Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromPlaylist", G.Locale.Code(), len(PlaylistTracks), Localizations.Pluralize("Song", len(PlaylistTracks), G.Locale.Code()), "Playlist"),
-
-				})))
-
-			}()
-
-		}
-
-	// YouTube
-
-	case APIs.URITypeYouTubeVideo:
-
-		ResolvedSong, _, YouTubeFetchErr := YouTube.VideoIDToSong(ID)
-
-		if YouTubeFetchErr != nil {
-
-			return nil, -1, YouTubeFetchErr
-
-		}
-
-		SongFound = &ResolvedSong
-
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-	case APIs.URITypeYouTubePlaylist:
-
-		FirstSong, YouTubePlaylist, FirstSongError := YouTube.PlaylistIDToFirstSong(ID)
-
-		if FirstSongError != nil {
-
-			return nil, -1, FirstSongError
-
-		}
-
-		SongFound = &FirstSong
+}
 
-		PosAdded = G.Queue.Add(&FirstSong, Requestor)
+// HandleURIAt is HandleURI with an insert position; multi-track content keeps its order from that position onwards
+func (G *Guild) HandleURIAt(URI string, Requestor string, Position InsertPosition) (*Tidal.Song, int, error) {
 
-		go func() {
+	Content, ResolveError := ResolveURI(URI)
 
-			// Add rest of playlist
+	if ResolveError != nil {
 
-			AllOtherSongs, FailedCount, _, OtherFetchError := YouTube.PlaylistIDToAllSongs(YouTubePlaylist, true) // ignores first
+		return nil, -1, ResolveError
 
-			if OtherFetchError != nil {
+	}
 
-				Utils.Logger.Error("YouTube Fetch", fmt.Sprintf("Error fetching rest of YouTube playlist songs: %s", OtherFetchError.Error()))
-				return
+	var PosAdded int
+	var SongFound *Tidal.Song
+	var QuotaRejection error
 
-			}
+	Started := false // a "now" insert interrupts the current song and starts playback itself
 
-			for _, Song := range AllOtherSongs {
+	Skipped := QuotaSkips{}
+	Failed := 0
 
-				G.Queue.Add(&Song, Requestor)
+	// Queue the first song the quota policy admits; refused tracks are counted and reported with the rest
 
-			}
+	Index := 0
 
-			TotalVideos := len(YouTubePlaylist.Videos)
-			SuccessCount := len(AllOtherSongs) + 1 // +1 for first song
+	for SongFound == nil {
 
-			PlaylistTitle := YouTubePlaylist.Title
+		if Index == len(Content.Songs) {
 
-			if PlaylistTitle == "" {
+			if Content.More == nil {
 
-				PlaylistTitle = Localizations.Get("Common.Playlist", G.Locale.Code())
+				return nil, -1, QuotaRejection
 
 			}
 
-			Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
-
-				Title: Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-				Author: Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-				Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromPlaylist", G.Locale.Code(), SuccessCount, Localizations.Pluralize("Song", SuccessCount, G.Locale.Code()), PlaylistTitle),
-
-			})))
-
-			// If some songs failed, we should send an additional notification
-
-			if FailedCount > 0 {
+			// Every song known so far was refused, so fetch the rest now to look for one that is admitted
 
-				Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+			Rest, RestFailed, FetchError := Content.More()
+			Content.More = nil
 
-					Title: Localizations.Get("Embeds.Notifications.SomePlaylistSongsFailed.Title", G.Locale.Code()),
-					Author: Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-					Description: Localizations.GetFormat("Embeds.Notifications.SomePlaylistSongsFailed.Description", G.Locale.Code(), FailedCount, TotalVideos, Localizations.Pluralize("Song", TotalVideos, G.Locale.Code()), PlaylistTitle),
+			if FetchError != nil || len(Rest) == 0 {
 
-				})))
+				return nil, -1, QuotaRejection
 
 			}
 
-		}()
-
-	// YouTube Music
-
-	case APIs.URITypeYTMusicAlbum:
-
-		FirstSong, YouTubeMusicAlbum, FirstSongError := YouTube.MusicAlbumIDToFirstSong(ID)
-
-		if FirstSongError != nil {
-
-			return nil, -1, FirstSongError
+			Content.Songs = append(Content.Songs, Rest...)
+			Failed += RestFailed
 
 		}
 
-		SongFound = &FirstSong
+		Song := &Content.Songs[Index]
+		Index++
 
-		PosAdded = G.Queue.Add(&FirstSong, Requestor)
+		Interrupting := G.Queue.Current != nil
 
-		go func() {
-
-			// Add rest of album
-
-			AllOtherSongs, FailedCount, _, OtherFetchError := YouTube.MusicAlbumIDToAllSongs(YouTubeMusicAlbum, true) // ignores first
-
-			if OtherFetchError != nil {
-
-				Utils.Logger.Error("YouTube Fetch", fmt.Sprintf("Error fetching rest of YouTube Music album songs: %s", OtherFetchError.Error()))
-				return
-
-			}
-
-			for _, Song := range AllOtherSongs {
-
-				G.Queue.Add(&Song, Requestor)
-
-			}
-
-			// Calculate total videos (including first one)
-
-			TotalVideos := len(YouTubeMusicAlbum.Videos)
-			SuccessCount := len(AllOtherSongs) + 1 // +1 for first song
+		Pos, EnqueueError := G.Queue.AddAt(Song, Requestor, Position)
 
-			Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+		if QuotaErr, IsQuota := EnqueueError.(*QuotaError); IsQuota {
 
-				Title: Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-				Author: Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-				Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromAlbum", G.Locale.Code(), SuccessCount, Localizations.Pluralize("Song", SuccessCount, G.Locale.Code()), YouTubeMusicAlbum.Title),
+			Skipped[QuotaErr.Reason]++
 
-			})))
+			if QuotaRejection == nil {
 
-			if FailedCount > 0 {
-
-				Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
-
-					Title:Localizations.Get("Embeds.Notifications.SomePlaylistSongsFailed.Title", G.Locale.Code()),
-					Author:Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-					Description: Localizations.GetFormat("Embeds.Notifications.SomePlaylistSongsFailed.Description", G.Locale.Code(), FailedCount, TotalVideos, Localizations.Pluralize("Song", TotalVideos, G.Locale.Code()), YouTubeMusicAlbum.Title),
-
-				})))
+				QuotaRejection = QuotaErr
 
 			}
 
-		}()
-
-	case APIs.URITypeYTMusicArtist:
-
-		ArtistSongs, ArtistFetchErr := YouTube.MusicArtistIDToSongs(ID)
-
-		if ArtistFetchErr != nil || len(ArtistSongs) == 0 {
-
-			return nil, -1, errors.New("could not fetch artist songs")
-
-		}
-
-		SongFound = &ArtistSongs[0]
-
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-		// Add rest of artist songs in background
-
-		if len(ArtistSongs) > 1 {
-
-			go func() {
-
-				for _, Song := range ArtistSongs[1:] {
-
-					SongCopy := Song
-					G.Queue.Add(&SongCopy, Requestor)
-
-				}
-
-				Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
-
-					Title:Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-					Author:Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-					Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromArtist", G.Locale.Code(), len(ArtistSongs), Localizations.Pluralize("Song", len(ArtistSongs), G.Locale.Code()), ArtistSongs[0].Artists[0]),
-
-				})))
-
-			}()
-
-		}
-
-	// Spotify
-
-	case APIs.URITypeSPSong:
-
-		ResolvedSong, _, SpotifyFetchErr := Spotify.SpotifyIDToSong(ID)
-
-		if SpotifyFetchErr != nil {
-
-			return nil, -1, SpotifyFetchErr
+			continue
 
 		}
 
-		SongFound = &ResolvedSong
-
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-	case APIs.URITypeSPAlbum:
+		if EnqueueError != nil {
 
-		FirstSong, SpotifyAlbum, FirstSongError := Spotify.SpotifyAlbumToFirstSong(ID)
-
-		if FirstSongError != nil {
-
-			return nil, -1, FirstSongError
+			return nil, -1, EnqueueError
 
 		}
 
-		SongFound = &FirstSong
-
-		PosAdded = G.Queue.Add(&FirstSong, Requestor)
-
-		go func() {
-
-			// Add rest
-
-			AllOtherSongs, _, OtherFetchError := Spotify.SpotifyAlbumToAllSongs(SpotifyAlbum, true) // ignores first
+		SongFound = Song
+		PosAdded = Pos
+		Started = Interrupting && Pos == 0
 
-			if OtherFetchError != nil {
-
-				Utils.Logger.Error("Spotify Fetch", fmt.Sprintf("Error fetching rest of Spotify album songs: %s", OtherFetchError.Error()))
-				return
-
-			}
-
-			for _, Song := range AllOtherSongs {
+	}
 
-				G.Queue.Add(&Song, Requestor)
+	// Add the rest in the background
 
-			}
+	Remaining := Content.Songs[Index:]
 
-			Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+	if len(Remaining) > 0 || Content.More != nil || Skipped.Total() > 0 {
 
-				Title:       Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-				Author:      Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-				Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromAlbum", G.Locale.Code(), len(AllOtherSongs)+1, Localizations.Pluralize("Song", len(AllOtherSongs)+1, G.Locale.Code()), SpotifyAlbum.Name),
-			})))
+		go G.queueRemaining(Content, Remaining, Requestor, Position.After(PosAdded), Skipped, Failed)
 
-		}()
+	}
 
-	case APIs.URITypeSPPlaylist:
+	// Auto-plays if first in queue and not already playing
 
-		FirstSong, SpotifyPlaylist, FirstSongError := Spotify.SpotifyPlaylistToFirstSong(ID)
+	if PosAdded == 0 && !Started && G.Queue.State != StatePlaying {
 
-		if FirstSongError != nil {
+		go func() { // done as to not block
 
-			return nil, -1, FirstSongError
+			PlayError := G.Play(SongFound)
 
-		}
+			if PlayError != nil {
 
-		SongFound = &FirstSong
+				Utils.Logger.Error("Playback", fmt.Sprintf("Error playing song: %s", PlayError.Error()))
 
-		PosAdded = G.Queue.Add(&FirstSong, Requestor)
+				if errors.Is(PlayError, ErrStreamUnavailable) {
 
-		go func() {
+					Locale := G.Locale.Code()
 
-			// Add rest. same as album
+					_, ErrorSending := Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().
+						AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
 
-			AllOtherSongs, _, OtherFetchError := Spotify.SpotifyPlaylistToAllSongs(SpotifyPlaylist, true) // ignores first
+							Title:Localizations.Get("Embeds.Notifications.SongUnavailable.Title", Locale),
+							Author:Localizations.Get("Embeds.Categories.Error", Locale),
+							Description: Localizations.Get("Embeds.Notifications.SongUnavailable.DescriptionImmediate", Locale),
+							Color:Utils.ERROR,
 
-			if OtherFetchError != nil {
+						})))
 
-				Utils.Logger.Error("Spotify Fetch", fmt.Sprintf("Error fetching rest of Spotify playlist songs: %s", OtherFetchError.Error()))
-				return
+					if ErrorSending != nil {
 
-			}
+						Utils.Logger.Error("Command", fmt.Sprintf("Error sending unavailable message to guild %s: %s", G.ID.String(), ErrorSending.Error()))
 
-			for _, Song := range AllOtherSongs {
+					}
 
-				G.Queue.Add(&Song, Requestor)
+				}
 
 			}
 
-			Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().
-				AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
-
-					Title:Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-					Author:Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-					Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromPlaylist", G.Locale.Code(), len(AllOtherSongs)+1, Localizations.Pluralize("Song", len(AllOtherSongs)+1, G.Locale.Code()), SpotifyPlaylist.Name),
-
-				})))
-
 		}()
 
-	// Apple Music
-
-	case APIs.URITypeAMSong:
-
-		ResolvedSong, _, AppleMusicFetchErr := Apple.AppleMusicIDToSong(ID)
-
-		if AppleMusicFetchErr != nil {
-
-			return nil, -1, AppleMusicFetchErr
-
-		}
-
-		SongFound = &ResolvedSong
-
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-	case APIs.URITypeAMAlbum:
-
-		FirstSong, AppleMusicAlbum, FirstSongError := Apple.AppleMusicAlbumToFirstSong(ID)
-
-		if FirstSongError != nil {
-
-			return nil, -1, FirstSongError
-
-		}
-
-		SongFound = &FirstSong
-
-		PosAdded = G.Queue.Add(&FirstSong, Requestor)
-
-		go func() {
-
-			// Add rest
-
-			AllOtherSongs, _, OtherFetchError := Apple.AppleMusicAlbumToAllSongs(AppleMusicAlbum, true) // ignores first
-
-			if OtherFetchError != nil {
-
-				Utils.Logger.Error("Apple Music Fetch", fmt.Sprintf("Error fetching rest of Apple Music album songs: %s", OtherFetchError.Error()))
-				return
-
-			}
-
-			for _, Song := range AllOtherSongs {
-
-				G.Queue.Add(&Song, Requestor)
-
-			}
-
-			Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+	}
 
-				Title: Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-				Author: Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-				Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromAlbum", G.Locale.Code(), len(AllOtherSongs)+1, Localizations.Pluralize("Song", len(AllOtherSongs)+1, G.Locale.Code()), AppleMusicAlbum.Attributes.Name),
+	return SongFound, PosAdded, nil
 
-			})))
+}
 
-		}()
+// queueRemaining adds the rest of a collection after its first song and announces what was added and what the quota policy refused
+func (G *Guild) queueRemaining(Content URIContent, Remaining []Tidal.Song, Requestor string, Position InsertPosition, Skipped QuotaSkips, Failed int) {
 
-	case APIs.URITypeDirectMedia:
+	if Content.More != nil {
 
-		Adapted, AdaptErr := Tidal.SongFromDirectURL(ID)
+		Rest, RestFailed, FetchError := Content.More()
 
-		if AdaptErr != nil {
+		if FetchError != nil {
 
-			return nil, -1, AdaptErr
+			Utils.Logger.Error("Fetch", fmt.Sprintf("Error fetching the rest of %s: %s", Content.Name, FetchError.Error()))
+			return
 
 		}
 
-		SongFound = Adapted
-		PosAdded = G.Queue.Add(SongFound, Requestor)
-
-	case APIs.URITypeAMPlaylist:
+		Remaining = append(Remaining, Rest...)
+		Failed += RestFailed
 
-		FirstSong, AppleMusicPlaylist, FirstSongError := Apple.AppleMusicPlaylistToFirstSong(ID)
+	}
 
-		if FirstSongError != nil {
+	Added, RestSkipped := G.Queue.AddAllAt(Remaining, Requestor, Position)
 
-			return nil, -1, FirstSongError
+	for Reason, Count := range RestSkipped {
 
-		}
+		Skipped[Reason] += Count
 
-		SongFound = &FirstSong
+	}
 
-		PosAdded = G.Queue.Add(&FirstSong, Requestor)
+	Locale := G.Locale.Code()
 
-		go func() {
+	Kind := Content.Kind
+	Name := Content.Name
 
-			// Add rest
+	if Kind == "" {
 
-			AllOtherSongs, _, OtherFetchError := Apple.AppleMusicPlaylistToAllSongs(AppleMusicPlaylist, true) // ignores first
+		Kind = ContentPlaylist
 
-			if OtherFetchError != nil {
+	}
 
-				Utils.Logger.Error("Apple Music Fetch", fmt.Sprintf("Error fetching rest of Apple Music playlist songs: %s", OtherFetchError.Error()))
-				return
+	if Name == "" {
 
-			}
+		Name = Localizations.Get("Common.Playlist", Locale)
 
-			for _, Song := range AllOtherSongs {
+	}
 
-				G.Queue.Add(&Song, Requestor)
+	SuccessCount := Added + 1 // +1 for first song
 
-			}
+	// System mixes and plain links are only announced when songs were refused; failures below are always reported
 
-			Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().
-				AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+	if Content.Kind != "" || Skipped.Total() > 0 {
 
-					Title: Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", G.Locale.Code()),
-					Author: Localizations.Get("Embeds.Categories.Notifications", G.Locale.Code()),
-					Description: Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.FromPlaylist", G.Locale.Code(), len(AllOtherSongs)+1, Localizations.Pluralize("Song", len(AllOtherSongs)+1, G.Locale.Code()), AppleMusicPlaylist.Attributes.Name),
+		Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
 
-				})))
+			Title: Localizations.Get("Embeds.Notifications.AddedAdditionalSongs.Title", Locale),
+			Author: Localizations.Get("Embeds.Categories.Notifications", Locale),
+			Description: Skipped.AppendTo(Localizations.GetFormat("Embeds.Notifications.AddedAdditionalSongs.From"+Kind, Locale, SuccessCount, Localizations.Pluralize("Song", SuccessCount, Locale), Name), Locale),
 
-		}()
+		})))
 
 	}
 
-	// Auto-plays if first in queue and not already playing
-
-	if PosAdded == 0 && G.Queue.State != StatePlaying {
-
-		go func() { // done as to not block
+	// If some songs failed, we should send an additional notification
 
-			PlayError := G.Play(SongFound)
-
-			if PlayError != nil {
+	if Failed > 0 {
 
-				Utils.Logger.Error("Playback", fmt.Sprintf("Error playing song: %s", PlayError.Error()))
+		Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
 
-				if errors.Is(PlayError, ErrStreamUnavailable) {
+			Title: Localizations.Get("Embeds.Notifications.SomePlaylistSongsFailed.Title", Locale),
+			Author: Localizations.Get("Embeds.Categories.Notifications", Locale),
+			Description: Localizations.GetFormat("Embeds.Notifications.SomePlaylistSongsFailed.Description", Locale, Failed, Content.Total, Localizations.Pluralize("Song", Content.Total, Locale), Name),
 
-					Locale := G.Locale.Code()
+		})))
 
-					_, ErrorSending := Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, discord.NewMessageCreate().
-						AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+	}
 
-							Title:Localizations.Get("Embeds.Notifications.SongUnavailable.Title", Locale),
-							Author:Localizations.Get("Embeds.Categories.Error", Locale),
-							Description: Localizations.Get("Embeds.Notifications.SongUnavailable.DescriptionImmediate", Locale),
-							Color:Utils.ERROR,
+}
 
-						})))
+// QueueInfo describes the queue for a now playing message in Locale; Pos is the song's place in upcoming, 0 for the current song
+func (G *Guild) QueueInfo(Playing bool, Pos int, Locale string) Tidal.QueueInfo {
 
-					if ErrorSending != nil {
+	return Tidal.QueueInfo{
 
-						Utils.Logger.Error("Command", fmt.Sprintf("Error sending unavailable message to guild %s: %s", G.ID.String(), ErrorSending.Error()))
+		Playing: Playing,
 
-					}
+		GuildID: G.ID,
+		SongPosition: Pos,
 
-				}
+		TotalPrevious: len(G.Queue.Previous),
+		TotalUpcoming: len(G.Queue.Upcoming),
 
-			}
+		Locale: Locale,
 
-		}()
+		VoiceCommands: Receive.IsEnabled(),
 
 	}
 
-	return SongFound, PosAdded, nil
-
 }
 
 // Play starts playing the song using Tidal streaming
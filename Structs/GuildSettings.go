@@ -0,0 +1,347 @@
+package Structs
+
+import (
+	"Synthara-Redux/Audio"
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Receive"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+
+	DefaultInactivityTimeout = 1 * time.Hour
+	DefaultAutoplayInactivityTimeout = 3 * time.Hour
+
+	DefaultAloneGraceSeconds = 120
+
+	DefaultVoteSkipThreshold = 50
+
+	DefaultWakeSensitivity = 85
+
+	// The mixer's own defaults, so a guild without saved settings sounds the same as one that never loaded them
+
+	DefaultTalkOverDepth = Audio.DefaultTalkOverDepthPercent
+	DefaultTalkOverAttackMs = int(Audio.DefaultTalkOverAttack / time.Millisecond)
+	DefaultTalkOverReleaseMs = int(Audio.DefaultTalkOverRelease / time.Millisecond)
+
+)
+
+var ErrNoDatabase = errors.New("no database configured")
+
+var AllowedInactivityMinutes = []int{0, 15, 30, 60, 180, 360, 720, 1440} // 0 keeps the default (1 hour, 3 with AutoPlay)
+var AllowedAloneGraceSeconds = []int{30, 60, 120, 300, 600}
+var AllowedVoteSkipThresholds = []int{25, 34, 50, 67, 75, 100} // percent of listeners
+var AllowedWakeSensitivities = []int{30, 50, 70, 85, 95} // percent; higher wakes more easily but triggers falsely more often
+var AllowedTalkOverDepths = []int{30, 50, 70, 85} // percent of the music's level removed while people talk
+var AllowedTalkOverAttacksMs = []int{20, 50, 100, 250}
+var AllowedTalkOverReleasesMs = []int{300, 800, 1500, 3000}
+
+type InactivityPolicy struct {
+
+	TimeoutMinutes int `bson:"timeout_minutes"` // 0 uses the default timeout
+	AlwaysOn bool `bson:"always_on"` // 24/7 mode; never leaves on its own
+
+	LeaveWhenAlone bool `bson:"leave_when_alone"`
+	AloneGraceSeconds int `bson:"alone_grace_seconds"`
+
+}
+
+type VoteSkipPolicy struct {
+
+	Enabled bool `bson:"enabled"`
+	Threshold int `bson:"threshold"` // percent of non-bot listeners required to skip
+
+}
+
+type VoicePolicy struct {
+
+	WakePhrase string `bson:"wake_phrase"` // empty uses "Synthara"
+	WakeSensitivity int `bson:"wake_sensitivity"` // percent
+
+}
+
+type TalkOverPolicy struct {
+
+	Enabled bool `bson:"enabled"` // duck music whenever someone in the channel talks
+	Depth int `bson:"depth"` // percent
+	AttackMs int `bson:"attack_ms"`
+	ReleaseMs int `bson:"release_ms"`
+
+}
+
+type QueuePolicy struct {
+
+	FairShare bool `bson:"fair_share"` // interleave upcoming songs round-robin by requestor
+
+}
+
+type GuildSettings struct {
+
+	GuildID string `bson:"_id"`
+
+	Inactivity InactivityPolicy `bson:"inactivity"`
+	VoteSkip VoteSkipPolicy `bson:"vote_skip"`
+	Permissions PermissionPolicy `bson:"permissions"`
+	Queue QueuePolicy `bson:"queue"`
+	Quotas QuotaPolicy `bson:"quotas"`
+	Voice VoicePolicy `bson:"voice"`
+	TalkOver TalkOverPolicy `bson:"talk_over"`
+
+}
+
+// DefaultGuildSettings returns the settings used by guilds that have never changed them
+func DefaultGuildSettings(GuildID string) GuildSettings {
+
+	return GuildSettings{
+
+		GuildID: GuildID,
+
+		Inactivity: InactivityPolicy{
+
+			TimeoutMinutes: 0,
+			AlwaysOn: false,
+
+			LeaveWhenAlone: true,
+			AloneGraceSeconds: DefaultAloneGraceSeconds,
+
+		},
+
+		Quotas: QuotaPolicy{
+
+			Duplicates: DuplicatesAllow,
+			DuplicateWindow: DefaultDuplicateWindow,
+
+		},
+
+		Voice: VoicePolicy{
+
+			WakeSensitivity: DefaultWakeSensitivity,
+
+		},
+
+		TalkOver: TalkOverPolicy{
+
+			Depth: DefaultTalkOverDepth,
+			AttackMs: DefaultTalkOverAttackMs,
+			ReleaseMs: DefaultTalkOverReleaseMs,
+
+		},
+
+	}
+
+}
+
+// LoadGuildSettings reads a guild's settings from MongoDB, falling back to defaults when none are stored
+func LoadGuildSettings(GuildID string) GuildSettings {
+
+	Settings := DefaultGuildSettings(GuildID)
+
+	if Globals.Database == nil {
+
+		return Settings
+
+	}
+
+	Collection := Globals.Database.Collection("GuildSettings")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Error := Collection.FindOne(Context, bson.M{"_id": GuildID}).Decode(&Settings)
+
+	if Error != nil {
+
+		return DefaultGuildSettings(GuildID)
+
+	}
+
+	Settings.Inactivity.TimeoutMinutes = nearestAllowed(Settings.Inactivity.TimeoutMinutes, AllowedInactivityMinutes, 0)
+	Settings.Inactivity.AloneGraceSeconds = nearestAllowed(Settings.Inactivity.AloneGraceSeconds, AllowedAloneGraceSeconds, DefaultAloneGraceSeconds)
+	Settings.VoteSkip.Threshold = nearestAllowed(Settings.VoteSkip.Threshold, AllowedVoteSkipThresholds, DefaultVoteSkipThreshold)
+	Settings.Quotas = clampQuotaPolicy(Settings.Quotas)
+	Settings.Voice.WakeSensitivity = nearestAllowed(Settings.Voice.WakeSensitivity, AllowedWakeSensitivities, DefaultWakeSensitivity)
+	Settings.TalkOver = clampTalkOverPolicy(Settings.TalkOver)
+
+	return Settings
+
+}
+
+// saveGuildSetting upserts a single field of a guild's settings document
+func saveGuildSetting(GuildID string, Field string, Value interface{}) error {
+
+	if Globals.Database == nil {
+
+		return ErrNoDatabase
+
+	}
+
+	Collection := Globals.Database.Collection("GuildSettings")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	_, UpdateError := Collection.UpdateOne(Context, bson.M{"_id": GuildID}, bson.M{"$set": bson.M{Field: Value}}, options.Update().SetUpsert(true))
+
+	return UpdateError
+
+}
+
+// SaveQueuePolicy persists a guild's queue policy
+func SaveQueuePolicy(GuildID string, Policy QueuePolicy) error {
+
+	return saveGuildSetting(GuildID, "queue", Policy)
+
+}
+
+// SaveInactivityPolicy clamps and persists a guild's inactivity policy, returning the stored value
+func SaveInactivityPolicy(GuildID string, Policy InactivityPolicy) (InactivityPolicy, error) {
+
+	Policy.TimeoutMinutes = nearestAllowed(Policy.TimeoutMinutes, AllowedInactivityMinutes, 0)
+	Policy.AloneGraceSeconds = nearestAllowed(Policy.AloneGraceSeconds, AllowedAloneGraceSeconds, DefaultAloneGraceSeconds)
+
+	return Policy, saveGuildSetting(GuildID, "inactivity", Policy)
+
+}
+
+// SaveVoteSkipPolicy clamps and persists a guild's vote-skip policy, returning the stored value
+func SaveVoteSkipPolicy(GuildID string, Policy VoteSkipPolicy) (VoteSkipPolicy, error) {
+
+	Policy.Threshold = nearestAllowed(Policy.Threshold, AllowedVoteSkipThresholds, DefaultVoteSkipThreshold)
+
+	return Policy, saveGuildSetting(GuildID, "vote_skip", Policy)
+
+}
+
+// SaveVoicePolicy clamps and persists a guild's wake word settings, returning the stored value
+func SaveVoicePolicy(GuildID string, Policy VoicePolicy) (VoicePolicy, error) {
+
+	Policy.WakeSensitivity = nearestAllowed(Policy.WakeSensitivity, AllowedWakeSensitivities, DefaultWakeSensitivity)
+
+	return Policy, saveGuildSetting(GuildID, "voice", Policy)
+
+}
+
+// SaveTalkOverPolicy clamps and persists a guild's talk-over ducking, returning the stored value
+func SaveTalkOverPolicy(GuildID string, Policy TalkOverPolicy) (TalkOverPolicy, error) {
+
+	Policy = clampTalkOverPolicy(Policy)
+
+	return Policy, saveGuildSetting(GuildID, "talk_over", Policy)
+
+}
+
+func clampTalkOverPolicy(Policy TalkOverPolicy) TalkOverPolicy {
+
+	Policy.Depth = nearestAllowed(Policy.Depth, AllowedTalkOverDepths, DefaultTalkOverDepth)
+	Policy.AttackMs = nearestAllowed(Policy.AttackMs, AllowedTalkOverAttacksMs, DefaultTalkOverAttackMs)
+	Policy.ReleaseMs = nearestAllowed(Policy.ReleaseMs, AllowedTalkOverReleasesMs, DefaultTalkOverReleaseMs)
+
+	return Policy
+
+}
+
+// WakeWord returns the guild's wake phrase and detector sensitivity for voice commands
+func (G *Guild) WakeWord() Receive.WakeWord {
+
+	return Receive.WakeWord{
+
+		Phrase: G.Settings.Voice.WakePhrase,
+		Sensitivity: float64(G.Settings.Voice.WakeSensitivity) / 100,
+
+	}
+
+}
+
+// SetInactivityPolicy persists the inactivity policy and re-arms the inactivity timer with it
+func (G *Guild) SetInactivityPolicy(Policy InactivityPolicy) error {
+
+	Policy, UpdateError := SaveInactivityPolicy(G.ID.String(), Policy)
+
+	G.Settings.Inactivity = Policy
+
+	if G.VoiceConnection != nil {
+
+		if Policy.AlwaysOn {
+
+			G.StopInactivityTimer()
+
+		} else if G.Queue.State != StatePlaying || G.Features.Autoplay {
+
+			G.StartInactivityTimer()
+
+		}
+
+		go G.CheckAlone()
+
+	}
+
+	return UpdateError
+
+}
+
+// InactivityTimeout returns how long the guild may stay idle before disconnecting
+func (G *Guild) InactivityTimeout() time.Duration {
+
+	if G.Settings.Inactivity.TimeoutMinutes > 0 {
+
+		return time.Duration(G.Settings.Inactivity.TimeoutMinutes) * time.Minute
+
+	}
+
+	if G.Features.Autoplay {
+
+		return DefaultAutoplayInactivityTimeout
+
+	}
+
+	return DefaultInactivityTimeout
+
+}
+
+// AloneGracePeriod returns how long the bot waits in an empty channel before leaving
+func (G *Guild) AloneGracePeriod() time.Duration {
+
+	if G.Settings.Inactivity.AloneGraceSeconds <= 0 {
+
+		return DefaultAloneGraceSeconds * time.Second
+
+	}
+
+	return time.Duration(G.Settings.Inactivity.AloneGraceSeconds) * time.Second
+
+}
+
+// FormatPolicyDuration renders a timeout or grace period for notifications (e.g. "3 hours", "90 seconds")
+func FormatPolicyDuration(Duration time.Duration, Locale string) string {
+
+	switch {
+
+	case Duration == time.Hour:
+
+		return Localizations.Get("Common.OneHour", Locale)
+
+	case Duration == 3*time.Hour:
+
+		return Localizations.Get("Common.ThreeHours", Locale)
+
+	case Duration >= time.Hour && Duration%time.Hour == 0:
+
+		return Localizations.GetFormat("Common.HoursCount", Locale, int(Duration/time.Hour))
+
+	case Duration >= time.Minute && Duration%time.Minute == 0:
+
+		return Localizations.GetFormat("Common.MinutesCount", Locale, int(Duration/time.Minute))
+
+	default:
+
+		return Localizations.GetFormat("Common.SecondsCount", Locale, int(Duration/time.Second))
+
+	}
+
+}
@@ -0,0 +1,336 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals/Icons"
+	"Synthara-Redux/Globals/Localizations"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const (
+
+	OperationAdd     = "Add"
+	OperationRemove  = "Remove"
+	OperationMove    = "Move"
+	OperationClear   = "Clear"
+	OperationShuffle = "Shuffle"
+	OperationLoad    = "Load"
+	OperationReplay  = "Replay"
+
+	MaxQueueHistory = 25
+
+)
+
+// operationCapabilities maps operations to the capability needed to undo or redo them
+var operationCapabilities = map[string]string{
+
+	OperationRemove: CapabilityRemove,
+	OperationMove:   CapabilityMove,
+	OperationClear:  CapabilityClear,
+	OperationLoad:   CapabilitySaveLoad,
+	OperationAdd:    CapabilityRemove, // undoing an add removes songs; users may always undo adds of only their own songs
+
+}
+
+type queueSnapshot struct {
+
+	Previous []*Tidal.Song
+	Current  *Tidal.Song
+	Upcoming []*Tidal.Song
+
+}
+
+// QueueOperation is a recorded queue mutation with the state needed to invert it
+type QueueOperation struct {
+
+	ID   int // identifies the operation in Undo button custom IDs
+	Kind string
+
+	Before queueSnapshot
+	After  queueSnapshot
+
+}
+
+// QueueHistory is a capped undo/redo log of queue mutations
+type QueueHistory struct {
+
+	Undo []QueueOperation
+	Redo []QueueOperation
+
+	Mutex sync.Mutex
+
+	lastID int
+
+}
+
+// OperationCapability returns the capability needed to undo or redo an operation, or "" when anyone may
+func OperationCapability(Kind string) string {
+
+	return operationCapabilities[Kind]
+
+}
+
+func (Q *Queue) snapshot() queueSnapshot {
+
+	return queueSnapshot{
+
+		Previous: slices.Clone(Q.Previous),
+		Current:  Q.Current,
+		Upcoming: slices.Clone(Q.Upcoming),
+
+	}
+
+}
+
+// Track runs a mutation and records it as a single operation when it reports success; the mutation must use the unrecorded queue helpers (insertAt, addAllAt, reset, shuffleUpcoming, moveTo) so nothing is recorded twice
+func (Q *Queue) Track(Kind string, Mutate func() bool) bool {
+
+	Before := Q.snapshot()
+
+	Success := Mutate()
+
+	if Success {
+
+		Q.record(Kind, Before)
+
+	}
+
+	return Success
+
+}
+
+// record appends an operation to the undo log and drops the redo log
+func (Q *Queue) record(Kind string, Before queueSnapshot) {
+
+	Q.History.Mutex.Lock()
+	defer Q.History.Mutex.Unlock()
+
+	Q.History.lastID++
+
+	Q.History.Undo = append(Q.History.Undo, QueueOperation{ID: Q.History.lastID, Kind: Kind, Before: Before, After: Q.snapshot()})
+
+	if len(Q.History.Undo) > MaxQueueHistory {
+
+		Q.History.Undo = Q.History.Undo[len(Q.History.Undo)-MaxQueueHistory:]
+
+	}
+
+	Q.History.Redo = nil
+
+}
+
+// ClearHistory discards the undo and redo logs
+func (Q *Queue) ClearHistory() {
+
+	Q.History.Mutex.Lock()
+
+	Q.History.Undo = nil
+	Q.History.Redo = nil
+
+	Q.History.Mutex.Unlock()
+
+}
+
+// PeekHistory returns the operation the next undo (or redo) would invert
+func (Q *Queue) PeekHistory(Redo bool) (QueueOperation, bool) {
+
+	Q.History.Mutex.Lock()
+	defer Q.History.Mutex.Unlock()
+
+	Log := Q.History.Undo
+
+	if Redo {
+
+		Log = Q.History.Redo
+
+	}
+
+	if len(Log) == 0 {
+
+		return QueueOperation{}, false
+
+	}
+
+	return Log[len(Log)-1], true
+
+}
+
+// Undo reverts the most recent queue operation; returns false when there is nothing to undo
+func (G *Guild) Undo() (QueueOperation, bool) {
+
+	return G.walkHistory(false, 0)
+
+}
+
+// Redo re-applies the most recently undone queue operation; returns false when there is nothing to redo
+func (G *Guild) Redo() (QueueOperation, bool) {
+
+	return G.walkHistory(true, 0)
+
+}
+
+// walkHistory inverts the latest operation, or nothing when OperationID is set and no longer the latest
+func (G *Guild) walkHistory(Redo bool, OperationID int) (QueueOperation, bool) {
+
+	Q := &G.Queue
+
+	Q.History.Mutex.Lock()
+
+	From, To := &Q.History.Undo, &Q.History.Redo
+
+	if Redo {
+
+		From, To = To, From
+
+	}
+
+	if len(*From) == 0 || (OperationID != 0 && (*From)[len(*From)-1].ID != OperationID) {
+
+		Q.History.Mutex.Unlock()
+		return QueueOperation{}, false
+
+	}
+
+	Operation := (*From)[len(*From)-1]
+	*From = (*From)[:len(*From)-1]
+	*To = append(*To, Operation)
+
+	Q.History.Mutex.Unlock()
+
+	Target, Undone := Operation.Before, Operation.After
+
+	if Redo {
+
+		Target, Undone = Operation.After, Operation.Before
+
+	}
+
+	// Songs queued or played since the operation are kept; only what it changed is inverted
+
+	Upcoming := reconcileUpcoming(Q.Upcoming, Target.Upcoming, Undone.Upcoming)
+
+	if Target.Current != Undone.Current {
+
+		if Live := Q.Current; Live != nil && Live != Undone.Current && Live != Target.Current && !slices.Contains(Upcoming, Live) {
+
+			Upcoming = append(Upcoming, Live) // queued after the operation; keep it rather than drop it
+
+		}
+
+		G.replaceQueue(slices.Clone(Target.Previous), Target.Current, Upcoming)
+		return Operation, true
+
+	}
+
+	Q.Upcoming = Upcoming
+	Q.Functions.Updated(Q)
+
+	return Operation, true
+
+}
+
+// reconcileUpcoming rebuilds the target order from the songs still relevant: those still queued plus those the inverted operation removed; songs queued since are kept at the end
+func reconcileUpcoming(Live []*Tidal.Song, Target []*Tidal.Song, Undone []*Tidal.Song) []*Tidal.Song {
+
+	Result := make([]*Tidal.Song, 0, len(Target)+len(Live))
+
+	for _, Song := range Target {
+
+		if slices.Contains(Live, Song) || !slices.Contains(Undone, Song) {
+
+			Result = append(Result, Song)
+
+		}
+
+	}
+
+	for _, Song := range Live {
+
+		if !slices.Contains(Target, Song) && !slices.Contains(Undone, Song) {
+
+			Result = append(Result, Song)
+
+		}
+
+	}
+
+	return Result
+
+}
+
+// StepHistory undoes (or redoes) the latest operation on behalf of a user; when the user lacks the capability the operation needs, it is returned as Missing and nothing changes.
+// A non-zero OperationID only steps that operation: when it is no longer the latest, the latest is returned with Done false
+func (G *Guild) StepHistory(UserID snowflake.ID, Redo bool, OperationID int) (Operation QueueOperation, Missing string, Done bool) {
+
+	Next, Exists := G.Queue.PeekHistory(Redo)
+
+	if !Exists {
+
+		return QueueOperation{}, "", false
+
+	}
+
+	if OperationID != 0 && Next.ID != OperationID {
+
+		return Next, "", false
+
+	}
+
+	if Capability := OperationCapability(Next.Kind); Capability != "" && !G.Allowed(UserID, Capability) && !(Next.Kind == OperationAdd && G.addedOnlyBy(Next, UserID)) {
+
+		return Next, Capability, false
+
+	}
+
+	Operation, Done = G.walkHistory(Redo, Next.ID)
+
+	return Operation, "", Done
+
+}
+
+// addedOnlyBy reports whether every song an add operation queued was requested by the user
+func (G *Guild) addedOnlyBy(Operation QueueOperation, UserID snowflake.ID) bool {
+
+	Before := append([]*Tidal.Song{Operation.Before.Current}, Operation.Before.Upcoming...)
+
+	for _, Song := range append([]*Tidal.Song{Operation.After.Current}, Operation.After.Upcoming...) {
+
+		if Song != nil && !slices.Contains(Before, Song) && !G.IsRequestor(Song, UserID, "") {
+
+			return false
+
+		}
+
+	}
+
+	return true
+
+}
+
+// UndoButton returns the button attached to confirmation embeds of undoable queue changes; it undoes only the latest operation at the time it is sent
+func (Q *Queue) UndoButton(Locale string) discord.ButtonComponent {
+
+	Q.History.Mutex.Lock()
+
+	OperationID := 0
+
+	if len(Q.History.Undo) > 0 {
+
+		OperationID = Q.History.Undo[len(Q.History.Undo)-1].ID
+
+	}
+
+	Q.History.Mutex.Unlock()
+
+	return discord.NewButton(discord.ButtonStyleSecondary, Localizations.Get("Common.Undo", Locale), fmt.Sprintf("Undo:%d", OperationID), "", 0).WithEmoji(discord.ComponentEmoji{
+
+		ID: snowflake.MustParse(Icons.GetID(Icons.PlaySkipBack)),
+
+	})
+
+}
@@ -0,0 +1,155 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// testGuild registers a guild whose queue callbacks do nothing, so queue operations run without Discord
+func testGuild(T *testing.T, ID snowflake.ID) *Guild {
+
+	T.Helper()
+
+	G := NewGuild(ID, discord.LocaleEnglishUS)
+
+	G.Queue.Functions = QueueFunctions{
+
+		State:   func(*Queue, int) {},
+		Updated: func(*Queue) {},
+
+	}
+
+	T.Cleanup(func() {
+
+		GuildStoreMutex.Lock()
+		delete(GuildStore, ID)
+		GuildStoreMutex.Unlock()
+
+	})
+
+	return G
+
+}
+
+// testSongs builds songs with direct links, so playback started by the queue fails fast instead of reaching for a stream
+func testSongs(Titles ...string) []*Tidal.Song {
+
+	Songs := make([]*Tidal.Song, len(Titles))
+
+	for i, Title := range Titles {
+
+		Songs[i] = &Tidal.Song{TidalID: int64(i + 1), Title: Title}
+		Songs[i].Internal.DirectURL = fmt.Sprintf("https://example.invalid/%d.mp3", i+1)
+
+	}
+
+	return Songs
+
+}
+
+func titles(Songs []*Tidal.Song) []string {
+
+	Names := make([]string, len(Songs))
+
+	for i, Song := range Songs {
+
+		Names[i] = Song.Title
+
+	}
+
+	return Names
+
+}
+
+func TestAdvanceWithShuffleRecordsNothing(T *testing.T) {
+
+	G := testGuild(T, 9001)
+	G.Features.Shuffle = true
+
+	Songs := testSongs("Current", "A", "B", "C", "D", "E")
+
+	G.Queue.Current = Songs[0]
+	G.Queue.Upcoming = slices.Clone(Songs[1:])
+
+	if !G.Queue.moveTo(1, false) {
+
+		T.Fatal("moveTo(1) failed")
+
+	}
+
+	if Operation, Exists := G.Queue.PeekHistory(false); Exists {
+
+		T.Fatalf("advancing with shuffle on recorded a %s operation", Operation.Kind)
+
+	}
+
+}
+
+func TestUndoPlayNowWithShuffle(T *testing.T) {
+
+	G := testGuild(T, 9002)
+	G.Features.Shuffle = true
+
+	Songs := testSongs("Current", "A", "B", "C", "D", "E", "Now")
+	Now := Songs[6]
+
+	G.Queue.Current = Songs[0]
+	G.Queue.Upcoming = slices.Clone(Songs[1:6])
+
+	// The tracked part of AddAt; starting playback afterwards needs Discord
+
+	G.Queue.Track(OperationAdd, func() bool {
+
+		G.Queue.insertAt(Now, "1", InsertPosition{Mode: InsertNow})
+		return true
+
+	})
+
+	if G.Queue.Current != Now {
+
+		T.Fatalf("current is %q after playing now, want %q", G.Queue.Current.Title, Now.Title)
+
+	}
+
+	G.Queue.History.Mutex.Lock()
+	Recorded := len(G.Queue.History.Undo)
+	G.Queue.History.Mutex.Unlock()
+
+	if Recorded != 1 {
+
+		T.Fatalf("playing now with shuffle on recorded %d operations, want 1", Recorded)
+
+	}
+
+	Operation, Undone := G.Undo()
+
+	if !Undone || Operation.Kind != OperationAdd {
+
+		T.Fatalf("undo returned %q (%t), want the add", Operation.Kind, Undone)
+
+	}
+
+	if G.Queue.Current != Songs[0] {
+
+		T.Errorf("current is %q after undo, want %q", G.Queue.Current.Title, Songs[0].Title)
+
+	}
+
+	if Got, Want := titles(G.Queue.Upcoming), titles(Songs[1:6]); !slices.Equal(Got, Want) {
+
+		T.Errorf("upcoming is %v after undo, want the order before the add %v", Got, Want)
+
+	}
+
+	if len(G.Queue.Previous) != 0 {
+
+		T.Errorf("previous is %v after undo, want it empty", titles(G.Queue.Previous))
+
+	}
+
+}
@@ -0,0 +1,88 @@
+package Structs
+
+import (
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Utils"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes creates the query and retention indexes of the persisted logs; run once after connecting to MongoDB
+func EnsureIndexes() {
+
+	ensureIndexes("PlayHistory", "started_at", PlayLogRetention(),
+
+		bson.D{{Key: "guild_id", Value: 1}, {Key: "started_at", Value: -1}},
+		bson.D{{Key: "guild_id", Value: 1}, {Key: "requestor_id", Value: 1}, {Key: "started_at", Value: -1}},
+
+	)
+
+	ensureIndexes("VoiceCommandLog", "at", VoiceLogRetention(),
+
+		bson.D{{Key: "guild_id", Value: 1}, {Key: "at", Value: -1}},
+		bson.D{{Key: "guild_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "at", Value: -1}},
+		bson.D{{Key: "user_id", Value: 1}},
+
+	)
+
+}
+
+// ensureIndexes creates a collection's query indexes and a TTL index that expires documents Retention after TimeField; a changed retention is applied to the existing TTL index
+func ensureIndexes(Name string, TimeField string, Retention time.Duration, Keys ...bson.D) {
+
+	Collection := Globals.Database.Collection(Name)
+
+	Context, Cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer Cancel()
+
+	Models := []mongo.IndexModel{}
+
+	for _, Key := range Keys {
+
+		Models = append(Models, mongo.IndexModel{Keys: Key})
+
+	}
+
+	if _, IndexError := Collection.Indexes().CreateMany(Context, Models); IndexError != nil {
+
+		Utils.Logger.Warn("Database", fmt.Sprintf("Failed to create indexes on %s: %s", Name, IndexError.Error()))
+
+	}
+
+	TTLName := TimeField + "_ttl"
+	Seconds := int32(Retention.Seconds())
+
+	_, TTLError := Collection.Indexes().CreateOne(Context, mongo.IndexModel{
+
+		Keys: bson.D{{Key: TimeField, Value: 1}},
+		Options: options.Index().SetName(TTLName).SetExpireAfterSeconds(Seconds),
+
+	})
+
+	if TTLError == nil {
+
+		return
+
+	}
+
+	// The TTL index already exists with another retention; update it in place
+
+	UpdateError := Globals.Database.RunCommand(Context, bson.D{
+
+		{Key: "collMod", Value: Name},
+		{Key: "index", Value: bson.M{"name": TTLName, "expireAfterSeconds": Seconds}},
+
+	}).Err()
+
+	if UpdateError != nil {
+
+		Utils.Logger.Warn("Database", fmt.Sprintf("Failed to set retention on %s: %s (%s)", Name, UpdateError.Error(), TTLError.Error()))
+
+	}
+
+}
@@ -0,0 +1,180 @@
+package Structs
+
+import (
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Globals/Icons"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Utils"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// CheckAlone pauses playback and arms the alone timer when no listeners remain, and undoes both once someone returns
+func (G *Guild) CheckAlone() {
+
+	if G.VoiceConnection == nil || G.Internal.Disconnecting {
+
+		return
+
+	}
+
+	Listeners := Utils.CountListeners(G.ID, G.Channels.Voice)
+
+	G.Internal.InactivityMutex.Lock()
+
+	if Listeners > 0 {
+
+		if G.Internal.AloneTimer != nil {
+
+			Utils.Logger.Info("Guild", fmt.Sprintf("Listener returned to guild %s, cancelling alone timer", G.ID.String()))
+
+			G.Internal.AloneTimer.Stop()
+			G.Internal.AloneTimer = nil
+
+		}
+
+		Resume := G.Internal.AutoPaused
+		G.Internal.AutoPaused = false
+
+		G.Internal.InactivityMutex.Unlock()
+
+		if Resume && G.Queue.State == StatePaused && G.Queue.Current != nil {
+
+			G.Queue.SetState(StatePlaying)
+			G.sendAloneNotification("Embeds.Notifications.AloneResumed.Title", Localizations.Get("Embeds.Notifications.AloneResumed.Description", G.Locale.Code()), false)
+
+		}
+
+		return
+
+	}
+
+	if G.Internal.AloneTimer != nil {
+
+		G.Internal.InactivityMutex.Unlock()
+		return // Already counting down
+
+	}
+
+	Paused := false
+
+	if G.Queue.State == StatePlaying {
+
+		G.Internal.AutoPaused = true
+		Paused = true
+
+	}
+
+	Leaves := G.Settings.Inactivity.LeaveWhenAlone && !G.Settings.Inactivity.AlwaysOn
+	Grace := G.AloneGracePeriod()
+
+	if Leaves {
+
+		Utils.Logger.Info("Guild", fmt.Sprintf("Guild %s voice channel is empty, leaving in %s", G.ID.String(), Grace.String()))
+
+		G.Internal.AloneTimer = time.AfterFunc(Grace, G.leaveWhenAlone)
+
+	}
+
+	G.Internal.InactivityMutex.Unlock()
+
+	if Paused {
+
+		G.Queue.SetState(StatePaused)
+
+		Locale := G.Locale.Code()
+		Description := Localizations.Get("Embeds.Notifications.AlonePaused.DescriptionStay", Locale)
+
+		if Leaves {
+
+			Description = Localizations.GetFormat("Embeds.Notifications.AlonePaused.DescriptionLeave", Locale, FormatPolicyDuration(Grace, Locale))
+
+		}
+
+		G.sendAloneNotification("Embeds.Notifications.AlonePaused.Title", Description, false)
+
+	}
+
+}
+
+// StopAloneTimer stops and clears the alone timer
+func (G *Guild) StopAloneTimer() {
+
+	G.Internal.InactivityMutex.Lock()
+	defer G.Internal.InactivityMutex.Unlock()
+
+	if G.Internal.AloneTimer != nil {
+
+		G.Internal.AloneTimer.Stop()
+		G.Internal.AloneTimer = nil
+
+	}
+
+	G.Internal.AutoPaused = false
+
+}
+
+// leaveWhenAlone disconnects once the grace period ends, provided the channel is still empty
+func (G *Guild) leaveWhenAlone() {
+
+	G.Internal.InactivityMutex.Lock()
+	G.Internal.AloneTimer = nil
+	G.Internal.InactivityMutex.Unlock()
+
+	if G.Settings.Inactivity.AlwaysOn || !G.Settings.Inactivity.LeaveWhenAlone {
+
+		return
+
+	}
+
+	if Utils.CountListeners(G.ID, G.Channels.Voice) > 0 {
+
+		return
+
+	}
+
+	Utils.Logger.Info("Guild", fmt.Sprintf("No listeners remained in guild %s, disconnecting...", G.ID.String()))
+
+	G.sendAloneNotification("Embeds.Notifications.AloneDisconnect.Title", Localizations.Get("Embeds.Notifications.AloneDisconnect.Description", G.Locale.Code()), true)
+
+	G.Disconnect(true)
+
+}
+
+func (G *Guild) sendAloneNotification(TitleKey string, Description string, Reconnect bool) {
+
+	Locale := G.Locale.Code()
+
+	Message := discord.NewMessageCreate().
+		AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get(TitleKey, Locale),
+			Author:      Localizations.Get("Embeds.Categories.Notifications", Locale),
+			Description: Description,
+
+		}))
+
+	if Reconnect {
+
+		ReconnectButton := discord.NewButton(discord.ButtonStylePrimary, Localizations.Get("Buttons.Reconnect", Locale), "Reconnect", "", 0).WithEmoji(discord.ComponentEmoji{
+
+			ID: snowflake.MustParse(Icons.GetID(Icons.Call)),
+
+		})
+
+		Message = Message.AddActionRow(ReconnectButton)
+
+	}
+
+	_, ErrorSending := Globals.DiscordClient.Rest.CreateMessage(G.Channels.Text, Message)
+
+	if ErrorSending != nil {
+
+		Utils.Logger.Error("Guild", fmt.Sprintf("Error sending alone notification to guild %s: %s", G.ID.String(), ErrorSending.Error()))
+
+	}
+
+}
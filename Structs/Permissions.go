@@ -0,0 +1,141 @@
+package Structs
+
+import (
+	"Synthara-Redux/Globals"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const (
+
+	CapabilitySkip     = "skip"
+	CapabilityRemove   = "remove" // removing songs requested by someone else
+	CapabilityClear    = "clear"
+	CapabilityMove     = "move"
+	CapabilityVolume   = "volume"
+	CapabilityEffects  = "effects"
+	CapabilitySaveLoad = "save_load"
+	CapabilityLock     = "lock"
+
+)
+
+var Capabilities = []string{CapabilitySkip, CapabilityRemove, CapabilityClear, CapabilityMove, CapabilityVolume, CapabilityEffects, CapabilitySaveLoad, CapabilityLock}
+
+// PermissionPolicy maps Discord roles to capabilities; capabilities without roles stay open to everyone
+type PermissionPolicy struct {
+
+	DJRoles []string `bson:"dj_roles"` // Roles granted every capability
+
+	Roles map[string][]string `bson:"roles"` // Capability -> role IDs allowed to use it
+
+}
+
+// Restricted reports whether a capability is limited to specific roles
+func (P PermissionPolicy) Restricted(Capability string) bool {
+
+	return len(P.Roles[Capability]) > 0
+
+}
+
+// Privileged reports whether a member holds a capability explicitly: Manage Server, a DJ role, or a role mapped to it
+func (P PermissionPolicy) Privileged(GuildID snowflake.ID, UserID snowflake.ID, Capability string) bool {
+
+	Member, Exists := lookupMember(GuildID, UserID)
+
+	if !Exists {
+
+		return false
+
+	}
+
+	if Globals.DiscordClient.Caches.MemberPermissions(Member).Has(discord.PermissionManageGuild) {
+
+		return true
+
+	}
+
+	for _, RoleID := range Member.RoleIDs {
+
+		if slices.Contains(P.DJRoles, RoleID.String()) || slices.Contains(P.Roles[Capability], RoleID.String()) {
+
+			return true
+
+		}
+
+	}
+
+	return false
+
+}
+
+// Allowed reports whether a member may use a capability under the policy
+func (P PermissionPolicy) Allowed(GuildID snowflake.ID, UserID snowflake.ID, Capability string) bool {
+
+	return !P.Restricted(Capability) || P.Privileged(GuildID, UserID, Capability)
+
+}
+
+// IsDJ reports whether a member has a DJ role or Manage Server
+func (P PermissionPolicy) IsDJ(GuildID snowflake.ID, UserID snowflake.ID) bool {
+
+	return P.Privileged(GuildID, UserID, "")
+
+}
+
+// Allowed reports whether a member may use a capability in this guild
+func (G *Guild) Allowed(UserID snowflake.ID, Capability string) bool {
+
+	return G.Settings.Permissions.Allowed(G.ID, UserID, Capability)
+
+}
+
+// SetPermissionPolicy persists the permission policy and applies it to the active session
+func (G *Guild) SetPermissionPolicy(Policy PermissionPolicy) error {
+
+	G.Settings.Permissions = Policy
+
+	return SavePermissionPolicy(G.ID.String(), Policy)
+
+}
+
+// SavePermissionPolicy persists a guild's permission policy
+func SavePermissionPolicy(GuildID string, Policy PermissionPolicy) error {
+
+	return saveGuildSetting(GuildID, "permissions", Policy)
+
+}
+
+// GuildSettingsFor returns the live settings for an active session, or the stored settings otherwise
+func GuildSettingsFor(GuildID snowflake.ID) GuildSettings {
+
+	if Guild := GetGuild(GuildID, false); Guild != nil {
+
+		return Guild.Settings
+
+	}
+
+	return LoadGuildSettings(GuildID.String())
+
+}
+
+func lookupMember(GuildID snowflake.ID, UserID snowflake.ID) (discord.Member, bool) {
+
+	if Member, Exists := Globals.DiscordClient.Caches.Member(GuildID, UserID); Exists {
+
+		return Member, true
+
+	}
+
+	Member, ErrorFetching := Globals.DiscordClient.Rest.GetMember(GuildID, UserID)
+
+	if ErrorFetching != nil || Member == nil {
+
+		return discord.Member{}, false
+
+	}
+
+	return *Member, true
+
+}
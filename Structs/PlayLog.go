@@ -0,0 +1,297 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Utils"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+
+	MaxPreviousSongs = 100 // in-memory history; the full log is persisted
+
+	PlayLogPageSize = 10
+
+	DefaultPlayLogRetentionDays = 400 // a full year for Wrapped, plus a month to see it in January
+
+)
+
+// PlayLogEntry is one persisted play of a song in a guild
+type PlayLogEntry struct {
+
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	GuildID string             `bson:"guild_id"`
+
+	Song *Tidal.Song `bson:"song"`
+
+	Requestor   string `bson:"requestor"`
+	RequestorID string `bson:"requestor_id,omitempty"` // set when the requestor is a Discord user
+
+	StartedAt     time.Time `bson:"started_at"`
+	PlayedSeconds int       `bson:"played_seconds"`
+	Skipped       bool      `bson:"skipped"`
+
+}
+
+// PlayLogQuery filters the play log; zero values match everything
+type PlayLogQuery struct {
+
+	UserID string
+	Day    time.Time // UTC midnight of the day to show
+	Text   string    // matched against title, artists and album
+
+}
+
+// activePlay is the song currently being logged
+type activePlay struct {
+
+	Song    *Tidal.Song
+	Started time.Time
+
+	Mutex sync.Mutex
+
+}
+
+// PlayLogRetention is how long plays are kept, from PLAY_HISTORY_RETENTION_DAYS (400 days by default)
+func PlayLogRetention() time.Duration {
+
+	Days, ErrorParsing := strconv.Atoi(strings.TrimSpace(os.Getenv("PLAY_HISTORY_RETENTION_DAYS")))
+
+	if ErrorParsing != nil || Days <= 0 {
+
+		Days = DefaultPlayLogRetentionDays
+
+	}
+
+	return time.Duration(Days) * 24 * time.Hour
+
+}
+
+// RequestorUserID extracts the Discord user behind a requestor (a mention or user ID)
+func RequestorUserID(Requestor string) (snowflake.ID, bool) {
+
+	UserID, ErrorParsing := snowflake.Parse(strings.TrimSuffix(strings.TrimPrefix(Requestor, "<@"), ">"))
+
+	return UserID, ErrorParsing == nil
+
+}
+
+// beginPlay starts logging a song; a song still being logged was interrupted and is logged as skipped
+func (Q *Queue) beginPlay(Song *Tidal.Song) {
+
+	Q.endPlay(true)
+
+	Q.playing.Mutex.Lock()
+
+	Q.playing.Song = Song
+	Q.playing.Started = time.Now()
+
+	Q.playing.Mutex.Unlock()
+
+}
+
+// discardPlay stops logging a song that never started playing
+func (Q *Queue) discardPlay() {
+
+	Q.playing.Mutex.Lock()
+	Q.playing.Song = nil
+	Q.playing.Mutex.Unlock()
+
+}
+
+// endPlay persists the song being logged, if any, with how long it played
+func (Q *Queue) endPlay(Skipped bool) {
+
+	Q.playing.Mutex.Lock()
+
+	Song, Started := Q.playing.Song, Q.playing.Started
+	Q.playing.Song = nil
+
+	Q.playing.Mutex.Unlock()
+
+	if Song == nil {
+
+		return
+
+	}
+
+	Played := int(time.Since(Started).Seconds())
+
+	if Q.PlaybackSession != nil && Q.PlaybackSession.Streamer != nil {
+
+		Played = int(Q.PlaybackSession.Streamer.Progress / 1000) // excludes time spent paused
+
+	}
+
+	if Song.Duration.Seconds > 0 {
+
+		Played = min(Played, Song.Duration.Seconds)
+
+		if !Skipped {
+
+			Played = Song.Duration.Seconds
+
+		}
+
+	}
+
+	Entry := PlayLogEntry{
+
+		GuildID: Q.ParentID.String(),
+		Song:    CloneSong(Song),
+
+		Requestor: Song.Internal.Requestor,
+
+		StartedAt:     Started.UTC(),
+		PlayedSeconds: Played,
+		Skipped:       Skipped,
+
+	}
+
+	if UserID, IsUser := RequestorUserID(Song.Internal.Requestor); IsUser {
+
+		Entry.RequestorID = UserID.String()
+
+	}
+
+	go func() {
+
+		if ErrorSaving := SavePlayLogEntry(Entry); ErrorSaving != nil {
+
+			Utils.Logger.Error("History", fmt.Sprintf("Error saving play log entry for guild %s: %s", Entry.GuildID, ErrorSaving.Error()))
+
+		}
+
+	}()
+
+}
+
+// trimPrevious caps the in-memory history at MaxPreviousSongs
+func (Q *Queue) trimPrevious() {
+
+	if len(Q.Previous) > MaxPreviousSongs {
+
+		Q.Previous = Q.Previous[len(Q.Previous)-MaxPreviousSongs:]
+
+	}
+
+}
+
+// SavePlayLogEntry persists a play
+func SavePlayLogEntry(Entry PlayLogEntry) error {
+
+	Collection := Globals.Database.Collection("PlayHistory")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	_, InsertError := Collection.InsertOne(Context, Entry)
+
+	return InsertError
+
+}
+
+func (P PlayLogQuery) filter(GuildID string) bson.M {
+
+	Filter := bson.M{"guild_id": GuildID}
+
+	if P.UserID != "" {
+
+		Filter["requestor_id"] = P.UserID
+
+	}
+
+	if !P.Day.IsZero() {
+
+		Filter["started_at"] = bson.M{"$gte": P.Day, "$lt": P.Day.AddDate(0, 0, 1)}
+
+	}
+
+	if Text := strings.TrimSpace(P.Text); Text != "" {
+
+		Pattern := primitive.Regex{Pattern: regexp.QuoteMeta(Text), Options: "i"}
+
+		Filter["$or"] = bson.A{bson.M{"song.title": Pattern}, bson.M{"song.artists": Pattern}, bson.M{"song.album": Pattern}}
+
+	}
+
+	return Filter
+
+}
+
+// SearchPlayLog returns one page of a guild's play log, newest first, and the total number of matching plays
+func SearchPlayLog(GuildID string, Query PlayLogQuery, Page int) ([]PlayLogEntry, int, error) {
+
+	Collection := Globals.Database.Collection("PlayHistory")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Filter := Query.filter(GuildID)
+
+	Total, CountError := Collection.CountDocuments(Context, Filter)
+
+	if CountError != nil {
+
+		return nil, 0, CountError
+
+	}
+
+	Options := options.Find().
+		SetSort(bson.D{{Key: "started_at", Value: -1}}).
+		SetSkip(int64(max(Page, 0) * PlayLogPageSize)).
+		SetLimit(PlayLogPageSize)
+
+	Cursor, FindError := Collection.Find(Context, Filter, Options)
+
+	if FindError != nil {
+
+		return nil, 0, FindError
+
+	}
+
+	Entries := []PlayLogEntry{}
+
+	if DecodeError := Cursor.All(Context, &Entries); DecodeError != nil {
+
+		return nil, 0, DecodeError
+
+	}
+
+	return Entries, int(Total), nil
+
+}
+
+// ParsePlayLogDay parses a YYYY-MM-DD date (or "today"/"yesterday") as a UTC day
+func ParsePlayLogDay(Input string) (time.Time, error) {
+
+	Today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	switch strings.ToLower(strings.TrimSpace(Input)) {
+
+	case "today":
+
+		return Today, nil
+
+	case "yesterday":
+
+		return Today.AddDate(0, 0, -1), nil
+
+	}
+
+	return time.Parse("2006-01-02", strings.TrimSpace(Input))
+
+}
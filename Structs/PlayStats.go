@@ -0,0 +1,362 @@
+package Structs
+
+import (
+	"Synthara-Redux/Globals"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+
+	StatsPeriodWeek  = "week"
+	StatsPeriodMonth = "month"
+	StatsPeriodYear  = "year"
+	StatsPeriodAll   = "all"
+
+	PlayStatsTopCount = 5
+	PlayStatsCacheTTL = 5 * time.Minute
+
+)
+
+var StatsPeriods = []string{StatsPeriodWeek, StatsPeriodMonth, StatsPeriodYear, StatsPeriodAll}
+
+// PlayStatsQuery selects the plays to aggregate; a zero Since or Until leaves that end open
+type PlayStatsQuery struct {
+
+	GuildID string
+	UserID  string
+
+	Since time.Time
+	Until time.Time
+
+}
+
+// PlayStatsEntry is one row of a top list
+type PlayStatsEntry struct {
+
+	ID     string `json:"id" bson:"_id"`
+	Name   string `json:"name" bson:"name"`
+	Detail string `json:"detail,omitempty" bson:"detail"` // e.g. the artist of a song
+	Cover  string `json:"cover,omitempty" bson:"cover"`
+
+	Plays   int `json:"plays" bson:"plays"`
+	Seconds int `json:"seconds" bson:"seconds"`
+
+}
+
+// PlayStats aggregates a guild's (or one requestor's) play log
+type PlayStats struct {
+
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+
+	Plays            int `json:"plays"`
+	Skips            int `json:"skips"`
+	UniqueSongs      int `json:"unique_songs"`
+	ListeningSeconds int `json:"listening_seconds"`
+
+	TopSongs      []PlayStatsEntry `json:"top_songs"`
+	TopArtists    []PlayStatsEntry `json:"top_artists"`
+	TopAlbums     []PlayStatsEntry `json:"top_albums"`
+	TopRequestors []PlayStatsEntry `json:"top_requestors"`
+
+	BusiestHours [24]int `json:"busiest_hours"` // plays started in each UTC hour
+
+}
+
+// PeriodStart returns when a stats period begins, or the zero time for all time
+func PeriodStart(Period string) (time.Time, error) {
+
+	Now := time.Now().UTC().Truncate(time.Hour) // keeps cache keys stable within the hour
+
+	switch strings.ToLower(strings.TrimSpace(Period)) {
+
+	case StatsPeriodWeek:
+
+		return Now.AddDate(0, 0, -7), nil
+
+	case StatsPeriodMonth, "":
+
+		return Now.AddDate(0, -1, 0), nil
+
+	case StatsPeriodYear:
+
+		return Now.AddDate(-1, 0, 0), nil
+
+	case StatsPeriodAll:
+
+		return time.Time{}, nil
+
+	}
+
+	return time.Time{}, fmt.Errorf("unknown stats period %q", Period)
+
+}
+
+// WrappedQuery covers a calendar year of a guild's plays
+func WrappedQuery(GuildID string, Year int) PlayStatsQuery {
+
+	Since := time.Date(Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	return PlayStatsQuery{GuildID: GuildID, Since: Since, Until: Since.AddDate(1, 0, 0)}
+
+}
+
+func (P PlayStatsQuery) filter() bson.M {
+
+	Filter := bson.M{"guild_id": P.GuildID}
+
+	if P.UserID != "" {
+
+		Filter["requestor_id"] = P.UserID
+
+	}
+
+	Range := bson.M{}
+
+	if !P.Since.IsZero() {
+
+		Range["$gte"] = P.Since
+
+	}
+
+	if !P.Until.IsZero() {
+
+		Range["$lt"] = P.Until
+
+	}
+
+	if len(Range) > 0 {
+
+		Filter["started_at"] = Range
+
+	}
+
+	return Filter
+
+}
+
+// topStages groups plays by Key (as a string) and keeps the most played groups
+func topStages(Key any, Name any, Detail any) bson.A {
+
+	return bson.A{
+
+		bson.M{"$group": bson.M{
+
+			"_id":     bson.M{"$toString": Key},
+			"name":    bson.M{"$first": Name},
+			"detail":  bson.M{"$first": Detail},
+			"cover":   bson.M{"$first": "$song.cover"},
+			"plays":   bson.M{"$sum": 1},
+			"seconds": bson.M{"$sum": "$played_seconds"},
+
+		}},
+
+		bson.M{"$sort": bson.D{{Key: "plays", Value: -1}, {Key: "seconds", Value: -1}}},
+		bson.M{"$limit": PlayStatsTopCount},
+
+	}
+
+}
+
+type playStatsFacets struct {
+
+	Totals []struct {
+
+		Plays   int `bson:"plays"`
+		Skips   int `bson:"skips"`
+		Seconds int `bson:"seconds"`
+
+	} `bson:"totals"`
+
+	Unique []struct {
+
+		Count int `bson:"count"`
+
+	} `bson:"unique"`
+
+	Songs      []PlayStatsEntry `bson:"songs"`
+	Artists    []PlayStatsEntry `bson:"artists"`
+	Albums     []PlayStatsEntry `bson:"albums"`
+	Requestors []PlayStatsEntry `bson:"requestors"`
+
+	Hours []struct {
+
+		Hour  int `bson:"_id"`
+		Plays int `bson:"plays"`
+
+	} `bson:"hours"`
+
+}
+
+// AggregatePlayStats computes top lists, totals and busiest hours from the persisted play log
+func AggregatePlayStats(Query PlayStatsQuery) (*PlayStats, error) {
+
+	Collection := Globals.Database.Collection("PlayHistory")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer Cancel()
+
+	Artists := append(bson.A{bson.M{"$unwind": "$song.artists"}}, topStages("$song.artists", "$song.artists", "")...)
+	Albums := append(bson.A{bson.M{"$match": bson.M{"song.album": bson.M{"$ne": ""}}}}, topStages("$song.albumid", "$song.album", bson.M{"$arrayElemAt": bson.A{"$song.artists", 0}})...)
+	Requestors := append(bson.A{bson.M{"$match": bson.M{"requestor_id": bson.M{"$exists": true, "$ne": ""}}}}, topStages("$requestor_id", "$requestor", "")...)
+
+	Pipeline := mongo.Pipeline{
+
+		{{Key: "$match", Value: Query.filter()}},
+
+		{{Key: "$facet", Value: bson.M{
+
+			"totals": bson.A{bson.M{"$group": bson.M{
+
+				"_id":     nil,
+				"plays":   bson.M{"$sum": 1},
+				"skips":   bson.M{"$sum": bson.M{"$cond": bson.A{"$skipped", 1, 0}}},
+				"seconds": bson.M{"$sum": "$played_seconds"},
+
+			}}},
+
+			"unique": bson.A{bson.M{"$group": bson.M{"_id": "$song.tidalid"}}, bson.M{"$count": "count"}},
+
+			"songs":      topStages("$song.tidalid", "$song.title", bson.M{"$arrayElemAt": bson.A{"$song.artists", 0}}),
+			"artists":    Artists,
+			"albums":     Albums,
+			"requestors": Requestors,
+
+			"hours": bson.A{bson.M{"$group": bson.M{"_id": bson.M{"$hour": "$started_at"}, "plays": bson.M{"$sum": 1}}}},
+
+		}}},
+
+	}
+
+	Cursor, AggregateError := Collection.Aggregate(Context, Pipeline)
+
+	if AggregateError != nil {
+
+		return nil, AggregateError
+
+	}
+
+	Results := []playStatsFacets{}
+
+	if DecodeError := Cursor.All(Context, &Results); DecodeError != nil {
+
+		return nil, DecodeError
+
+	}
+
+	Stats := &PlayStats{
+
+		Since: Query.Since,
+		Until: Query.Until,
+
+		TopSongs:      []PlayStatsEntry{},
+		TopArtists:    []PlayStatsEntry{},
+		TopAlbums:     []PlayStatsEntry{},
+		TopRequestors: []PlayStatsEntry{},
+
+	}
+
+	if Stats.Until.IsZero() {
+
+		Stats.Until = time.Now().UTC()
+
+	}
+
+	if len(Results) == 0 {
+
+		return Stats, nil
+
+	}
+
+	Facets := Results[0]
+
+	if len(Facets.Totals) > 0 {
+
+		Stats.Plays = Facets.Totals[0].Plays
+		Stats.Skips = Facets.Totals[0].Skips
+		Stats.ListeningSeconds = Facets.Totals[0].Seconds
+
+	}
+
+	if len(Facets.Unique) > 0 {
+
+		Stats.UniqueSongs = Facets.Unique[0].Count
+
+	}
+
+	Stats.TopSongs = append(Stats.TopSongs, Facets.Songs...)
+	Stats.TopArtists = append(Stats.TopArtists, Facets.Artists...)
+	Stats.TopAlbums = append(Stats.TopAlbums, Facets.Albums...)
+	Stats.TopRequestors = append(Stats.TopRequestors, Facets.Requestors...)
+
+	for _, Hour := range Facets.Hours {
+
+		if Hour.Hour >= 0 && Hour.Hour < 24 {
+
+			Stats.BusiestHours[Hour.Hour] = Hour.Plays
+
+		}
+
+	}
+
+	return Stats, nil
+
+}
+
+// CachedPlayStats aggregates a query at most once every PlayStatsCacheTTL
+func CachedPlayStats(Query PlayStatsQuery) (*PlayStats, error) {
+
+	Cache := Globals.GetOrCreateCache("PlayStats")
+	Key := fmt.Sprintf("%s:%s:%d:%d", Query.GuildID, Query.UserID, Query.Since.Unix(), Query.Until.Unix())
+
+	if Cached, Exists := Cache.Get(Key); Exists {
+
+		return Cached.(*PlayStats), nil
+
+	}
+
+	Stats, ErrorAggregating := AggregatePlayStats(Query)
+
+	if ErrorAggregating != nil {
+
+		return nil, ErrorAggregating
+
+	}
+
+	Cache.Set(Key, Stats, PlayStatsCacheTTL)
+
+	return Stats, nil
+
+}
+
+// PeakHours returns the busiest UTC hours, most plays first
+func (S *PlayStats) PeakHours(Count int) []int {
+
+	Hours := []int{}
+
+	for Hour, Plays := range S.BusiestHours {
+
+		if Plays > 0 {
+
+			Hours = append(Hours, Hour)
+
+		}
+
+	}
+
+	sort.SliceStable(Hours, func(i, j int) bool {
+
+		return S.BusiestHours[Hours[i]] > S.BusiestHours[Hours[j]]
+
+	})
+
+	return Hours[:min(Count, len(Hours))]
+
+}
@@ -0,0 +1,570 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals"
+	"context"
+	"crypto/rand"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+
+	MaxPlaylistsPerUser = 25
+	MaxPlaylistSongs    = 500
+
+	PlaylistPageSize     = 10
+	PlaylistShareCodeLen = 8
+
+)
+
+var (
+
+	ErrPlaylistLimit     = errors.New("playlist limit reached")
+	ErrPlaylistNotFound  = errors.New("playlist not found")
+	ErrPlaylistExists    = errors.New("a playlist with that name already exists")
+	ErrPlaylistFull      = errors.New("playlist is full")
+	ErrPlaylistEmpty     = errors.New("playlist has no songs")
+	ErrPlaylistPosition  = errors.New("playlist position out of range")
+	ErrShareCodeNotFound = errors.New("share code not found")
+
+)
+
+// shareCodeAlphabet leaves out characters that are easy to confuse when read aloud or typed
+const shareCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// UserPlaylist is a playlist owned by a user rather than a guild, so it can be played anywhere the owner is
+type UserPlaylist struct {
+
+	ID      string `bson:"_id" json:"id"`
+	OwnerID string `bson:"owner_id" json:"owner_id"`
+	Name    string `bson:"name" json:"name"`
+
+	Songs []Tidal.Song `bson:"songs" json:"songs"`
+
+	ShareCode string `bson:"share_code,omitempty" json:"share_code,omitempty"` // set while the playlist is shared
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+
+}
+
+func playlistsCollection() *mongo.Collection {
+
+	return Globals.Database.Collection("Playlists")
+
+}
+
+// GenerateShareCode returns a random code that other users can import a shared playlist with
+func GenerateShareCode() string {
+
+	Bytes := make([]byte, PlaylistShareCodeLen)
+	rand.Read(Bytes)
+
+	for Index, Byte := range Bytes {
+
+		Bytes[Index] = shareCodeAlphabet[int(Byte)%len(shareCodeAlphabet)]
+
+	}
+
+	return string(Bytes)
+
+}
+
+// ListPlaylists returns a user's playlists sorted by name
+func ListPlaylists(OwnerID string) ([]UserPlaylist, error) {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Cursor, Error := playlistsCollection().Find(Context, bson.M{"owner_id": OwnerID})
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	Playlists := []UserPlaylist{}
+
+	if Error := Cursor.All(Context, &Playlists); Error != nil {
+
+		return nil, Error
+
+	}
+
+	sort.Slice(Playlists, func(i, j int) bool {
+
+		return strings.ToLower(Playlists[i].Name) < strings.ToLower(Playlists[j].Name)
+
+	})
+
+	return Playlists, nil
+
+}
+
+// findPlaylist matches names case-insensitively so "Chill" and "chill" cannot coexist
+func findPlaylist(Playlists []UserPlaylist, Name string) *UserPlaylist {
+
+	for Index := range Playlists {
+
+		if strings.EqualFold(Playlists[Index].Name, Name) {
+
+			return &Playlists[Index]
+
+		}
+
+	}
+
+	return nil
+
+}
+
+// GetPlaylist returns one of a user's playlists by name
+func GetPlaylist(OwnerID string, Name string) (*UserPlaylist, error) {
+
+	Normalized, Error := NormalizeSavedQueueName(Name)
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	Playlists, Error := ListPlaylists(OwnerID)
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	Playlist := findPlaylist(Playlists, Normalized)
+
+	if Playlist == nil {
+
+		return nil, ErrPlaylistNotFound
+
+	}
+
+	return Playlist, nil
+
+}
+
+// GetPlaylistByID returns a user's playlist by ID, used by components that must survive renames
+func GetPlaylistByID(OwnerID string, ID string) (*UserPlaylist, error) {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Playlist := &UserPlaylist{}
+
+	if Error := playlistsCollection().FindOne(Context, bson.M{"_id": ID, "owner_id": OwnerID}).Decode(Playlist); Error != nil {
+
+		if errors.Is(Error, mongo.ErrNoDocuments) {
+
+			return nil, ErrPlaylistNotFound
+
+		}
+
+		return nil, Error
+
+	}
+
+	return Playlist, nil
+
+}
+
+func (P *UserPlaylist) persist() error {
+
+	P.UpdatedAt = time.Now().UTC()
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	_, Error := playlistsCollection().ReplaceOne(Context, bson.M{"_id": P.ID}, P, options.Replace().SetUpsert(true))
+
+	return Error
+
+}
+
+// cloneForPlaylist strips per-session state and drops songs past MaxPlaylistSongs
+func cloneForPlaylist(Songs []Tidal.Song) []Tidal.Song {
+
+	Cloned := make([]Tidal.Song, 0, min(len(Songs), MaxPlaylistSongs))
+
+	for Index := range Songs {
+
+		if len(Cloned) >= MaxPlaylistSongs {
+
+			break
+
+		}
+
+		Cloned = append(Cloned, *CloneSong(&Songs[Index]))
+
+	}
+
+	return Cloned
+
+}
+
+// CreatePlaylist creates a playlist for a user, optionally seeded with songs
+func CreatePlaylist(OwnerID string, Name string, Songs []Tidal.Song) (*UserPlaylist, error) {
+
+	Normalized, Error := NormalizeSavedQueueName(Name)
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	Playlists, Error := ListPlaylists(OwnerID)
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	if findPlaylist(Playlists, Normalized) != nil {
+
+		return nil, ErrPlaylistExists
+
+	}
+
+	if len(Playlists) >= MaxPlaylistsPerUser {
+
+		return nil, ErrPlaylistLimit
+
+	}
+
+	Playlist := &UserPlaylist{
+
+		ID:        GenerateNotificationID(),
+		OwnerID:   OwnerID,
+		Name:      Normalized,
+		Songs:     cloneForPlaylist(Songs),
+		CreatedAt: time.Now().UTC(),
+
+	}
+
+	return Playlist, Playlist.persist()
+
+}
+
+// RenamePlaylist renames one of a user's playlists
+func RenamePlaylist(OwnerID string, Name string, NewName string) (*UserPlaylist, error) {
+
+	Normalized, Error := NormalizeSavedQueueName(NewName)
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	Playlists, Error := ListPlaylists(OwnerID)
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	Playlist := findPlaylist(Playlists, strings.TrimSpace(Name))
+
+	if Playlist == nil {
+
+		return nil, ErrPlaylistNotFound
+
+	}
+
+	if Existing := findPlaylist(Playlists, Normalized); Existing != nil && Existing.ID != Playlist.ID {
+
+		return nil, ErrPlaylistExists
+
+	}
+
+	Playlist.Name = Normalized
+
+	return Playlist, Playlist.persist()
+
+}
+
+// DeletePlaylist deletes one of a user's playlists, invalidating its share code
+func DeletePlaylist(OwnerID string, Name string) error {
+
+	Playlist, Error := GetPlaylist(OwnerID, Name)
+
+	if Error != nil {
+
+		return Error
+
+	}
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	_, Error = playlistsCollection().DeleteOne(Context, bson.M{"_id": Playlist.ID})
+
+	return Error
+
+}
+
+// AddSongs appends songs that are not already in the playlist, returning how many were added
+func (P *UserPlaylist) AddSongs(Songs []Tidal.Song) (int, error) {
+
+	Existing := map[int64]bool{}
+
+	for _, Song := range P.Songs {
+
+		Existing[Song.TidalID] = true
+
+	}
+
+	Added := 0
+
+	for Index := range Songs {
+
+		if Songs[Index].TidalID == 0 || Existing[Songs[Index].TidalID] {
+
+			continue
+
+		}
+
+		if len(P.Songs) >= MaxPlaylistSongs {
+
+			if Added == 0 {
+
+				return 0, ErrPlaylistFull
+
+			}
+
+			break
+
+		}
+
+		Existing[Songs[Index].TidalID] = true
+		P.Songs = append(P.Songs, *CloneSong(&Songs[Index]))
+		Added++
+
+	}
+
+	if Added == 0 {
+
+		return 0, nil
+
+	}
+
+	return Added, P.persist()
+
+}
+
+// RemoveSong removes the song at a zero-based position and returns it
+func (P *UserPlaylist) RemoveSong(Index int) (Tidal.Song, error) {
+
+	if Index < 0 || Index >= len(P.Songs) {
+
+		return Tidal.Song{}, ErrPlaylistPosition
+
+	}
+
+	Removed := P.Songs[Index]
+	P.Songs = append(P.Songs[:Index], P.Songs[Index+1:]...)
+
+	return Removed, P.persist()
+
+}
+
+// MoveSong moves the song at one zero-based position to another
+func (P *UserPlaylist) MoveSong(From int, To int) error {
+
+	if From < 0 || From >= len(P.Songs) || To < 0 || To >= len(P.Songs) {
+
+		return ErrPlaylistPosition
+
+	}
+
+	if From == To {
+
+		return nil
+
+	}
+
+	Song := P.Songs[From]
+	P.Songs = append(P.Songs[:From], P.Songs[From+1:]...)
+	P.Songs = append(P.Songs[:To], append([]Tidal.Song{Song}, P.Songs[To:]...)...)
+
+	return P.persist()
+
+}
+
+// Share gives the playlist a share code, keeping the existing one if it is already shared
+func (P *UserPlaylist) Share() (string, error) {
+
+	if P.ShareCode != "" {
+
+		return P.ShareCode, nil
+
+	}
+
+	P.ShareCode = GenerateShareCode()
+
+	return P.ShareCode, P.persist()
+
+}
+
+// Unshare revokes the playlist's share code; copies that were already imported are kept
+func (P *UserPlaylist) Unshare() error {
+
+	if P.ShareCode == "" {
+
+		return nil
+
+	}
+
+	P.ShareCode = ""
+
+	return P.persist()
+
+}
+
+// GetSharedPlaylist looks up a shared playlist by its share code
+func GetSharedPlaylist(Code string) (*UserPlaylist, error) {
+
+	Code = strings.ToUpper(strings.TrimSpace(Code))
+
+	if Code == "" {
+
+		return nil, ErrShareCodeNotFound
+
+	}
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Playlist := &UserPlaylist{}
+
+	if Error := playlistsCollection().FindOne(Context, bson.M{"share_code": Code}).Decode(Playlist); Error != nil {
+
+		if errors.Is(Error, mongo.ErrNoDocuments) {
+
+			return nil, ErrShareCodeNotFound
+
+		}
+
+		return nil, Error
+
+	}
+
+	return Playlist, nil
+
+}
+
+// ImportPlaylist copies a shared playlist into the user's own playlists, keeping its name unless one is given
+func ImportPlaylist(OwnerID string, Code string, Name string) (*UserPlaylist, error) {
+
+	Shared, Error := GetSharedPlaylist(Code)
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	if strings.TrimSpace(Name) == "" {
+
+		Name = Shared.Name
+
+	}
+
+	return CreatePlaylist(OwnerID, Name, Shared.Songs)
+
+}
+
+// QueueSongs returns copies of the playlist's songs tagged with the playlist so the queue can show where they came from
+func (P *UserPlaylist) QueueSongs() []Tidal.Song {
+
+	Meta := Tidal.PlaylistMeta{
+
+		Name:     P.Name,
+		Platform: "System",
+		Total:    len(P.Songs),
+		ID:       "playlist:" + P.ID,
+
+	}
+
+	Songs := make([]Tidal.Song, len(P.Songs))
+
+	for Index := range P.Songs {
+
+		Songs[Index] = *CloneSong(&P.Songs[Index])
+		Songs[Index].Internal.Playlist = Meta
+		Songs[Index].Internal.Playlist.Index = Index
+
+	}
+
+	return Songs
+
+}
+
+// LoadPlaylist replaces the guild's queue with a playlist; like saved queues it bypasses quotas and can be undone
+func (G *Guild) LoadPlaylist(Playlist *UserPlaylist, Requestor string) error {
+
+	Songs := Playlist.QueueSongs()
+
+	if len(Songs) == 0 {
+
+		return ErrPlaylistEmpty
+
+	}
+
+	Queued := make([]*Tidal.Song, len(Songs))
+
+	for Index := range Songs {
+
+		Songs[Index].Internal.Requestor = Requestor
+		Queued[Index] = &Songs[Index]
+
+	}
+
+	G.Queue.Track(OperationLoad, func() bool {
+
+		G.replaceQueue([]*Tidal.Song{}, Queued[0], Queued[1:])
+		return true
+
+	})
+
+	return nil
+
+}
+
+// AppendPlaylist adds a playlist to the end of the guild's queue, starting playback if it was idle
+func (G *Guild) AppendPlaylist(Playlist *UserPlaylist, Requestor string) (int, QuotaSkips, error) {
+
+	if len(Playlist.Songs) == 0 {
+
+		return 0, QuotaSkips{}, ErrPlaylistEmpty
+
+	}
+
+	Added, Skipped := G.Queue.AddAll(Playlist.QueueSongs(), Requestor)
+
+	if Added > 0 && G.Queue.State == StateIdle && G.Queue.Current != nil {
+
+		G.Queue.Play() // the queue was empty
+
+	}
+
+	return Added, Skipped, nil
+
+}
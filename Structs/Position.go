@@ -0,0 +1,258 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"strconv"
+	"strings"
+)
+
+const (
+
+	InsertEnd   = "end"
+	InsertNext  = "next"
+	InsertNow   = "now"   // play immediately, interrupting the current song
+	InsertIndex = "index"
+
+)
+
+// InsertPosition describes where new songs go in the upcoming queue
+type InsertPosition struct {
+
+	Mode  string
+	Index int // 0-indexed position in upcoming, used with InsertIndex
+
+}
+
+var PositionEnd = InsertPosition{Mode: InsertEnd}
+
+// ParsePosition reads "next", "now", "end" or a 1-indexed queue position; anything else means the end of the queue
+func ParsePosition(Value string) InsertPosition {
+
+	Value = strings.ToLower(strings.TrimSpace(Value))
+
+	switch Value {
+
+	case InsertNext, InsertNow:
+
+		return InsertPosition{Mode: Value}
+
+	}
+
+	if Index, ErrorParsing := strconv.Atoi(Value); ErrorParsing == nil && Index > 0 {
+
+		return InsertPosition{Mode: InsertIndex, Index: Index - 1}
+
+	}
+
+	return PositionEnd
+
+}
+
+// String encodes the position for component custom IDs, reversible with ParsePosition
+func (P InsertPosition) String() string {
+
+	if P.Mode == InsertIndex {
+
+		return strconv.Itoa(P.Index + 1)
+
+	}
+
+	return P.Mode
+
+}
+
+// After returns where the songs following one added at Pos (as returned by AddAt) should go to keep their order
+func (P InsertPosition) After(Pos int) InsertPosition {
+
+	if P.Mode == InsertEnd {
+
+		return P
+
+	}
+
+	return InsertPosition{Mode: InsertIndex, Index: Pos}
+
+}
+
+// AddAt queues a song at the given position and returns its 1-indexed position in upcoming, or 0 when it became the current song
+func (Q *Queue) AddAt(Song *Tidal.Song, Requestor string, Position InsertPosition) (int, error) {
+
+	if QuotaErr := Q.Admit(Song, Requestor); QuotaErr != nil {
+
+		return -1, QuotaErr
+
+	}
+
+	Pos := 0
+	Playing := Q.Current
+
+	Q.Track(OperationAdd, func() bool {
+
+		Pos = Q.insertAt(Song, Requestor, Position)
+		return true
+
+	})
+
+	Q.startReplaced(Playing)
+
+	return Pos, nil
+
+}
+
+// startReplaced plays and announces the current song when an add played a song now in place of Playing
+func (Q *Queue) startReplaced(Playing *Tidal.Song) {
+
+	if Playing == nil || Q.Current == Playing {
+
+		return
+
+	}
+
+	Q.Functions.Updated(Q)
+
+	go Q.Play()
+
+	Q.SendNowPlayingMessage()
+
+}
+
+func (Q *Queue) insertAt(Song *Tidal.Song, Requestor string, Position InsertPosition) int {
+
+	Song.Internal.Requestor = Requestor
+
+	if Q.Current == nil {
+
+		Q.Current = Song
+
+		go Q.Functions.Updated(Q)
+
+		return 0
+
+	}
+
+	Index := len(Q.Upcoming)
+
+	switch Position.Mode {
+
+	case InsertNext, InsertNow:
+
+		Index = 0
+
+	case InsertIndex:
+
+		Index = min(max(Position.Index, 0), len(Q.Upcoming))
+
+	default:
+
+		if Q.fairShare() {
+
+			Index = Q.fairInsertIndex(Requestor)
+
+		}
+
+	}
+
+	Q.Upcoming = append(Q.Upcoming[:Index], append([]*Tidal.Song{Song}, Q.Upcoming[Index:]...)...)
+
+	// Playing now only moves the queue here; the caller starts the song once the add is recorded
+
+	if Position.Mode == InsertNow && Q.moveTo(Index+1, false) {
+
+		return 0
+
+	}
+
+	go Q.Functions.Updated(Q)
+
+	return Index + 1 // Position in UPCOMING queue is 1-based
+
+}
+
+// AddAllAt queues songs in order starting at the given position, skipping (and counting) those rejected by the quota policy
+func (Q *Queue) AddAllAt(Songs []Tidal.Song, Requestor string, Position InsertPosition) (int, QuotaSkips) {
+
+	Added := 0
+	Skipped := QuotaSkips{}
+	Playing := Q.Current
+
+	Q.Track(OperationAdd, func() bool {
+
+		Added, Skipped = Q.addAllAt(Songs, Requestor, Position)
+		return Added > 0
+
+	})
+
+	Q.startReplaced(Playing)
+
+	return Added, Skipped
+
+}
+
+// ReplaceAll swaps the whole queue for the given songs as one undoable load, skipping (and counting) those rejected by the quota policy
+func (Q *Queue) ReplaceAll(Songs []Tidal.Song, Requestor string) (int, QuotaSkips) {
+
+	Added := 0
+	Skipped := QuotaSkips{}
+
+	Q.Track(OperationLoad, func() bool {
+
+		Q.reset()
+
+		Added, Skipped = Q.addAllAt(Songs, Requestor, PositionEnd)
+		return true
+
+	})
+
+	return Added, Skipped
+
+}
+
+// addAllAt is AddAllAt without recording an operation
+func (Q *Queue) addAllAt(Songs []Tidal.Song, Requestor string, Position InsertPosition) (int, QuotaSkips) {
+
+	Added := 0
+	Skipped := QuotaSkips{}
+
+	for _, Song := range Songs {
+
+		SongCopy := Song
+
+		if QuotaErr := Q.Admit(&SongCopy, Requestor); QuotaErr != nil {
+
+			if Quota, IsQuota := QuotaErr.(*QuotaError); IsQuota {
+
+				Skipped[Quota.Reason]++
+
+			}
+
+			continue
+
+		}
+
+		Position = Position.After(Q.insertAt(&SongCopy, Requestor, Position))
+		Added++
+
+	}
+
+	return Added, Skipped
+
+}
+
+// Capability returns the capability required to insert at this position, or "" when none is needed; jumping the line counts as a move and playing now as a skip
+func (P InsertPosition) Capability() string {
+
+	switch P.Mode {
+
+	case InsertNow:
+
+		return CapabilitySkip
+
+	case InsertNext, InsertIndex:
+
+		return CapabilityMove
+
+	}
+
+	return ""
+
+}
@@ -52,6 +52,11 @@ type Queue struct {
 	WebSockets      map[*websocket.Conn]bool `json:"-"`
 	SocketMutex     sync.Mutex               `json:"-"`
 
+	History QueueHistory `json:"-"`
+	Radio   RadioState   `json:"-"`
+
+	playing activePlay
+
 }
 
 type QueueFunctions struct {
@@ -122,6 +127,9 @@ func QueueStateHandler(Queue *Queue, State int) {
 
 			}
 
+			// The song ended on its own; log it as played through
+			Queue.endPlay(false)
+
 			// Handle Repeat One - replay current song
 			if Guild.Features.Repeat == RepeatOne && Queue.Current != nil {
 
@@ -135,7 +143,9 @@ func QueueStateHandler(Queue *Queue, State int) {
 			// Move current song to Previous before calling Next(), for AutoPlay seed
 			if Queue.Current != nil {
 
+				Queue.Radio.Finish(Queue.Current)
 				Queue.Previous = append(Queue.Previous, Queue.Current)
+				Queue.trimPrevious()
 				Queue.Current = nil
 
 			}
@@ -193,6 +203,8 @@ func QueueUpdatedHandler(Queue *Queue) {
 
 	}()
 
+	Guild := GetGuild(Queue.ParentID, false)
+
 	Queue.SendToWebsockets(Event_QueueUpdated, map[string]interface{}{
 
 		"Current": Queue.Current,
@@ -200,9 +212,9 @@ func QueueUpdatedHandler(Queue *Queue) {
 		"Upcoming": Queue.Upcoming,
 		"Suggestions": Queue.Suggestions,
 
-	})
+		"FairShare": Guild != nil && Guild.Settings.Queue.FairShare,
 
-	Guild := GetGuild(Queue.ParentID, false)
+	})
 
 	if Guild != nil && Guild.Features.Autoplay {
 
@@ -511,7 +523,11 @@ func (Q *Queue) Remove(Index int) bool {
 
 	}
 
+	Before := Q.snapshot()
+
 	Q.Upcoming = append(Q.Upcoming[:Index], Q.Upcoming[Index+1:]...)
+	Q.record(OperationRemove, Before)
+
 	Q.Functions.Updated(Q)
 
 	return true
@@ -533,6 +549,8 @@ func (Q *Queue) Move(FromIndex int, ToIndex int) bool {
 
 	}
 
+	Before := Q.snapshot()
+
 	Song := Q.Upcoming[FromIndex]
 	Q.Upcoming = append(Q.Upcoming[:FromIndex], Q.Upcoming[FromIndex+1:]...)
 
@@ -543,6 +561,8 @@ func (Q *Queue) Move(FromIndex int, ToIndex int) bool {
 	}
 
 	Q.Upcoming = append(Q.Upcoming[:ToIndex], append([]*Tidal.Song{Song}, Q.Upcoming[ToIndex:]...)...)
+	Q.record(OperationMove, Before)
+
 	Q.Functions.Updated(Q)
 
 	return true
@@ -558,6 +578,8 @@ func (Q *Queue) Replay(Index int) bool {
 
 	}
 
+	Before := Q.snapshot()
+
 	// Move current song to front of upcoming
 
 	if Q.Current != nil {
@@ -582,6 +604,8 @@ func (Q *Queue) Replay(Index int) bool {
 
 	Q.Previous = Q.Previous[:Index]
 
+	Q.record(OperationReplay, Before)
+
 	Q.Functions.Updated(Q)
 
 	Q.SendNowPlayingMessage()
@@ -594,36 +618,53 @@ func (Q *Queue) Replay(Index int) bool {
 // ClearQueue resets the queue to an empty state
 func (Q *Queue) Clear() {
 
-	Q.Current = nil
+	Before := Q.snapshot()
 
-	Q.Previous = []*Tidal.Song{}
-	Q.Upcoming = []*Tidal.Song{}
+	Q.reset()
+
+	Q.record(OperationClear, Before)
 
 	Q.Functions.Updated(Q)
 
 }
 
-// Add appends a song to the end of the queue OR current
-func (Q *Queue) Add(Song *Tidal.Song, Requestor string) int {
+// reset empties the queue without recording an operation
+func (Q *Queue) reset() {
 
-	Song.Internal.Requestor = Requestor
+	Q.Current = nil
 
-	Pos := len(Q.Upcoming)
+	Q.Previous = []*Tidal.Song{}
+	Q.Upcoming = []*Tidal.Song{}
 
-	if Q.Current == nil {
+}
 
-		Q.Current = Song
+// ClearUpcoming removes every upcoming song but keeps the current one playing; returns how many were removed.
+func (Q *Queue) ClearUpcoming() int {
 
-	} else {
+	Removed := len(Q.Upcoming)
 
-		Q.Upcoming = append(Q.Upcoming, Song)
-		Pos++ // Position in UPCOMING queue is 1-based
+	if Removed == 0 {
+
+		return 0
 
 	}
 
-	go Q.Functions.Updated(Q)
+	Before := Q.snapshot()
+
+	Q.Upcoming = []*Tidal.Song{}
+
+	Q.record(OperationClear, Before)
+
+	Q.Functions.Updated(Q)
+
+	return Removed
+
+}
+
+// Add appends a song to the end of the queue OR current; in fair-share mode it is placed at the requestor's next turn. Songs rejected by the quota policy return a *QuotaError
+func (Q *Queue) Add(Song *Tidal.Song, Requestor string) (int, error) {
 
-	return Pos
+	return Q.AddAt(Song, Requestor, PositionEnd)
 
 }
 
@@ -666,10 +707,14 @@ func (Q *Queue) Play() bool {
 
 	}
 
+	Q.beginPlay(Q.Current)
+
 	ErrorPlaying := Guild.Play(Q.Current)
 
 	if ErrorPlaying != nil {
 
+		Q.discardPlay()
+
 		Utils.Logger.Error("Playback", fmt.Sprintf("Error playing song %s for Queue %s: %s", Q.Current.Title, Q.ParentID.String(), ErrorPlaying.Error()))
 
 		if errors.Is(ErrorPlaying, ErrStreamUnavailable) {
@@ -729,42 +774,44 @@ func (Q *Queue) SendNowPlayingMessage() {
 
 	}
 
-	State := Tidal.QueueInfo{
-
-		Playing: true,
-
-		GuildID: Q.ParentID,
-		SongPosition: 0,
-
-		TotalPrevious: len(Q.Previous),
-		TotalUpcoming: len(Q.Upcoming),
-
-		Locale: Guild.Locale.Code(),
-
-	}
+	State := Guild.QueueInfo(true, 0, Guild.Locale.Code())
 
 	go func() {
 
-		_, ErrorSending := Globals.DiscordClient.Rest.CreateMessage(Guild.Channels.Text, discord.NewMessageCreate().
+		Message, ErrorSending := Globals.DiscordClient.Rest.CreateMessage(Guild.Channels.Text, discord.NewMessageCreate().
 			AddEmbeds(Q.Current.Embed(State)).
-			AddActionRow(Q.Current.Buttons(State)...))
+			AddComponents(Q.Current.Components(State)...))
 
 		if ErrorSending != nil {
 
 			Utils.Logger.Error("Command", fmt.Sprintf("Error sending now playing message to channel %s for Queue %s: %s", Guild.Channels.Text, Q.ParentID.String(), ErrorSending.Error()))
+			return
 
 		}
 
+		Guild.Internal.NowPlayingMessage = Message.ID
+
 	}()
 
 }
 
-// shuffleUpcoming uses Fisher-Yates algorithm to shuffle the upcoming queue in-place
-func (Q *Queue) ShuffleUpcoming() {
+// ShuffleUpcomingWith shuffles the upcoming queue on request and records it as an undoable operation
+func (Q *Queue) ShuffleUpcomingWith(Mode string) {
+
+	Q.Track(OperationShuffle, func() bool {
+
+		return Q.shuffleUpcoming(Mode)
+
+	})
+
+}
+
+// shuffleUpcoming shuffles the upcoming queue in-place without recording it; smart mode spreads out artists, albums and requestors after the Fisher-Yates pass
+func (Q *Queue) shuffleUpcoming(Mode string) bool {
 
 	if len(Q.Upcoming) <= 1 {
 
-		return
+		return false
 
 	}
 
@@ -776,6 +823,14 @@ func (Q *Queue) ShuffleUpcoming() {
 
 	}
 
+	if Mode == ShuffleSmart {
+
+		Q.Upcoming = spreadSongs(Q.recentSongs(), Q.Upcoming)
+
+	}
+
+	return true
+
 }
 
 // moveTo performs the queue movement; optionally starts playback when ShouldPlay is true. Positive indices navigate upcoming songs (1-indexed), negative indices navigate previous songs (-1 is most recent).
@@ -863,7 +918,9 @@ func (Q *Queue) moveTo(Index int, ShouldPlay bool) bool {
 
 	if Q.Current != nil {
 
+		Q.Radio.Skip(Q.Current) // left before it ended
 		Q.Previous = append(Q.Previous, Q.Current)
+		Q.trimPrevious()
 
 		// Handles Repeat All - re-enqueue the current song after it's moved to previous
 
@@ -884,9 +941,11 @@ func (Q *Queue) moveTo(Index int, ShouldPlay bool) bool {
 
 	Q.Upcoming = Remaining
 
+	// Shuffle mode reshuffles on every advance; that is not an operation anyone asked to undo
+
 	if Guild != nil && Guild.Features.Shuffle {
 
-		Q.ShuffleUpcoming()
+		Q.shuffleUpcoming(Q.shuffleMode())
 
 	}
 
@@ -903,124 +962,3 @@ func (Q *Queue) moveTo(Index int, ShouldPlay bool) bool {
 	return true
 
 }
-
-// RegenerateSuggestions fetches new song suggestions based on the last played song using Tidal mix
-func (Q *Queue) RegenerateSuggestions() {
-
-	Guild := GetGuild(Q.ParentID, false)
-
-	if Guild == nil {
-
-		Utils.Logger.Warn("AutoPlay", fmt.Sprintf("RegenerateSuggestions called but Guild is nil for Queue %s", Q.ParentID.String()))
-		return
-
-	}
-
-	if !Guild.Features.Autoplay {
-
-		Utils.Logger.Warn("AutoPlay", fmt.Sprintf("RegenerateSuggestions called but AutoPlay is disabled for Queue %s", Q.ParentID.String()))
-		return
-
-	}
-
-	// Determine seed song (last in Previous queue, then Current)
-
-	var SeedSong *Tidal.Song
-
-	if len(Q.Previous) > 0 {
-
-		SeedSong = Q.Previous[len(Q.Previous)-1]
-
-	} else if Q.Current != nil {
-
-		SeedSong = Q.Current
-
-	} else {
-
-		Utils.Logger.Warn("AutoPlay", fmt.Sprintf("No seed song available for Queue %s (Previous: %d, Current: %v)", Q.ParentID.String(), len(Q.Previous), Q.Current != nil))
-		return // No seed available
-
-	}
-
-	Utils.Logger.Info("AutoPlay", fmt.Sprintf("Regenerating suggestions for Queue %s using seed: %s", Q.ParentID.String(), SeedSong.Title))
-
-	// Get mix ID from current song if available, otherwise fetch it
-
-	MixID := SeedSong.MixID
-
-	if MixID == "" {
-
-		var Err error
-
-		MixID, Err = Tidal.FetchTrackMix(SeedSong.TidalID)
-
-		if Err != nil {
-
-			Utils.Logger.Error("Tidal API", fmt.Sprintf("Error fetching track mix for Queue %s: %s", Q.ParentID.String(), Err.Error()))
-			return
-
-		}
-
-	}
-
-	// Fetch mix items (similar songs)
-
-	SimilarSongs, ErrorFetching := Tidal.FetchMixItems(MixID)
-
-	if ErrorFetching != nil {
-
-		Utils.Logger.Error("Tidal API", fmt.Sprintf("Error fetching mix items for Queue %s: %s", Q.ParentID.String(), ErrorFetching.Error()))
-		return
-
-	}
-
-	// Filter out the seed song
-
-	Filtered := make([]Tidal.Song, 0, len(SimilarSongs))
-
-	for _, Song := range SimilarSongs {
-
-		if Song.TidalID != SeedSong.TidalID {
-
-			Filtered = append(Filtered, Song)
-
-		}
-
-	}
-
-	// Randomize and take up to 5 suggestions
-
-	MaxSuggestions := 5
-
-	if len(Filtered) > MaxSuggestions {
-
-		// Shuffle using Fisher-Yates and take first MaxSuggestions
-
-		for i := len(Filtered) - 1; i > 0; i-- {
-
-			j := rand.Intn(i + 1)
-			Filtered[i], Filtered[j] = Filtered[j], Filtered[i]
-
-		}
-
-		Filtered = Filtered[:MaxSuggestions]
-
-	}
-
-	// Convert to pointers and mark as suggested
-
-	Q.Suggestions = make([]*Tidal.Song, 0, len(Filtered))
-
-	for i := range Filtered {
-
-		Song := &Filtered[i]
-		Song.Internal.Suggested = true
-		Song.Internal.Requestor = "AutoPlay"
-
-		Q.Suggestions = append(Q.Suggestions, Song)
-
-	}
-
-	Utils.Logger.Info("AutoPlay", fmt.Sprintf("Generated %d suggestions for Queue %s", len(Q.Suggestions), Q.ParentID.String()))
-
-}
@@ -0,0 +1,312 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals/Localizations"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+
+	DuplicatesAllow  = "allow"
+	DuplicatesReject = "reject" // never while the song is still playing or queued
+	DuplicatesWindow = "window" // only once per DuplicateWindow songs
+
+	DefaultDuplicateWindow = 10
+
+)
+
+const (
+
+	QuotaUserLimit       = "UserLimit"
+	QuotaTrackLength     = "TrackLength"
+	QuotaQueueFull       = "QueueFull"
+	QuotaDuplicate       = "Duplicate"
+	QuotaRecentDuplicate = "RecentDuplicate"
+
+)
+
+var QuotaReasons = []string{QuotaUserLimit, QuotaTrackLength, QuotaQueueFull, QuotaDuplicate, QuotaRecentDuplicate}
+
+var AllowedDuplicatePolicies = []string{DuplicatesAllow, DuplicatesReject, DuplicatesWindow}
+
+// QuotaPolicy limits what can be queued; zero values mean unlimited
+type QuotaPolicy struct {
+
+	MaxSongsPerUser int `bson:"max_songs_per_user"` // upcoming songs a single requestor may have queued
+	MaxTrackMinutes int `bson:"max_track_minutes"`
+	MaxQueueLength int `bson:"max_queue_length"` // upcoming songs in total
+
+	Duplicates string `bson:"duplicates"`
+	DuplicateWindow int `bson:"duplicate_window"`
+
+}
+
+// QuotaError is returned when a song is rejected by the guild's quota policy
+type QuotaError struct {
+
+	Reason string
+	Limit int
+
+}
+
+func (E *QuotaError) Error() string {
+
+	switch E.Reason {
+
+	case QuotaUserLimit:
+
+		return fmt.Sprintf("you already have %d songs queued", E.Limit)
+
+	case QuotaTrackLength:
+
+		return fmt.Sprintf("songs longer than %d minutes are not allowed", E.Limit)
+
+	case QuotaQueueFull:
+
+		return fmt.Sprintf("the queue is full (%d songs)", E.Limit)
+
+	case QuotaRecentDuplicate:
+
+		return fmt.Sprintf("this song was queued within the last %d songs", E.Limit)
+
+	default:
+
+		return "this song is already queued"
+
+	}
+
+}
+
+// Localized describes the rejection in the given locale
+func (E *QuotaError) Localized(Locale string) string {
+
+	if E.Limit == 0 {
+
+		return Localizations.Get("Embeds.Errors.Quota."+E.Reason, Locale)
+
+	}
+
+	return Localizations.GetFormat("Embeds.Errors.Quota."+E.Reason, Locale, E.Limit)
+
+}
+
+// QuotaSkips counts songs skipped while adding a batch, by quota reason
+type QuotaSkips map[string]int
+
+// Total returns how many songs were skipped for any reason
+func (S QuotaSkips) Total() int {
+
+	Total := 0
+
+	for _, Count := range S {
+
+		Total += Count
+
+	}
+
+	return Total
+
+}
+
+// Describe summarises the skipped songs, one reason per line
+func (S QuotaSkips) Describe(Locale string) string {
+
+	Lines := []string{Localizations.GetFormat("Embeds.Notifications.QuotaSkipped.Summary", Locale, S.Total(), Localizations.Pluralize("Song", S.Total(), Locale))}
+
+	for _, Reason := range QuotaReasons {
+
+		if S[Reason] > 0 {
+
+			Lines = append(Lines, Localizations.GetFormat("Embeds.Notifications.QuotaSkipped."+Reason, Locale, S[Reason]))
+
+		}
+
+	}
+
+	return strings.Join(Lines, "\n")
+
+}
+
+// AppendTo adds the skipped summary to a notification description when anything was skipped
+func (S QuotaSkips) AppendTo(Description string, Locale string) string {
+
+	if S.Total() == 0 {
+
+		return Description
+
+	}
+
+	return Description + "\n\n" + S.Describe(Locale)
+
+}
+
+// Admit checks a song against the guild's quota policy before it is queued
+func (Q *Queue) Admit(Song *Tidal.Song, Requestor string) error {
+
+	Guild := GetGuild(Q.ParentID, false)
+
+	if Guild == nil {
+
+		return nil
+
+	}
+
+	Policy := Guild.Settings.Quotas
+
+	if Policy.MaxTrackMinutes > 0 && Song.Duration.Seconds > Policy.MaxTrackMinutes*60 {
+
+		return &QuotaError{Reason: QuotaTrackLength, Limit: Policy.MaxTrackMinutes}
+
+	}
+
+	if Q.Current == nil {
+
+		return nil // an empty queue always accepts the first song
+
+	}
+
+	if Policy.MaxQueueLength > 0 && len(Q.Upcoming) >= Policy.MaxQueueLength {
+
+		return &QuotaError{Reason: QuotaQueueFull, Limit: Policy.MaxQueueLength}
+
+	}
+
+	if Policy.MaxSongsPerUser > 0 {
+
+		Queued := 0
+		Key := requestorKey(Requestor) // the same user may be stored as a mention or a bare ID
+
+		for _, Upcoming := range Q.Upcoming {
+
+			if requestorKey(Upcoming.Internal.Requestor) == Key {
+
+				Queued++
+
+			}
+
+		}
+
+		if Queued >= Policy.MaxSongsPerUser {
+
+			return &QuotaError{Reason: QuotaUserLimit, Limit: Policy.MaxSongsPerUser}
+
+		}
+
+	}
+
+	if Q.isDuplicate(Song, Policy) {
+
+		if Policy.Duplicates == DuplicatesWindow {
+
+			return &QuotaError{Reason: QuotaRecentDuplicate, Limit: Policy.DuplicateWindow}
+
+		}
+
+		return &QuotaError{Reason: QuotaDuplicate}
+
+	}
+
+	return nil
+
+}
+
+// isDuplicate reports whether the song is already queued (or, in window mode, played within the last DuplicateWindow songs)
+func (Q *Queue) isDuplicate(Song *Tidal.Song, Policy QuotaPolicy) bool {
+
+	Recent := append([]*Tidal.Song{Q.Current}, Q.Upcoming...)
+
+	switch Policy.Duplicates {
+
+	case DuplicatesReject:
+
+	case DuplicatesWindow:
+
+		Window := Policy.DuplicateWindow
+
+		if Window <= 0 {
+
+			Window = DefaultDuplicateWindow
+
+		}
+
+		Recent = append(slices.Clone(Q.Previous), Recent...)
+
+		if len(Recent) > Window {
+
+			Recent = Recent[len(Recent)-Window:]
+
+		}
+
+	default:
+
+		return false
+
+	}
+
+	return slices.ContainsFunc(Recent, func(Other *Tidal.Song) bool { return sameSong(Song, Other) })
+
+}
+
+func sameSong(A *Tidal.Song, B *Tidal.Song) bool {
+
+	if A.TidalID != 0 || B.TidalID != 0 {
+
+		return A.TidalID == B.TidalID
+
+	}
+
+	return A.Title == B.Title && slices.Equal(A.Artists, B.Artists)
+
+}
+
+// AddAll queues songs at the end, skipping (and counting) those rejected by the quota policy
+func (Q *Queue) AddAll(Songs []Tidal.Song, Requestor string) (int, QuotaSkips) {
+
+	return Q.AddAllAt(Songs, Requestor, PositionEnd)
+
+}
+
+// SaveQuotaPolicy clamps and persists a guild's quota policy, returning the stored value
+func SaveQuotaPolicy(GuildID string, Policy QuotaPolicy) (QuotaPolicy, error) {
+
+	Policy = clampQuotaPolicy(Policy)
+
+	return Policy, saveGuildSetting(GuildID, "quotas", Policy)
+
+}
+
+// SetQuotaPolicy persists the quota policy and applies it to the active session
+func (G *Guild) SetQuotaPolicy(Policy QuotaPolicy) error {
+
+	Policy, UpdateError := SaveQuotaPolicy(G.ID.String(), Policy)
+
+	G.Settings.Quotas = Policy
+
+	return UpdateError
+
+}
+
+func clampQuotaPolicy(Policy QuotaPolicy) QuotaPolicy {
+
+	Policy.MaxSongsPerUser = max(Policy.MaxSongsPerUser, 0)
+	Policy.MaxTrackMinutes = max(Policy.MaxTrackMinutes, 0)
+	Policy.MaxQueueLength = max(Policy.MaxQueueLength, 0)
+
+	if !slices.Contains(AllowedDuplicatePolicies, Policy.Duplicates) {
+
+		Policy.Duplicates = DuplicatesAllow
+
+	}
+
+	if Policy.DuplicateWindow <= 0 {
+
+		Policy.DuplicateWindow = DefaultDuplicateWindow
+
+	}
+
+	return Policy
+
+}
@@ -0,0 +1,658 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs"
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Utils"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+
+	RadioSeedArtist = "artist"
+	RadioSeedSong   = "song"
+
+	RadioSeedTracks    = 5 // recently played tracks whose mixes are blended
+	RadioFavoriteSeeds = 2 // requestors' favorites blended in alongside them
+	RadioBatchSize     = 5 // suggestions produced per regeneration
+
+	RadioArtistWindow   = 10 // recent radio picks the artist cap is measured over
+	RadioMaxArtistShare = 3  // picks a single artist may have within that window
+
+	RadioSkipMemory = 25 // recently skipped tracks excluded from the radio
+
+	radioSeedWeight     = 3 // an explicit /radio seed outweighs the session
+	radioFinishedWeight = 2 // tracks played to the end count more than ones still playing
+
+)
+
+// RadioSeed pins the radio to an artist or song instead of the session's recent tracks
+type RadioSeed struct {
+
+	Kind  string
+	ID    int64
+	Title string
+
+}
+
+// RadioState remembers what the radio has played this session and how listeners reacted to it
+type RadioState struct {
+
+	Seed *RadioSeed
+
+	Played   map[int64]bool // every track played or suggested this session; never suggested again
+	Finished map[int64]bool
+	Skipped  []int64 // most recent last
+
+	Picks []*Tidal.Song // radio picks, most recent last
+
+	Mutex    sync.Mutex
+	Generate sync.Mutex // serializes regeneration between playback and the update handler
+
+}
+
+// radioSource is a track whose mix is blended into the radio, weighted by how strongly it should steer it
+type radioSource struct {
+
+	Song   *Tidal.Song
+	Weight int
+
+}
+
+type radioCandidate struct {
+
+	Song  Tidal.Song
+	Score int
+
+}
+
+// remember marks a track as played this session; callers hold the mutex
+func (R *RadioState) remember(Song *Tidal.Song) bool {
+
+	if Song == nil || Song.TidalID == 0 {
+
+		return false
+
+	}
+
+	if R.Played == nil {
+
+		R.Played = make(map[int64]bool)
+		R.Finished = make(map[int64]bool)
+
+	}
+
+	R.Played[Song.TidalID] = true
+
+	return true
+
+}
+
+// Finish records a track that played to the end
+func (R *RadioState) Finish(Song *Tidal.Song) {
+
+	R.Mutex.Lock()
+	defer R.Mutex.Unlock()
+
+	if R.remember(Song) {
+
+		R.Finished[Song.TidalID] = true
+
+	}
+
+}
+
+// Skip records a track that was skipped before it ended
+func (R *RadioState) Skip(Song *Tidal.Song) {
+
+	R.Mutex.Lock()
+	defer R.Mutex.Unlock()
+
+	if !R.remember(Song) || R.Finished[Song.TidalID] {
+
+		return // replays of finished tracks keep their positive signal
+
+	}
+
+	R.Skipped = append(R.Skipped, Song.TidalID)
+
+	if len(R.Skipped) > RadioSkipMemory {
+
+		R.Skipped = R.Skipped[len(R.Skipped)-RadioSkipMemory:]
+
+	}
+
+}
+
+// SetSeed pins the radio to a seed; nil returns it to blending the session
+func (R *RadioState) SetSeed(Seed *RadioSeed) {
+
+	R.Mutex.Lock()
+	R.Seed = Seed
+	R.Mutex.Unlock()
+
+}
+
+// CurrentSeed returns the radio's explicit seed, or nil when it blends the session
+func (R *RadioState) CurrentSeed() *RadioSeed {
+
+	R.Mutex.Lock()
+	defer R.Mutex.Unlock()
+
+	return R.Seed
+
+}
+
+// Reset forgets the session, seed and listener signals
+func (R *RadioState) Reset() {
+
+	R.Mutex.Lock()
+
+	R.Seed = nil
+	R.Played = nil
+	R.Finished = nil
+	R.Skipped = nil
+	R.Picks = nil
+
+	R.Mutex.Unlock()
+
+}
+
+// ResolveRadioSeed searches Tidal for the artist or song a radio should start from
+func ResolveRadioSeed(Kind string, Query string) (*RadioSeed, error) {
+
+	Songs, ErrorSearching := Tidal.SearchSongs(Query)
+
+	if ErrorSearching != nil {
+
+		return nil, ErrorSearching
+
+	}
+
+	if len(Songs) == 0 {
+
+		return nil, errors.New("no results")
+
+	}
+
+	if Kind == RadioSeedSong {
+
+		return &RadioSeed{Kind: RadioSeedSong, ID: Songs[0].TidalID, Title: fmt.Sprintf("%s - %s", Songs[0].Title, strings.Join(Songs[0].Artists, ", "))}, nil
+
+	}
+
+	Match := Songs[0]
+
+	for _, Song := range Songs {
+
+		if len(Song.Artists) > 0 && strings.EqualFold(Song.Artists[0], strings.TrimSpace(Query)) {
+
+			Match = Song
+			break
+
+		}
+
+	}
+
+	if Match.ArtistID == 0 || len(Match.Artists) == 0 {
+
+		return nil, errors.New("no artist found")
+
+	}
+
+	return &RadioSeed{Kind: RadioSeedArtist, ID: Match.ArtistID, Title: Match.Artists[0]}, nil
+
+}
+
+// RegenerateSuggestions refills the autoplay radio by blending mixes from the seed or the session's recent tracks and requestors' favorites
+func (Q *Queue) RegenerateSuggestions() {
+
+	Guild := GetGuild(Q.ParentID, false)
+
+	if Guild == nil {
+
+		Utils.Logger.Warn("AutoPlay", fmt.Sprintf("RegenerateSuggestions called but Guild is nil for Queue %s", Q.ParentID.String()))
+		return
+
+	}
+
+	if !Guild.Features.Autoplay {
+
+		Utils.Logger.Warn("AutoPlay", fmt.Sprintf("RegenerateSuggestions called but AutoPlay is disabled for Queue %s", Q.ParentID.String()))
+		return
+
+	}
+
+	Q.Radio.Generate.Lock()
+	defer Q.Radio.Generate.Unlock()
+
+	if len(Q.Suggestions) >= 2 {
+
+		return // refilled while this call was waiting
+
+	}
+
+	Sources, Candidates := Q.radioSources()
+
+	if len(Sources) == 0 && len(Candidates) == 0 {
+
+		Utils.Logger.Warn("AutoPlay", fmt.Sprintf("No seed song available for Queue %s (Previous: %d, Current: %v)", Q.ParentID.String(), len(Q.Previous), Q.Current != nil))
+		return
+
+	}
+
+	Utils.Logger.Info("AutoPlay", fmt.Sprintf("Regenerating suggestions for Queue %s from %d seed tracks", Q.ParentID.String(), len(Sources)))
+
+	Picks := Q.pickRadioSongs(blendRadioMixes(Sources, Candidates))
+
+	if len(Picks) == 0 {
+
+		// Everything near the recent tracks has been played; widen to older ones so the radio never runs dry
+
+		Picks = Q.pickRadioSongs(blendRadioMixes(Q.fallbackRadioSources(), nil))
+
+	}
+
+	Q.Radio.Mutex.Lock()
+
+	for _, Song := range Picks {
+
+		Song.Internal.Suggested = true
+		Song.Internal.Requestor = "AutoPlay"
+
+		Q.Radio.remember(Song)
+		Q.Radio.Picks = append(Q.Radio.Picks, Song)
+
+	}
+
+	if len(Q.Radio.Picks) > RadioArtistWindow {
+
+		Q.Radio.Picks = Q.Radio.Picks[len(Q.Radio.Picks)-RadioArtistWindow:]
+
+	}
+
+	Q.Radio.Mutex.Unlock()
+
+	Q.Suggestions = append(Q.Suggestions, Picks...)
+
+	Utils.Logger.Info("AutoPlay", fmt.Sprintf("Generated %d suggestions for Queue %s", len(Picks), Q.ParentID.String()))
+
+}
+
+// radioSources picks the tracks whose mixes are blended, plus songs offered directly (an artist seed's top tracks)
+func (Q *Queue) radioSources() ([]radioSource, []radioCandidate) {
+
+	Seed := Q.Radio.CurrentSeed()
+
+	var Sources []radioSource
+	var Candidates []radioCandidate
+
+	if Seed != nil {
+
+		switch Seed.Kind {
+
+		case RadioSeedSong:
+
+			Sources = append(Sources, radioSource{Song: &Tidal.Song{TidalID: Seed.ID}, Weight: radioSeedWeight})
+
+		case RadioSeedArtist:
+
+			TopTracks, ErrorFetching := Tidal.FetchArtistTopTracks(Seed.ID)
+
+			if ErrorFetching != nil {
+
+				Utils.Logger.Error("Tidal API", fmt.Sprintf("Error fetching artist top tracks for Queue %s: %s", Q.ParentID.String(), ErrorFetching.Error()))
+
+			}
+
+			for Index, Song := range TopTracks {
+
+				if Index == 0 {
+
+					Sources = append(Sources, radioSource{Song: &TopTracks[0], Weight: radioSeedWeight})
+
+				}
+
+				Candidates = append(Candidates, radioCandidate{Song: Song, Score: radioSeedWeight})
+
+			}
+
+		}
+
+	}
+
+	Q.Radio.Mutex.Lock()
+	Skipped := slices.Clone(Q.Radio.Skipped)
+	Finished := Q.Radio.Finished
+	Q.Radio.Mutex.Unlock()
+
+	// The session's most recent tracks, newest first; skipped ones are a negative signal and never seed
+
+	Recent := append(slices.Clone(Q.Previous), Q.Current)
+	Requestors := []string{}
+
+	for Index := len(Recent) - 1; Index >= 0 && len(Sources) < RadioSeedTracks; Index-- {
+
+		Song := Recent[Index]
+
+		if Song == nil || Song.TidalID == 0 || slices.Contains(Skipped, Song.TidalID) {
+
+			continue
+
+		}
+
+		if Seed != nil && !Song.Internal.Suggested {
+
+			continue // a seeded radio only follows its own picks, not unrelated songs queued before it
+
+		}
+
+		Weight := 1
+
+		if Finished[Song.TidalID] {
+
+			Weight = radioFinishedWeight
+
+		}
+
+		Sources = append(Sources, radioSource{Song: Song, Weight: Weight})
+
+		if !Song.Internal.Suggested && !slices.Contains(Requestors, Song.Internal.Requestor) {
+
+			Requestors = append(Requestors, Song.Internal.Requestor)
+
+		}
+
+	}
+
+	return append(Sources, requestorFavoriteSources(Requestors)...), Candidates
+
+}
+
+// requestorFavoriteSources picks a random liked song (or top favorite) from each recent requestor
+func requestorFavoriteSources(Requestors []string) []radioSource {
+
+	Sources := []radioSource{}
+
+	for _, Requestor := range Requestors {
+
+		if len(Sources) >= RadioFavoriteSeeds {
+
+			break
+
+		}
+
+		UserID, IsUser := RequestorUserID(Requestor)
+
+		if !IsUser {
+
+			continue // web usernames have no favorites
+
+		}
+
+		User, ErrorFetching := GetUser(UserID.String())
+
+		if ErrorFetching != nil {
+
+			continue
+
+		}
+
+		if len(User.Liked) > 0 {
+
+			Liked := User.Liked[rand.Intn(len(User.Liked))].Song
+			Sources = append(Sources, radioSource{Song: &Liked, Weight: 1})
+
+			continue
+
+		}
+
+		Favorites := User.GetTopFavorites(5)
+
+		if len(Favorites) == 0 {
+
+			continue
+
+		}
+
+		Prefix := "Synthara-Redux:" + APIs.URITypeTidalSong + ":"
+		URI := Favorites[rand.Intn(len(Favorites))]
+
+		if TidalID, ErrorParsing := strconv.ParseInt(strings.TrimPrefix(URI, Prefix), 10, 64); strings.HasPrefix(URI, Prefix) && ErrorParsing == nil {
+
+			Sources = append(Sources, radioSource{Song: &Tidal.Song{TidalID: TidalID}, Weight: 1})
+
+		}
+
+	}
+
+	return Sources
+
+}
+
+// fallbackRadioSources samples older session tracks when the recent ones have nothing new to offer
+func (Q *Queue) fallbackRadioSources() []radioSource {
+
+	Q.Radio.Mutex.Lock()
+	Skipped := slices.Clone(Q.Radio.Skipped)
+	Q.Radio.Mutex.Unlock()
+
+	Pool := []*Tidal.Song{}
+
+	for _, Song := range Q.Previous {
+
+		if Song.TidalID != 0 && !slices.Contains(Skipped, Song.TidalID) {
+
+			Pool = append(Pool, Song)
+
+		}
+
+	}
+
+	rand.Shuffle(len(Pool), func(i, j int) { Pool[i], Pool[j] = Pool[j], Pool[i] })
+
+	Sources := []radioSource{}
+
+	for _, Song := range Pool[:min(len(Pool), RadioSeedTracks)] {
+
+		Sources = append(Sources, radioSource{Song: Song, Weight: 1})
+
+	}
+
+	return Sources
+
+}
+
+// blendRadioMixes merges the mixes of every source; songs appearing in several mixes score higher
+func blendRadioMixes(Sources []radioSource, Candidates []radioCandidate) []radioCandidate {
+
+	Blended := map[int64]*radioCandidate{}
+	Order := []int64{}
+
+	Add := func(Song Tidal.Song, Weight int) {
+
+		if Existing, Exists := Blended[Song.TidalID]; Exists {
+
+			Existing.Score += Weight
+			return
+
+		}
+
+		Blended[Song.TidalID] = &radioCandidate{Song: Song, Score: Weight}
+		Order = append(Order, Song.TidalID)
+
+	}
+
+	for _, Candidate := range Candidates {
+
+		Add(Candidate.Song, Candidate.Score)
+
+	}
+
+	for _, Source := range Sources {
+
+		MixID := Source.Song.MixID
+
+		if MixID == "" {
+
+			var ErrorFetching error
+
+			MixID, ErrorFetching = Tidal.FetchTrackMix(Source.Song.TidalID)
+
+			if ErrorFetching != nil {
+
+				Utils.Logger.Error("Tidal API", fmt.Sprintf("Error fetching track mix for %d: %s", Source.Song.TidalID, ErrorFetching.Error()))
+				continue
+
+			}
+
+		}
+
+		Songs, ErrorFetching := Tidal.FetchMixItems(MixID)
+
+		if ErrorFetching != nil {
+
+			Utils.Logger.Error("Tidal API", fmt.Sprintf("Error fetching mix items for %s: %s", MixID, ErrorFetching.Error()))
+			continue
+
+		}
+
+		for _, Song := range Songs {
+
+			if Song.TidalID != 0 && Song.TidalID != Source.Song.TidalID {
+
+				Add(Song, Source.Weight)
+
+			}
+
+		}
+
+	}
+
+	Result := make([]radioCandidate, 0, len(Order))
+
+	for _, TidalID := range Order {
+
+		Result = append(Result, *Blended[TidalID])
+
+	}
+
+	return Result
+
+}
+
+// pickRadioSongs drops anything played, queued or recently skipped, then takes the best-scored songs while capping each artist's share
+func (Q *Queue) pickRadioSongs(Candidates []radioCandidate) []*Tidal.Song {
+
+	Excluded := map[int64]bool{}
+
+	for _, Songs := range [][]*Tidal.Song{Q.Previous, {Q.Current}, Q.Upcoming, Q.Suggestions} {
+
+		for _, Song := range Songs {
+
+			if Song != nil {
+
+				Excluded[Song.TidalID] = true
+
+			}
+
+		}
+
+	}
+
+	Q.Radio.Mutex.Lock()
+
+	for TidalID := range Q.Radio.Played {
+
+		Excluded[TidalID] = true
+
+	}
+
+	for _, TidalID := range Q.Radio.Skipped {
+
+		Excluded[TidalID] = true
+
+	}
+
+	ArtistPicks := map[string]int{}
+
+	for _, Song := range Q.Radio.Picks {
+
+		ArtistPicks[radioArtistKey(Song)]++
+
+	}
+
+	Q.Radio.Mutex.Unlock()
+
+	Remaining := []radioCandidate{}
+
+	for _, Candidate := range Candidates {
+
+		if !Excluded[Candidate.Song.TidalID] {
+
+			Remaining = append(Remaining, Candidate)
+
+		}
+
+	}
+
+	rand.Shuffle(len(Remaining), func(i, j int) { Remaining[i], Remaining[j] = Remaining[j], Remaining[i] })
+
+	slices.SortStableFunc(Remaining, func(A radioCandidate, B radioCandidate) int { return B.Score - A.Score })
+
+	Picks := []*Tidal.Song{}
+
+	for Index := range Remaining {
+
+		if len(Picks) >= RadioBatchSize {
+
+			break
+
+		}
+
+		Artist := radioArtistKey(&Remaining[Index].Song)
+
+		if ArtistPicks[Artist] >= RadioMaxArtistShare {
+
+			continue
+
+		}
+
+		ArtistPicks[Artist]++
+		Picks = append(Picks, &Remaining[Index].Song)
+
+	}
+
+	if len(Picks) == 0 && len(Remaining) > 0 {
+
+		Picks = append(Picks, &Remaining[0].Song) // every candidate is capped; one pick keeps the radio going
+
+	}
+
+	return Picks
+
+}
+
+func radioArtistKey(Song *Tidal.Song) string {
+
+	if Song.ArtistID != 0 {
+
+		return strconv.FormatInt(Song.ArtistID, 10)
+
+	}
+
+	if len(Song.Artists) > 0 {
+
+		return strings.ToLower(Song.Artists[0])
+
+	}
+
+	return ""
+
+}
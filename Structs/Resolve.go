@@ -0,0 +1,469 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs"
+	"Synthara-Redux/APIs/Apple"
+	"Synthara-Redux/APIs/Spotify"
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/APIs/YouTube"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+
+	ContentAlbum    = "Album"
+	ContentPlaylist = "Playlist"
+	ContentArtist   = "Artist"
+
+)
+
+// URIContent is what a URI resolves to: the songs known right away and, for large collections, a loader for the rest
+type URIContent struct {
+
+	Songs []Tidal.Song
+	More  func() ([]Tidal.Song, int, error) // fetches the songs after Songs and how many tracks failed to resolve; nil when Songs is complete
+
+	Kind  string // ContentAlbum, ContentPlaylist or ContentArtist; empty for single songs and system mixes
+	Name  string
+	Total int    // tracks in the collection, for failure notices
+
+}
+
+// All returns every song in the content, fetching the rest of a large collection unless Limit songs are already known; Limit 0 means no limit
+func (C URIContent) All(Limit int) ([]Tidal.Song, error) {
+
+	Songs := C.Songs
+
+	if C.More != nil && (Limit <= 0 || len(Songs) < Limit) {
+
+		Rest, _, FetchError := C.More()
+
+		if FetchError != nil {
+
+			return nil, FetchError
+
+		}
+
+		Songs = append(Songs, Rest...)
+
+	}
+
+	if Limit > 0 && len(Songs) > Limit {
+
+		Songs = Songs[:Limit]
+
+	}
+
+	return Songs, nil
+
+}
+
+// ResolveURI resolves a Synthara-Redux URI to its songs; it is the single place providers are mapped to content
+func ResolveURI(URI string) (URIContent, error) {
+
+	Type, ID, ErrorParsing := APIs.ParseURI(URI)
+
+	if ErrorParsing != nil {
+
+		return URIContent{}, ErrorParsing
+
+	}
+
+	single := func(Song Tidal.Song, Error error) (URIContent, error) {
+
+		if Error != nil {
+
+			return URIContent{}, Error
+
+		}
+
+		return URIContent{Songs: []Tidal.Song{Song}}, nil
+
+	}
+
+	switch Type {
+
+	// Plain-text search
+
+	case APIs.URITypeNone:
+
+		SearchResults, SearchErr := Tidal.SearchSongs(ID)
+
+		if SearchErr != nil || len(SearchResults) == 0 {
+
+			return URIContent{}, errors.New("no search results found")
+
+		}
+
+		return URIContent{Songs: SearchResults[:1]}, nil
+
+	// Tidal (Default)
+
+	case APIs.URITypeTidalSong:
+
+		TidalID, ParseErr := strconv.ParseInt(ID, 10, 64)
+
+		if ParseErr != nil {
+
+			return URIContent{}, fmt.Errorf("invalid Tidal ID: %s", ID)
+
+		}
+
+		return single(Tidal.GetSong(TidalID))
+
+	case APIs.URITypeTidalAlbum:
+
+		AlbumID, ParseErr := strconv.ParseInt(ID, 10, 64)
+
+		if ParseErr != nil {
+
+			return URIContent{}, fmt.Errorf("invalid Tidal album ID: %s", ID)
+
+		}
+
+		AlbumTracks, FetchErr := Tidal.FetchAlbumTracks(AlbumID)
+
+		if FetchErr != nil || len(AlbumTracks) == 0 {
+
+			return URIContent{}, errors.New("could not fetch album tracks")
+
+		}
+
+		return URIContent{Songs: AlbumTracks, Kind: ContentAlbum, Name: AlbumTracks[0].Album, Total: len(AlbumTracks)}, nil
+
+	case APIs.URITypeTidalPlaylist:
+
+		PlaylistID, ParseErr := strconv.ParseInt(ID, 10, 64)
+
+		if ParseErr != nil {
+
+			return URIContent{}, fmt.Errorf("invalid Tidal playlist ID: %s", ID)
+
+		}
+
+		PlaylistTracks, FetchErr := Tidal.FetchPlaylistTracks(PlaylistID)
+
+		if FetchErr != nil || len(PlaylistTracks) == 0 {
+
+			return URIContent{}, errors.New("could not fetch playlist tracks")
+
+		}
+
+		return URIContent{Songs: PlaylistTracks, Kind: ContentPlaylist, Total: len(PlaylistTracks)}, nil
+
+	// System mixes
+
+	case APIs.URITypeFavorites:
+
+		User, UserErr := GetUser(ID) // ID from URI is UserID
+
+		if UserErr != nil {
+
+			return URIContent{}, UserErr
+
+		}
+
+		Songs := User.FavoriteSongs()
+
+		if len(Songs) == 0 {
+
+			return URIContent{}, errors.New("no favorites found")
+
+		}
+
+		return systemMix(Songs, "Favorites", "favorites:"+ID), nil
+
+	case APIs.URITypeForYou:
+
+		User, UserErr := GetUser(ID)
+
+		if UserErr != nil {
+
+			return URIContent{}, UserErr
+
+		}
+
+		Songs, MixErr := User.GenerateForYouMix()
+
+		if MixErr != nil {
+
+			return URIContent{}, MixErr
+
+		}
+
+		return systemMix(Songs, "For You", "foryou:"+ID), nil
+
+	case APIs.URITypeSuggestions:
+
+		User, UserErr := GetUser(ID)
+
+		if UserErr != nil {
+
+			return URIContent{}, UserErr
+
+		}
+
+		if User.MostRecentMix == "" {
+
+			return URIContent{}, errors.New("no recent mix found")
+
+		}
+
+		Songs, FetchErr := Tidal.FetchMixItems(User.MostRecentMix)
+
+		if FetchErr != nil || len(Songs) == 0 {
+
+			return URIContent{}, errors.New("could not fetch suggestions")
+
+		}
+
+		if len(Songs) > 10 {
+
+			Songs = Songs[:10]
+
+		}
+
+		return systemMix(Songs, "Suggestions", "suggestions:"+ID), nil
+
+	// YouTube
+
+	case APIs.URITypeYouTubeVideo:
+
+		ResolvedSong, _, YouTubeFetchErr := YouTube.VideoIDToSong(ID)
+
+		return single(ResolvedSong, YouTubeFetchErr)
+
+	case APIs.URITypeYouTubePlaylist:
+
+		FirstSong, YouTubePlaylist, FirstSongError := YouTube.PlaylistIDToFirstSong(ID)
+
+		if FirstSongError != nil {
+
+			return URIContent{}, FirstSongError
+
+		}
+
+		return URIContent{
+
+			Songs: []Tidal.Song{FirstSong},
+			More: func() ([]Tidal.Song, int, error) {
+
+				Songs, FailedCount, _, FetchError := YouTube.PlaylistIDToAllSongs(YouTubePlaylist, true) // ignores first
+				return Songs, FailedCount, FetchError
+
+			},
+
+			Kind: ContentPlaylist,
+			Name: YouTubePlaylist.Title,
+			Total: len(YouTubePlaylist.Videos),
+
+		}, nil
+
+	// YouTube Music
+
+	case APIs.URITypeYTMusicAlbum:
+
+		FirstSong, YouTubeMusicAlbum, FirstSongError := YouTube.MusicAlbumIDToFirstSong(ID)
+
+		if FirstSongError != nil {
+
+			return URIContent{}, FirstSongError
+
+		}
+
+		return URIContent{
+
+			Songs: []Tidal.Song{FirstSong},
+			More: func() ([]Tidal.Song, int, error) {
+
+				Songs, FailedCount, _, FetchError := YouTube.MusicAlbumIDToAllSongs(YouTubeMusicAlbum, true) // ignores first
+				return Songs, FailedCount, FetchError
+
+			},
+
+			Kind: ContentAlbum,
+			Name: YouTubeMusicAlbum.Title,
+			Total: len(YouTubeMusicAlbum.Videos),
+
+		}, nil
+
+	case APIs.URITypeYTMusicArtist:
+
+		ArtistSongs, ArtistFetchErr := YouTube.MusicArtistIDToSongs(ID)
+
+		if ArtistFetchErr != nil || len(ArtistSongs) == 0 {
+
+			return URIContent{}, errors.New("could not fetch artist songs")
+
+		}
+
+		Name := ""
+
+		if len(ArtistSongs[0].Artists) > 0 {
+
+			Name = ArtistSongs[0].Artists[0]
+
+		}
+
+		return URIContent{Songs: ArtistSongs, Kind: ContentArtist, Name: Name, Total: len(ArtistSongs)}, nil
+
+	// Spotify
+
+	case APIs.URITypeSPSong:
+
+		ResolvedSong, _, SpotifyFetchErr := Spotify.SpotifyIDToSong(ID)
+
+		return single(ResolvedSong, SpotifyFetchErr)
+
+	case APIs.URITypeSPAlbum:
+
+		FirstSong, SpotifyAlbum, FirstSongError := Spotify.SpotifyAlbumToFirstSong(ID)
+
+		if FirstSongError != nil {
+
+			return URIContent{}, FirstSongError
+
+		}
+
+		return URIContent{
+
+			Songs: []Tidal.Song{FirstSong},
+			More: func() ([]Tidal.Song, int, error) {
+
+				Songs, _, FetchError := Spotify.SpotifyAlbumToAllSongs(SpotifyAlbum, true) // ignores first
+				return Songs, 0, FetchError
+
+			},
+
+			Kind: ContentAlbum,
+			Name: SpotifyAlbum.Name,
+
+		}, nil
+
+	case APIs.URITypeSPPlaylist:
+
+		FirstSong, SpotifyPlaylist, FirstSongError := Spotify.SpotifyPlaylistToFirstSong(ID)
+
+		if FirstSongError != nil {
+
+			return URIContent{}, FirstSongError
+
+		}
+
+		return URIContent{
+
+			Songs: []Tidal.Song{FirstSong},
+			More: func() ([]Tidal.Song, int, error) {
+
+				Songs, _, FetchError := Spotify.SpotifyPlaylistToAllSongs(SpotifyPlaylist, true) // ignores first
+				return Songs, 0, FetchError
+
+			},
+
+			Kind: ContentPlaylist,
+			Name: SpotifyPlaylist.Name,
+
+		}, nil
+
+	// Apple Music
+
+	case APIs.URITypeAMSong:
+
+		ResolvedSong, _, AppleMusicFetchErr := Apple.AppleMusicIDToSong(ID)
+
+		return single(ResolvedSong, AppleMusicFetchErr)
+
+	case APIs.URITypeAMAlbum:
+
+		FirstSong, AppleMusicAlbum, FirstSongError := Apple.AppleMusicAlbumToFirstSong(ID)
+
+		if FirstSongError != nil {
+
+			return URIContent{}, FirstSongError
+
+		}
+
+		return URIContent{
+
+			Songs: []Tidal.Song{FirstSong},
+			More: func() ([]Tidal.Song, int, error) {
+
+				Songs, _, FetchError := Apple.AppleMusicAlbumToAllSongs(AppleMusicAlbum, true) // ignores first
+				return Songs, 0, FetchError
+
+			},
+
+			Kind: ContentAlbum,
+			Name: AppleMusicAlbum.Attributes.Name,
+
+		}, nil
+
+	case APIs.URITypeAMPlaylist:
+
+		FirstSong, AppleMusicPlaylist, FirstSongError := Apple.AppleMusicPlaylistToFirstSong(ID)
+
+		if FirstSongError != nil {
+
+			return URIContent{}, FirstSongError
+
+		}
+
+		return URIContent{
+
+			Songs: []Tidal.Song{FirstSong},
+			More: func() ([]Tidal.Song, int, error) {
+
+				Songs, _, FetchError := Apple.AppleMusicPlaylistToAllSongs(AppleMusicPlaylist, true) // ignores first
+				return Songs, 0, FetchError
+
+			},
+
+			Kind: ContentPlaylist,
+			Name: AppleMusicPlaylist.Attributes.Name,
+
+		}, nil
+
+	case APIs.URITypeDirectMedia:
+
+		Adapted, AdaptErr := Tidal.SongFromDirectURL(ID)
+
+		if AdaptErr != nil {
+
+			return URIContent{}, AdaptErr
+
+		}
+
+		return URIContent{Songs: []Tidal.Song{*Adapted}}, nil
+
+	}
+
+	return URIContent{}, fmt.Errorf("unsupported URI type: %s", Type)
+
+}
+
+// systemMix tags generated songs with playlist metadata so the queue groups them
+func systemMix(Songs []Tidal.Song, Name string, ID string) URIContent {
+
+	PlaylistMeta := Tidal.PlaylistMeta{
+
+		Name: Name,
+		Platform: "System",
+
+		Total: len(Songs),
+
+		ID: ID,
+
+	}
+
+	for i := range Songs {
+
+		Songs[i].Internal.Playlist = PlaylistMeta
+		Songs[i].Internal.Playlist.Index = i
+
+	}
+
+	return URIContent{Songs: Songs, Name: Name, Total: len(Songs)}
+
+}
@@ -125,6 +125,18 @@ func SnapshotFromQueue(Queue *Queue) (SavedQueueSnapshot, error) {
 
 func (G *Guild) ApplySavedQueue(Snapshot SavedQueueSnapshot) {
 
+	G.Queue.Track(OperationLoad, func() bool {
+
+		G.replaceQueue(CloneSongs(Snapshot.Previous), CloneSong(Snapshot.Current), CloneSongs(Snapshot.Upcoming))
+		return true
+
+	})
+
+}
+
+// replaceQueue swaps in a whole queue, stopping the current stream and starting the new current song
+func (G *Guild) replaceQueue(Previous []*Tidal.Song, Current *Tidal.Song, Upcoming []*Tidal.Song) {
+
 	G.StreamerMutex.Lock()
 	defer G.StreamerMutex.Unlock()
 
@@ -145,9 +157,9 @@ func (G *Guild) ApplySavedQueue(Snapshot SavedQueueSnapshot) {
 
 	}
 
-	G.Queue.Previous = CloneSongs(Snapshot.Previous)
-	G.Queue.Current = CloneSong(Snapshot.Current)
-	G.Queue.Upcoming = CloneSongs(Snapshot.Upcoming)
+	G.Queue.Previous = Previous
+	G.Queue.Current = Current
+	G.Queue.Upcoming = Upcoming
 	G.Queue.Suggestions = []*Tidal.Song{}
 
 	G.Queue.State = StateIdle
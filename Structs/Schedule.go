@@ -0,0 +1,651 @@
+package Structs
+
+import (
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Utils"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+
+	MaxSchedulesPerGuild = 10
+
+	ScheduleSourceQueue    = "queue"
+	ScheduleSourcePlaylist = "playlist"
+
+	ScheduleTimeLayout = "2006-01-02 15:04"
+
+	MinScheduleInterval = time.Hour        // recurring jobs may not fire more often than this
+	MaxScheduleLateness = 15 * time.Minute // jobs missed by more than this (e.g. during downtime) are skipped
+
+	schedulerInterval = 30 * time.Second
+
+)
+
+var (
+
+	ErrScheduleLimit     = errors.New("schedule limit reached")
+	ErrScheduleNotFound  = errors.New("schedule not found")
+	ErrScheduleTime      = errors.New("invalid schedule time")
+	ErrSchedulePast      = errors.New("schedule time is in the past")
+	ErrScheduleTimezone  = errors.New("unknown timezone")
+	ErrScheduleFrequent  = errors.New("schedule runs too often")
+	ErrScheduleNoTrigger = errors.New("schedule needs a time or a cron expression")
+
+)
+
+// ScheduledJob joins a voice channel at set times and loads a saved queue or personal playlist
+type ScheduledJob struct {
+
+	ID        string `bson:"_id" json:"id"`
+	GuildID   string `bson:"guild_id" json:"guild_id"`
+	CreatorID string `bson:"creator_id" json:"creator_id"`
+
+	VoiceChannelID string `bson:"voice_channel_id" json:"voice_channel_id"`
+	TextChannelID  string `bson:"text_channel_id" json:"text_channel_id"`
+
+	SourceKind string `bson:"source_kind" json:"source_kind"` // ScheduleSourceQueue or ScheduleSourcePlaylist
+	SourceID   string `bson:"source_id" json:"source_id"`     // saved queue name or playlist ID
+	SourceName string `bson:"source_name" json:"source_name"`
+
+	Cron     string `bson:"cron,omitempty" json:"cron,omitempty"` // empty for one-off jobs
+	Timezone string `bson:"timezone" json:"timezone"`
+
+	Volume     *int `bson:"volume,omitempty" json:"volume,omitempty"`
+	SpeedMilli *int `bson:"speed_milli,omitempty" json:"speed_milli,omitempty"`
+	Reverb     *int `bson:"reverb,omitempty" json:"reverb,omitempty"`
+
+	Announcement string `bson:"announcement,omitempty" json:"announcement,omitempty"`
+
+	NextRun   time.Time `bson:"next_run" json:"next_run"`
+	LastRun   time.Time `bson:"last_run,omitempty" json:"last_run,omitempty"`
+	LastError string    `bson:"last_error,omitempty" json:"last_error,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+
+}
+
+var SchedulerOnce sync.Once
+
+func schedulesCollection() *mongo.Collection {
+
+	return Globals.Database.Collection("Schedules")
+
+}
+
+// LoadScheduleLocation resolves an IANA timezone name, defaulting to UTC
+func LoadScheduleLocation(Name string) (*time.Location, error) {
+
+	if strings.TrimSpace(Name) == "" {
+
+		return time.UTC, nil
+
+	}
+
+	Location, Error := time.LoadLocation(strings.TrimSpace(Name))
+
+	if Error != nil {
+
+		return nil, ErrScheduleTimezone
+
+	}
+
+	return Location, nil
+
+}
+
+// Recurring reports whether the job repeats on a cron expression
+func (J *ScheduledJob) Recurring() bool {
+
+	return J.Cron != ""
+
+}
+
+// PlanSchedule validates a one-off time or cron expression and returns the first run
+func PlanSchedule(At string, Cron string, Timezone string) (time.Time, error) {
+
+	Location, Error := LoadScheduleLocation(Timezone)
+
+	if Error != nil {
+
+		return time.Time{}, Error
+
+	}
+
+	Now := time.Now()
+
+	if strings.TrimSpace(Cron) != "" {
+
+		Schedule, Error := ParseCron(Cron)
+
+		if Error != nil {
+
+			return time.Time{}, Error
+
+		}
+
+		First, Found := Schedule.Next(Now, Location)
+
+		if !Found {
+
+			return time.Time{}, ErrCronSyntax
+
+		}
+
+		if Gap := Schedule.ShortestGap(Now, Location, 50); Gap > 0 && Gap < MinScheduleInterval {
+
+			return time.Time{}, ErrScheduleFrequent
+
+		}
+
+		return First, nil
+
+	}
+
+	if strings.TrimSpace(At) == "" {
+
+		return time.Time{}, ErrScheduleNoTrigger
+
+	}
+
+	Moment, Error := time.ParseInLocation(ScheduleTimeLayout, strings.TrimSpace(At), Location)
+
+	if Error != nil {
+
+		return time.Time{}, ErrScheduleTime
+
+	}
+
+	if !Moment.After(Now) {
+
+		return time.Time{}, ErrSchedulePast
+
+	}
+
+	return Moment, nil
+
+}
+
+// ListSchedules returns a guild's jobs ordered by their next run
+func ListSchedules(GuildID string) ([]ScheduledJob, error) {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Cursor, Error := schedulesCollection().Find(Context, bson.M{"guild_id": GuildID})
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	Jobs := []ScheduledJob{}
+
+	if Error := Cursor.All(Context, &Jobs); Error != nil {
+
+		return nil, Error
+
+	}
+
+	sort.Slice(Jobs, func(i, j int) bool {
+
+		return Jobs[i].NextRun.Before(Jobs[j].NextRun)
+
+	})
+
+	return Jobs, nil
+
+}
+
+// GetSchedule returns one of a guild's jobs by ID
+func GetSchedule(GuildID string, ID string) (*ScheduledJob, error) {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Job := &ScheduledJob{}
+
+	Error := schedulesCollection().FindOne(Context, bson.M{"_id": strings.ToUpper(strings.TrimSpace(ID)), "guild_id": GuildID}).Decode(Job)
+
+	if errors.Is(Error, mongo.ErrNoDocuments) {
+
+		return nil, ErrScheduleNotFound
+
+	}
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	return Job, nil
+
+}
+
+// CreateSchedule stores a new job, enforcing the per-guild limit
+func CreateSchedule(Job *ScheduledJob) error {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Count, Error := schedulesCollection().CountDocuments(Context, bson.M{"guild_id": Job.GuildID})
+
+	if Error != nil {
+
+		return Error
+
+	}
+
+	if Count >= MaxSchedulesPerGuild {
+
+		return ErrScheduleLimit
+
+	}
+
+	Job.ID = GenerateShareCode()
+	Job.CreatedAt = time.Now()
+
+	_, Error = schedulesCollection().InsertOne(Context, Job)
+
+	return Error
+
+}
+
+// UpdateSchedule replaces a stored job after an edit
+func UpdateSchedule(Job *ScheduledJob) error {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Result, Error := schedulesCollection().ReplaceOne(Context, bson.M{"_id": Job.ID, "guild_id": Job.GuildID}, Job)
+
+	if Error != nil {
+
+		return Error
+
+	}
+
+	if Result.MatchedCount == 0 {
+
+		return ErrScheduleNotFound
+
+	}
+
+	return nil
+
+}
+
+// DeleteSchedule cancels a job
+func DeleteSchedule(GuildID string, ID string) error {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Result, Error := schedulesCollection().DeleteOne(Context, bson.M{"_id": strings.ToUpper(strings.TrimSpace(ID)), "guild_id": GuildID})
+
+	if Error != nil {
+
+		return Error
+
+	}
+
+	if Result.DeletedCount == 0 {
+
+		return ErrScheduleNotFound
+
+	}
+
+	return nil
+
+}
+
+// StartScheduler begins polling for due jobs; safe to call more than once
+func StartScheduler() {
+
+	SchedulerOnce.Do(func() {
+
+		go schedulerLoop()
+
+	})
+
+}
+
+func schedulerLoop() {
+
+	Ticker := time.NewTicker(schedulerInterval)
+	defer Ticker.Stop()
+
+	runDueSchedules() // pick up anything that came due while the bot was offline
+
+	for range Ticker.C {
+
+		runDueSchedules()
+
+	}
+
+}
+
+func runDueSchedules() {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer Cancel()
+
+	Now := time.Now()
+
+	Cursor, Error := schedulesCollection().Find(Context, bson.M{"next_run": bson.M{"$lte": Now}})
+
+	if Error != nil {
+
+		Utils.Logger.Error("Scheduler", fmt.Sprintf("Error loading due schedules: %s", Error.Error()))
+		return
+
+	}
+
+	Jobs := []ScheduledJob{}
+
+	if Error := Cursor.All(Context, &Jobs); Error != nil {
+
+		Utils.Logger.Error("Scheduler", fmt.Sprintf("Error decoding due schedules: %s", Error.Error()))
+		return
+
+	}
+
+	for _, Job := range Jobs {
+
+		if !claimSchedule(Job, Now) {
+
+			continue // another instance got there first, or the job was edited meanwhile
+
+		}
+
+		if Now.Sub(Job.NextRun) > MaxScheduleLateness {
+
+			Utils.Logger.Warn("Scheduler", fmt.Sprintf("Skipping schedule %s for guild %s; it was due at %s", Job.ID, Job.GuildID, Job.NextRun.Format(time.RFC3339)))
+
+			if !Job.Recurring() {
+
+				go notifyScheduleMissed(Job) // claiming deleted it, so say so rather than let it vanish
+
+			}
+
+			continue
+
+		}
+
+		go runSchedule(Job)
+
+	}
+
+}
+
+// claimSchedule advances a recurring job to its next run (or removes a one-off job) before it runs,
+// matching on the old next run so the same occurrence never fires twice
+func claimSchedule(Job ScheduledJob, Now time.Time) bool {
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Filter := bson.M{"_id": Job.ID, "next_run": Job.NextRun}
+
+	if !Job.Recurring() {
+
+		Result, Error := schedulesCollection().DeleteOne(Context, Filter)
+		return Error == nil && Result.DeletedCount == 1
+
+	}
+
+	Next := time.Time{}
+
+	if Schedule, Error := ParseCron(Job.Cron); Error == nil {
+
+		if Location, Error := LoadScheduleLocation(Job.Timezone); Error == nil {
+
+			Next, _ = Schedule.Next(Now, Location)
+
+		}
+
+	}
+
+	if Next.IsZero() {
+
+		Result, Error := schedulesCollection().DeleteOne(Context, Filter) // the expression can never match again
+		return Error == nil && Result.DeletedCount == 1
+
+	}
+
+	Result, Error := schedulesCollection().UpdateOne(Context, Filter, bson.M{"$set": bson.M{"next_run": Next, "last_run": Now}})
+
+	return Error == nil && Result.ModifiedCount == 1
+
+}
+
+// runSchedule joins the channel, loads the source, applies the audio settings and announces the session
+// notifyScheduleMissed tells a one-off job's text channel that it came due too long ago to start
+func notifyScheduleMissed(Job ScheduledJob) {
+
+	TextChannelID, Error := snowflake.Parse(Job.TextChannelID)
+
+	if Error != nil || TextChannelID == 0 {
+
+		return
+
+	}
+
+	Locale := Localizations.Default
+
+	if GuildID, ErrorParsing := snowflake.Parse(Job.GuildID); ErrorParsing == nil {
+
+		if Cached, Exists := Globals.DiscordClient.Caches.GuildCache().Get(GuildID); Exists {
+
+			Locale = discord.Locale(Cached.PreferredLocale).Code()
+
+		}
+
+	}
+
+	_, ErrorSending := Globals.DiscordClient.Rest.CreateMessage(TextChannelID, discord.NewMessageCreate().AddEmbeds(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Embeds.Notifications.ScheduleMissed.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Notifications", Locale),
+		Description: Localizations.GetFormat("Embeds.Notifications.ScheduleMissed.Description", Locale, Job.SourceName, Job.ID, Job.NextRun.Unix()),
+
+	})))
+
+	if ErrorSending != nil {
+
+		Utils.Logger.Error("Scheduler", fmt.Sprintf("Error sending missed schedule notice to guild %s: %s", Job.GuildID, ErrorSending.Error()))
+
+	}
+
+}
+
+func runSchedule(Job ScheduledJob) {
+
+	Error := startSchedule(Job)
+
+	if Error != nil {
+
+		Utils.Logger.Error("Scheduler", fmt.Sprintf("Schedule %s for guild %s failed: %s", Job.ID, Job.GuildID, Error.Error()))
+
+	}
+
+	if Job.Recurring() {
+
+		Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer Cancel()
+
+		Update := bson.M{"$unset": bson.M{"last_error": ""}}
+
+		if Error != nil {
+
+			Update = bson.M{"$set": bson.M{"last_error": Error.Error()}}
+
+		}
+
+		schedulesCollection().UpdateOne(Context, bson.M{"_id": Job.ID}, Update)
+
+	}
+
+}
+
+func startSchedule(Job ScheduledJob) error {
+
+	GuildID, Error := snowflake.Parse(Job.GuildID)
+
+	if Error != nil {
+
+		return Error
+
+	}
+
+	VoiceChannelID, Error := snowflake.Parse(Job.VoiceChannelID)
+
+	if Error != nil {
+
+		return Error
+
+	}
+
+	TextChannelID, _ := snowflake.Parse(Job.TextChannelID)
+
+	Guild := GetGuild(GuildID, true)
+
+	if Guild == nil {
+
+		return errors.New("guild is unavailable")
+
+	}
+
+	Locale := Guild.Locale.Code()
+
+	Announce := func(Embed discord.Embed) {
+
+		if TextChannelID == 0 {
+
+			return
+
+		}
+
+		if _, ErrorSending := Globals.DiscordClient.Rest.CreateMessage(TextChannelID, discord.NewMessageCreate().AddEmbeds(Embed)); ErrorSending != nil {
+
+			Utils.Logger.Error("Scheduler", fmt.Sprintf("Error sending schedule announcement to guild %s: %s", Job.GuildID, ErrorSending.Error()))
+
+		}
+
+	}
+
+	Failed := func(Reason error) error {
+
+		Announce(Utils.CreateEmbed(Utils.EmbedOptions{
+
+			Title:       Localizations.Get("Embeds.Notifications.ScheduleFailed.Title", Locale),
+			Author:      Localizations.Get("Embeds.Categories.Error", Locale),
+			Description: Localizations.GetFormat("Embeds.Notifications.ScheduleFailed.Description", Locale, Job.SourceName, Job.ID),
+			Color:       Utils.ERROR,
+
+		}))
+
+		return Reason
+
+	}
+
+	if Guild.VoiceConnection != nil && Guild.Channels.Voice != VoiceChannelID {
+
+		return Failed(errors.New("already playing in another channel"))
+
+	}
+
+	if Error := Guild.Connect(VoiceChannelID, TextChannelID); Error != nil {
+
+		return Failed(Error)
+
+	}
+
+	Requestor := fmt.Sprintf("<@%s>", Job.CreatorID)
+
+	switch Job.SourceKind {
+
+		case ScheduleSourcePlaylist:
+
+			Playlist, Error := GetPlaylistByID(Job.CreatorID, Job.SourceID)
+
+			if Error == nil {
+
+				Error = Guild.LoadPlaylist(Playlist, Requestor)
+
+			}
+
+			if Error != nil {
+
+				return Failed(Error)
+
+			}
+
+		default:
+
+			Snapshot, Error := GetSavedQueue(Job.GuildID, Job.SourceID)
+
+			if Error != nil {
+
+				return Failed(Error)
+
+			}
+
+			Guild.ApplySavedQueue(*Snapshot)
+
+	}
+
+	if Job.Volume != nil {
+
+		Guild.SetVolume(*Job.Volume)
+
+	}
+
+	if Job.SpeedMilli != nil {
+
+		Guild.SetSpeed(*Job.SpeedMilli)
+
+	}
+
+	if Job.Reverb != nil {
+
+		Guild.SetReverb(*Job.Reverb)
+
+	}
+
+	Guild.StartInactivityTimer()
+
+	Description := Localizations.GetFormat("Embeds.Notifications.ScheduleStarted.Description", Locale, Job.SourceName, VoiceChannelID.String())
+
+	if Job.Announcement != "" {
+
+		Description = Job.Announcement + "\n\n" + Description
+
+	}
+
+	Announce(Utils.CreateEmbed(Utils.EmbedOptions{
+
+		Title:       Localizations.Get("Embeds.Notifications.ScheduleStarted.Title", Locale),
+		Author:      Localizations.Get("Embeds.Categories.Notifications", Locale),
+		Description: Description,
+		Color:       Utils.PRIMARY,
+
+	}))
+
+	return nil
+
+}
@@ -0,0 +1,87 @@
+package Structs
+
+import (
+	"errors"
+)
+
+const SeekStepMs = 10000 // how far "rewind" or "skip ahead" moves without a time
+
+var ErrNothingPlaying = errors.New("nothing is playing")
+
+// Position returns how far into the current song playback is, in milliseconds
+func (G *Guild) Position() int64 {
+
+	G.StreamerMutex.Lock()
+	defer G.StreamerMutex.Unlock()
+
+	if G.Queue.PlaybackSession == nil || G.Queue.PlaybackSession.Streamer == nil {
+
+		return 0
+
+	}
+
+	return G.Queue.PlaybackSession.Streamer.Position()
+
+}
+
+// SeekTo moves playback of the current song to Ms milliseconds in, returning where it landed. Moving forward drops audio as it
+// decodes; moving back restarts the stream (resuming it when paused) and skips ahead from the start, since the streamer reads forward only
+func (G *Guild) SeekTo(Ms int64) (int64, error) {
+
+	Song := G.Queue.Current
+
+	if Song == nil {
+
+		return 0, ErrNothingPlaying
+
+	}
+
+	if Length := int64(Song.Duration.Seconds) * 1000; Length > 0 {
+
+		Ms = min(Ms, Length-1000) // stay inside the song so it still ends on its own
+
+	}
+
+	Ms = max(Ms, 0)
+
+	G.StreamerMutex.Lock()
+
+	Session := G.Queue.PlaybackSession
+
+	if Session != nil && Session.Streamer != nil && Ms >= Session.Streamer.Position() {
+
+		Session.Streamer.SkipAhead(Ms - Session.Streamer.Position())
+		G.StreamerMutex.Unlock()
+
+		G.Queue.SendToWebsockets(Event_ProgressUpdate, map[string]any{"Progress": Ms})
+
+		return Ms, nil
+
+	}
+
+	G.StreamerMutex.Unlock()
+
+	// Restarting goes through Guild.Play rather than Queue.Play: the old session's OnFinished sees it was replaced and returns
+	// without idling the queue, so no now-playing message is sent and the play log entry opened when the song began stays open
+
+	if ErrPlay := G.Play(Song); ErrPlay != nil {
+
+		return 0, ErrPlay
+
+	}
+
+	G.StreamerMutex.Lock()
+
+	if G.Queue.PlaybackSession != nil && G.Queue.PlaybackSession.Streamer != nil {
+
+		G.Queue.PlaybackSession.Streamer.SkipAhead(Ms)
+
+	}
+
+	G.StreamerMutex.Unlock()
+
+	G.Queue.SendToWebsockets(Event_ProgressUpdate, map[string]any{"Progress": Ms})
+
+	return Ms, nil
+
+}
@@ -0,0 +1,193 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+
+	ShuffleRandom = "random"
+	ShuffleSmart  = "smart" // spreads out artists, albums and requestors
+
+	spreadLookback = 50 // how many placed songs are searched when measuring spacing
+
+	recentlyPlayedWindow = 10 // previously played songs checked for repeats
+	recentlyPlayedGap    = 5  // recently played songs are kept out of this many leading positions
+
+	artistSpacingWeight    = 4
+	albumSpacingWeight     = 2
+	requestorSpacingWeight = 1
+
+)
+
+var ShuffleModes = []string{ShuffleRandom, ShuffleSmart}
+
+// ParseShuffleMode normalizes a shuffle mode, falling back to random
+func ParseShuffleMode(Mode string) string {
+
+	Mode = strings.ToLower(strings.TrimSpace(Mode))
+
+	if slices.Contains(ShuffleModes, Mode) {
+
+		return Mode
+
+	}
+
+	return ShuffleRandom
+
+}
+
+func (Q *Queue) shuffleMode() string {
+
+	Guild := GetGuild(Q.ParentID, false)
+
+	if Guild == nil {
+
+		return ShuffleRandom
+
+	}
+
+	return ParseShuffleMode(Guild.Features.ShuffleMode)
+
+}
+
+// recentSongs returns the most recently played songs, oldest first, ending with the current song
+func (Q *Queue) recentSongs() []*Tidal.Song {
+
+	Recent := slices.Clone(Q.Previous)
+
+	if len(Recent) > recentlyPlayedWindow {
+
+		Recent = Recent[len(Recent)-recentlyPlayedWindow:]
+
+	}
+
+	if Q.Current != nil {
+
+		Recent = append(Recent, Q.Current)
+
+	}
+
+	return Recent
+
+}
+
+// spreadSongs greedily reorders songs so each pick is as far as possible from the last song sharing its artist, album or requestor; ties keep the incoming (already shuffled) order
+func spreadSongs(Recent []*Tidal.Song, Songs []*Tidal.Song) []*Tidal.Song {
+
+	Placed := slices.Clone(Recent)
+	Remaining := slices.Clone(Songs)
+	Result := make([]*Tidal.Song, 0, len(Songs))
+
+	ArtistCounts := make(map[string]int) // songs left per artist, so larger groups can go first without rescanning
+
+	for _, Song := range Songs {
+
+		ArtistCounts[artistKey(Song)]++
+
+	}
+
+	for len(Remaining) > 0 {
+
+		Best, BestScore := 0, -1
+
+		for Index, Candidate := range Remaining {
+
+			Score := 0
+
+			if len(Result) >= recentlyPlayedGap || !slices.ContainsFunc(Recent, func(Played *Tidal.Song) bool { return sameSong(Candidate, Played) }) {
+
+				Score = spacingScore(Placed, Candidate)*len(Songs) + ArtistCounts[artistKey(Candidate)] // larger groups go first when spacing is equal
+
+			}
+
+			if Score > BestScore {
+
+				Best, BestScore = Index, Score
+
+			}
+
+		}
+
+		ArtistCounts[artistKey(Remaining[Best])]--
+
+		Result = append(Result, Remaining[Best])
+		Placed = append(Placed, Remaining[Best])
+		Remaining = slices.Delete(Remaining, Best, Best+1)
+
+	}
+
+	return Result
+
+}
+
+// spacingScore weighs how far back the last song sharing the candidate's artist, album and requestor was placed
+func spacingScore(Placed []*Tidal.Song, Candidate *Tidal.Song) int {
+
+	return artistSpacingWeight*spacing(Placed, Candidate, sameArtist) +
+		albumSpacingWeight*spacing(Placed, Candidate, sameAlbum) +
+		requestorSpacingWeight*spacing(Placed, Candidate, sameRequestor)
+
+}
+
+func spacing(Placed []*Tidal.Song, Candidate *Tidal.Song, Matches func(A *Tidal.Song, B *Tidal.Song) bool) int {
+
+	for Distance := 1; Distance <= spreadLookback && Distance <= len(Placed); Distance++ {
+
+		if Matches(Candidate, Placed[len(Placed)-Distance]) {
+
+			return Distance
+
+		}
+
+	}
+
+	return spreadLookback + 1
+
+}
+
+// artistKey identifies a song's artist for counting, by ID when known and otherwise by the first artist name
+func artistKey(Song *Tidal.Song) string {
+
+	if Song.ArtistID != 0 {
+
+		return strconv.FormatInt(Song.ArtistID, 10)
+
+	}
+
+	if len(Song.Artists) > 0 {
+
+		return "name:" + Song.Artists[0]
+
+	}
+
+	return ""
+
+}
+
+func sameArtist(A *Tidal.Song, B *Tidal.Song) bool {
+
+	if A.ArtistID != 0 && B.ArtistID != 0 {
+
+		return A.ArtistID == B.ArtistID
+
+	}
+
+	return len(A.Artists) > 0 && len(B.Artists) > 0 && A.Artists[0] == B.Artists[0]
+
+}
+
+func sameAlbum(A *Tidal.Song, B *Tidal.Song) bool {
+
+	return A.AlbumID != 0 && A.AlbumID == B.AlbumID
+
+}
+
+func sameRequestor(A *Tidal.Song, B *Tidal.Song) bool {
+
+	return A.Internal.Requestor != "" && requestorKey(A.Internal.Requestor) == requestorKey(B.Internal.Requestor)
+
+}
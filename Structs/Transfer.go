@@ -0,0 +1,511 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs"
+	"Synthara-Redux/APIs/Tidal"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+
+	ExportFormatM3U  = "m3u"
+	ExportFormatXSPF = "xspf"
+	ExportFormatJSON = "json"
+
+	TransferFormatName    = "Synthara-Redux"
+	TransferFormatVersion = 1
+
+	MaxImportBytes   = 1 << 20 // 1 MiB
+	MaxImportEntries = 500
+	MaxImportSongs   = 1000 // in total, however far the entries' playlist links expand
+
+	importWorkers = 4
+
+)
+
+var (
+
+	ErrExportEmpty  = errors.New("nothing to export")
+	ErrImportEmpty  = errors.New("import has no entries")
+	ErrImportFormat = errors.New("unsupported export format")
+
+)
+
+// TransferSong is a song in the JSON export; URL keeps direct media links, which Tidal.Song does not serialize
+type TransferSong struct {
+
+	Tidal.Song
+
+	URL string `json:"url,omitempty"`
+
+}
+
+// TransferFile is the JSON export format, which round-trips Tidal.Song metadata without refetching
+type TransferFile struct {
+
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+
+	Name       string    `json:"name"`
+	ExportedAt time.Time `json:"exported_at"`
+
+	Songs []TransferSong `json:"songs"`
+
+}
+
+type xspfTrack struct {
+
+	Location   string `xml:"location,omitempty"`
+	Identifier string `xml:"identifier,omitempty"`
+	Title      string `xml:"title,omitempty"`
+	Creator    string `xml:"creator,omitempty"`
+	Album      string `xml:"album,omitempty"`
+	Duration   int    `xml:"duration,omitempty"` // milliseconds
+	Image      string `xml:"image,omitempty"`
+
+}
+
+type xspfPlaylist struct {
+
+	XMLName xml.Name    `xml:"playlist"`
+	Version string      `xml:"version,attr"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title,omitempty"`
+	Tracks  []xspfTrack `xml:"trackList>track"`
+
+}
+
+// ImportEntry is one line of an imported file; Song is set when the file carried full metadata
+type ImportEntry struct {
+
+	Label    string // shown when the entry cannot be matched
+	Input    string // passed to APIs.Route
+	Fallback string // "Artist - Title" searched when Input does not resolve
+
+	Song *Tidal.Song
+
+}
+
+type ImportResult struct {
+
+	Songs     []Tidal.Song
+	Entries   int
+	Unmatched []string
+	Truncated int // songs left out past MaxImportSongs
+
+}
+
+// ExportSongs renders songs in the given format, returning the file contents and extension
+func ExportSongs(Songs []*Tidal.Song, Name string, Format string) ([]byte, string, error) {
+
+	if len(Songs) == 0 {
+
+		return nil, "", ErrExportEmpty
+
+	}
+
+	switch Format {
+
+	case ExportFormatM3U:
+
+		var Buffer bytes.Buffer
+
+		Buffer.WriteString("#EXTM3U\n")
+		Buffer.WriteString(fmt.Sprintf("#PLAYLIST:%s\n", Name))
+
+		for _, Song := range Songs {
+
+			Buffer.WriteString(fmt.Sprintf("#EXTINF:%d,%s - %s\n", Song.Duration.Seconds, strings.Join(Song.Artists, ", "), Song.Title))
+			Buffer.WriteString(Song.URL() + "\n")
+
+		}
+
+		return Buffer.Bytes(), "m3u8", nil
+
+	case ExportFormatXSPF:
+
+		Playlist := xspfPlaylist{Version: "1", XMLNS: "http://xspf.org/ns/0/", Title: Name}
+
+		for _, Song := range Songs {
+
+			Playlist.Tracks = append(Playlist.Tracks, xspfTrack{
+
+				Location: Song.URL(),
+				Title:    Song.Title,
+				Creator:  strings.Join(Song.Artists, ", "),
+				Album:    Song.Album,
+				Duration: Song.Duration.Seconds * 1000,
+				Image:    Song.Cover,
+
+			})
+
+		}
+
+		Data, Error := xml.MarshalIndent(Playlist, "", "\t")
+
+		if Error != nil {
+
+			return nil, "", Error
+
+		}
+
+		return append([]byte(xml.Header), Data...), "xspf", nil
+
+	case ExportFormatJSON:
+
+		File := TransferFile{
+
+			Format:     TransferFormatName,
+			Version:    TransferFormatVersion,
+			Name:       Name,
+			ExportedAt: time.Now().UTC(),
+
+		}
+
+		for _, Song := range Songs {
+
+			File.Songs = append(File.Songs, TransferSong{Song: *CloneSong(Song), URL: Song.Internal.DirectURL})
+
+		}
+
+		Data, Error := json.MarshalIndent(File, "", "\t")
+
+		return Data, "json", Error
+
+	}
+
+	return nil, "", ErrImportFormat
+
+}
+
+// ParseImport reads an exported file, detected by extension or content; anything unrecognised is treated as one link or search per line
+func ParseImport(FileName string, Data []byte) ([]ImportEntry, error) {
+
+	Trimmed := bytes.TrimSpace(bytes.TrimPrefix(Data, []byte("\xef\xbb\xbf")))
+	Extension := strings.ToLower(path.Ext(FileName))
+
+	var Entries []ImportEntry
+	var Error error
+
+	switch {
+
+	case Extension == ".json" || bytes.HasPrefix(Trimmed, []byte("{")):
+
+		Entries, Error = parseImportJSON(Trimmed)
+
+	case Extension == ".xspf" || bytes.HasPrefix(Trimmed, []byte("<")):
+
+		Entries, Error = parseImportXSPF(Trimmed)
+
+	default:
+
+		Entries = parseImportLines(Trimmed)
+
+	}
+
+	if Error != nil {
+
+		return nil, Error
+
+	}
+
+	if len(Entries) == 0 {
+
+		return nil, ErrImportEmpty
+
+	}
+
+	if len(Entries) > MaxImportEntries {
+
+		Entries = Entries[:MaxImportEntries]
+
+	}
+
+	return Entries, nil
+
+}
+
+func parseImportJSON(Data []byte) ([]ImportEntry, error) {
+
+	File := TransferFile{}
+
+	if Error := json.Unmarshal(Data, &File); Error != nil {
+
+		return nil, Error
+
+	}
+
+	Entries := make([]ImportEntry, 0, len(File.Songs))
+
+	for Index := range File.Songs {
+
+		Song := File.Songs[Index].Song
+		Label := fmt.Sprintf("%s - %s", strings.Join(Song.Artists, ", "), Song.Title)
+
+		switch {
+
+		case File.Songs[Index].URL != "":
+
+			Entries = append(Entries, ImportEntry{Label: Label, Input: File.Songs[Index].URL})
+
+		case Song.TidalID != 0:
+
+			Entries = append(Entries, ImportEntry{Label: Label, Song: &Song})
+
+		case Song.Title != "":
+
+			Entries = append(Entries, ImportEntry{Label: Label, Input: Label})
+
+		}
+
+	}
+
+	return Entries, nil
+
+}
+
+func parseImportXSPF(Data []byte) ([]ImportEntry, error) {
+
+	Playlist := xspfPlaylist{}
+
+	if Error := xml.Unmarshal(Data, &Playlist); Error != nil {
+
+		return nil, Error
+
+	}
+
+	Entries := make([]ImportEntry, 0, len(Playlist.Tracks))
+
+	for _, Track := range Playlist.Tracks {
+
+		Search := strings.TrimSpace(strings.Trim(Track.Creator+" - "+Track.Title, " -"))
+		Input := strings.TrimSpace(Track.Location)
+
+		if !APIs.IsURL(Input) {
+
+			Input = strings.TrimSpace(Track.Identifier)
+
+		}
+
+		if !APIs.IsURL(Input) {
+
+			Input = Search
+
+		}
+
+		if Input == "" {
+
+			continue
+
+		}
+
+		Entries = append(Entries, ImportEntry{Label: firstNonEmpty(Search, Input), Input: Input, Fallback: Search})
+
+	}
+
+	return Entries, nil
+
+}
+
+// parseImportLines reads M3U/M3U8 files and plain lists of links; #EXTINF titles become the search fallback for local paths and dead links
+func parseImportLines(Data []byte) []ImportEntry {
+
+	Entries := []ImportEntry{}
+	Fallback := ""
+
+	Scanner := bufio.NewScanner(bytes.NewReader(Data))
+
+	for Scanner.Scan() {
+
+		Line := strings.TrimSpace(Scanner.Text())
+
+		if Line == "" {
+
+			continue
+
+		}
+
+		if strings.HasPrefix(Line, "#") {
+
+			if Info, IsInfo := strings.CutPrefix(Line, "#EXTINF:"); IsInfo {
+
+				if _, Title, HasTitle := strings.Cut(Info, ","); HasTitle {
+
+					Fallback = strings.TrimSpace(Title)
+
+				}
+
+			}
+
+			continue
+
+		}
+
+		Input := Line
+
+		if !APIs.IsURL(Line) && Fallback != "" {
+
+			Input = Fallback // a local file path; only the title is usable
+
+		}
+
+		Entries = append(Entries, ImportEntry{Label: firstNonEmpty(Fallback, Line), Input: Input, Fallback: Fallback})
+		Fallback = ""
+
+	}
+
+	return Entries
+
+}
+
+func firstNonEmpty(Values ...string) string {
+
+	for _, Value := range Values {
+
+		if Value != "" {
+
+			return Value
+
+		}
+
+	}
+
+	return ""
+
+}
+
+// resolveImportEntry routes an entry through APIs.Route and the resolvers, searching the fallback title when that fails
+func resolveImportEntry(Entry ImportEntry) []Tidal.Song {
+
+	if Entry.Song != nil {
+
+		return []Tidal.Song{*Entry.Song}
+
+	}
+
+	for _, Input := range []string{Entry.Input, Entry.Fallback} {
+
+		if Input == "" {
+
+			continue
+
+		}
+
+		URI, RouteError := APIs.Route(Input)
+
+		if RouteError != nil {
+
+			continue
+
+		}
+
+		Content, ResolveError := ResolveURI(URI)
+
+		if ResolveError != nil {
+
+			continue
+
+		}
+
+		if Songs, FetchError := Content.All(MaxImportSongs); FetchError == nil && len(Songs) > 0 {
+
+			return Songs
+
+		}
+
+	}
+
+	return nil
+
+}
+
+// ResolveImport resolves entries a few at a time, keeping the file's order
+func ResolveImport(Entries []ImportEntry) ImportResult {
+
+	Resolved := make([][]Tidal.Song, len(Entries))
+
+	Indexes := make(chan int)
+	var Workers sync.WaitGroup
+
+	for range importWorkers {
+
+		Workers.Add(1)
+
+		go func() {
+
+			defer Workers.Done()
+
+			for Index := range Indexes {
+
+				Resolved[Index] = resolveImportEntry(Entries[Index])
+
+			}
+
+		}()
+
+	}
+
+	for Index := range Entries {
+
+		Indexes <- Index
+
+	}
+
+	close(Indexes)
+	Workers.Wait()
+
+	Result := ImportResult{Entries: len(Entries)}
+
+	for Index, Songs := range Resolved {
+
+		if len(Songs) == 0 {
+
+			Result.Unmatched = append(Result.Unmatched, Entries[Index].Label)
+			continue
+
+		}
+
+		Room := MaxImportSongs - len(Result.Songs)
+
+		if len(Songs) > Room {
+
+			Result.Truncated += len(Songs) - Room
+			Songs = Songs[:Room]
+
+		}
+
+		Result.Songs = append(Result.Songs, Songs...)
+
+	}
+
+	return Result
+
+}
+
+// ParseImportText reads links typed into a command option, where several links arrive space separated on one line
+func ParseImportText(Text string) []ImportEntry {
+
+	Fields := strings.Fields(Text)
+
+	for _, Field := range Fields {
+
+		if !APIs.IsURL(Field) {
+
+			return parseImportLines([]byte(Text)) // a search query; spaces are part of it
+
+		}
+
+	}
+
+	return parseImportLines([]byte(strings.Join(Fields, "\n")))
+
+}
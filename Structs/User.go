@@ -45,6 +45,7 @@ type User struct {
 
 	Favorites map[string]int `bson:"favorites"` // URI -> Count
 	MostRecentMix string `bson:"most_recent_mix,omitempty"` // ID of the last played mix
+	Liked []LikedSong `bson:"liked,omitempty"` // Explicit favorites, oldest first
 
 	Settings Settings `bson:"settings,omitempty"`
 
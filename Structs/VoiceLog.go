@@ -0,0 +1,163 @@
+package Structs
+
+import (
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Receive"
+	"Synthara-Redux/Utils"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+
+	DefaultVoiceLogRetentionDays = 30
+
+	VoiceLogPageSize = 15
+
+)
+
+// VoiceLogEntry is one persisted voice command and what became of it
+type VoiceLogEntry struct {
+
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	GuildID string             `bson:"guild_id"`
+	UserID  string             `bson:"user_id"`
+
+	Command    string `bson:"command"`
+	Args       string `bson:"args,omitempty"`
+	Transcript string `bson:"transcript"`
+
+	Outcome   string `bson:"outcome"`
+	LatencyMs int64  `bson:"latency_ms"`
+
+	At time.Time `bson:"at"`
+
+}
+
+// VoiceLogRetention is how long voice commands are kept, from VOICE_LOG_RETENTION_DAYS (30 days by default)
+func VoiceLogRetention() time.Duration {
+
+	Days, ErrorParsing := strconv.Atoi(strings.TrimSpace(os.Getenv("VOICE_LOG_RETENTION_DAYS")))
+
+	if ErrorParsing != nil || Days <= 0 {
+
+		Days = DefaultVoiceLogRetentionDays
+
+	}
+
+	return time.Duration(Days) * 24 * time.Hour
+
+}
+
+// RecordVoiceCommand persists a voice command in the background; the TTL index on "at" drops entries past the retention window
+func RecordVoiceCommand(Record Receive.VoiceCommandRecord) {
+
+	Entry := VoiceLogEntry{
+
+		GuildID: Record.GuildID.String(),
+		UserID:  Record.UserID.String(),
+
+		Command:    Record.Command,
+		Args:       Record.Args,
+		Transcript: Record.Transcript,
+
+		Outcome:   string(Record.Outcome),
+		LatencyMs: Record.Latency.Milliseconds(),
+
+		At: Record.At.UTC(),
+
+	}
+
+	go func() {
+
+		if ErrorSaving := SaveVoiceLogEntry(Entry); ErrorSaving != nil {
+
+			Utils.Logger.Error("VoiceLog", fmt.Sprintf("Error saving voice command for guild %s: %s", Entry.GuildID, ErrorSaving.Error()))
+
+		}
+
+	}()
+
+}
+
+// SaveVoiceLogEntry persists a voice command
+func SaveVoiceLogEntry(Entry VoiceLogEntry) error {
+
+	Collection := Globals.Database.Collection("VoiceCommandLog")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	_, InsertError := Collection.InsertOne(Context, Entry)
+
+	return InsertError
+
+}
+
+// DeleteVoiceLogForUser erases every logged voice command of a user, across all guilds
+func DeleteVoiceLogForUser(UserID string) error {
+
+	Collection := Globals.Database.Collection("VoiceCommandLog")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	_, DeleteError := Collection.DeleteMany(Context, bson.M{"user_id": UserID})
+
+	return DeleteError
+
+}
+
+// RecentVoiceLog returns the latest voice commands, newest first; an empty guild or user matches all
+func RecentVoiceLog(GuildID string, UserID string, Limit int) ([]VoiceLogEntry, error) {
+
+	Collection := Globals.Database.Collection("VoiceCommandLog")
+
+	Context, Cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer Cancel()
+
+	Filter := bson.M{"at": bson.M{"$gte": time.Now().UTC().Add(-VoiceLogRetention())}}
+
+	if GuildID != "" {
+
+		Filter["guild_id"] = GuildID
+
+	}
+
+	if UserID != "" {
+
+		Filter["user_id"] = UserID
+
+	}
+
+	Options := options.Find().
+		SetSort(bson.D{{Key: "at", Value: -1}}).
+		SetLimit(int64(max(Limit, 1)))
+
+	Cursor, FindError := Collection.Find(Context, Filter, Options)
+
+	if FindError != nil {
+
+		return nil, FindError
+
+	}
+
+	Entries := []VoiceLogEntry{}
+
+	if DecodeError := Cursor.All(Context, &Entries); DecodeError != nil {
+
+		return nil, DecodeError
+
+	}
+
+	return Entries, nil
+
+}
@@ -0,0 +1,229 @@
+package Structs
+
+import (
+	"Synthara-Redux/APIs/Tidal"
+	"Synthara-Redux/Globals"
+	"Synthara-Redux/Utils"
+	"fmt"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const Event_SkipVotes = "SKIP_VOTES"
+
+type SkipVoteResult struct {
+
+	Skip bool // The caller should advance the queue
+
+	Votes  int
+	Needed int
+
+	AlreadyVoted bool
+	NotListening bool // The voter is not in the bot's voice channel
+	Denied       bool // Skipping is restricted and vote-skip is off
+	VoteRequired bool // Jumping was refused because vote-skip is on and the user is not privileged
+
+}
+
+// CanSkipInstantly reports whether a user may skip the current song without a vote (its requestor, a DJ, or a role granted skip)
+func (G *Guild) CanSkipInstantly(UserID snowflake.ID, Username string) bool {
+
+	if G.Queue.Current != nil && G.IsRequestor(G.Queue.Current, UserID, Username) {
+
+		return true
+
+	}
+
+	return G.Settings.Permissions.Privileged(G.ID, UserID, CapabilitySkip)
+
+}
+
+// IsRequestor reports whether a song was queued by the given user
+func (G *Guild) IsRequestor(Song *Tidal.Song, UserID snowflake.ID, Username string) bool {
+
+	Requestor := Song.Internal.Requestor
+
+	return Requestor == discord.UserMention(UserID) || Requestor == UserID.String() || (Username != "" && Requestor == Username)
+
+}
+
+// RequestSkip registers a skip request; without vote-skip, or for privileged users, the skip is immediate
+func (G *Guild) RequestSkip(UserID snowflake.ID, Username string) SkipVoteResult {
+
+	if G.Queue.Current == nil || G.CanSkipInstantly(UserID, Username) {
+
+		G.ClearSkipVotes()
+		return SkipVoteResult{Skip: true}
+
+	}
+
+	if !G.Settings.VoteSkip.Enabled {
+
+		if !G.Allowed(UserID, CapabilitySkip) {
+
+			return SkipVoteResult{Denied: true}
+
+		}
+
+		return SkipVoteResult{Skip: true}
+
+	}
+
+	VoiceState, InVoice := Globals.DiscordClient.Caches.VoiceState(G.ID, UserID)
+
+	if !InVoice || VoiceState.ChannelID == nil || *VoiceState.ChannelID != G.Channels.Voice {
+
+		return SkipVoteResult{NotListening: true}
+
+	}
+
+	G.Internal.SkipVoteMutex.Lock()
+
+	if G.Internal.SkipVoteSong != G.Queue.Current || G.Internal.SkipVotes == nil {
+
+		G.Internal.SkipVotes = make(map[snowflake.ID]bool)
+		G.Internal.SkipVoteSong = G.Queue.Current
+
+	}
+
+	Result := SkipVoteResult{AlreadyVoted: G.Internal.SkipVotes[UserID]}
+
+	G.Internal.SkipVotes[UserID] = true
+
+	Result.Votes = len(G.Internal.SkipVotes)
+	Result.Needed = G.SkipVotesNeeded()
+
+	G.Internal.SkipVoteMutex.Unlock()
+
+	if Result.Votes >= Result.Needed {
+
+		Utils.Logger.Info("VoteSkip", fmt.Sprintf("Vote to skip passed in guild %s (%d/%d)", G.ID.String(), Result.Votes, Result.Needed))
+
+		G.ClearSkipVotes()
+		Result.Skip = true
+
+		return Result
+
+	}
+
+	if !Result.AlreadyVoted {
+
+		G.broadcastSkipVotes(Result.Votes, Result.Needed)
+
+	}
+
+	return Result
+
+}
+
+// RequestJump checks whether a user may jump ahead in the queue; a jump skips several songs at once, so while vote-skip is on only privileged users may jump
+func (G *Guild) RequestJump(UserID snowflake.ID) SkipVoteResult {
+
+	if G.Settings.Permissions.Privileged(G.ID, UserID, CapabilitySkip) {
+
+		G.ClearSkipVotes()
+		return SkipVoteResult{Skip: true}
+
+	}
+
+	if G.Settings.VoteSkip.Enabled {
+
+		return SkipVoteResult{VoteRequired: true}
+
+	}
+
+	if !G.Allowed(UserID, CapabilitySkip) {
+
+		return SkipVoteResult{Denied: true}
+
+	}
+
+	return SkipVoteResult{Skip: true}
+
+}
+
+// SkipVotesNeeded returns how many votes are required to skip, based on the listeners currently in the channel
+func (G *Guild) SkipVotesNeeded() int {
+
+	Listeners := Utils.CountListeners(G.ID, G.Channels.Voice)
+
+	Needed := (Listeners*G.Settings.VoteSkip.Threshold + 99) / 100
+
+	if Needed < 1 {
+
+		Needed = 1
+
+	}
+
+	return Needed
+
+}
+
+// SkipVotes returns the vote count for the current song and the votes required, or zeros when no vote is in progress
+func (G *Guild) SkipVotes() (int, int) {
+
+	G.Internal.SkipVoteMutex.Lock()
+	defer G.Internal.SkipVoteMutex.Unlock()
+
+	if G.Internal.SkipVoteSong == nil || G.Internal.SkipVoteSong != G.Queue.Current || len(G.Internal.SkipVotes) == 0 {
+
+		return 0, 0
+
+	}
+
+	return len(G.Internal.SkipVotes), G.SkipVotesNeeded()
+
+}
+
+// ClearSkipVotes discards all votes for the current song
+func (G *Guild) ClearSkipVotes() {
+
+	G.Internal.SkipVoteMutex.Lock()
+
+	G.Internal.SkipVotes = nil
+	G.Internal.SkipVoteSong = nil
+
+	G.Internal.SkipVoteMutex.Unlock()
+
+}
+
+// broadcastSkipVotes shows vote progress on the web UI and the last now-playing message
+func (G *Guild) broadcastSkipVotes(Votes int, Needed int) {
+
+	G.Queue.SendToWebsockets(Event_SkipVotes, map[string]interface{}{
+
+		"Votes":  Votes,
+		"Needed": Needed,
+
+	})
+
+	if G.Internal.NowPlayingMessage == 0 || G.Queue.Current == nil {
+
+		return
+
+	}
+
+	Song := G.Queue.Current
+	MessageID := G.Internal.NowPlayingMessage
+
+	State := G.QueueInfo(G.Queue.State == StatePlaying, 0, G.Locale.Code())
+
+	State.SkipVotes = Votes
+	State.SkipVotesNeeded = Needed
+
+	go func() {
+
+		_, ErrorUpdating := Globals.DiscordClient.Rest.UpdateMessage(G.Channels.Text, MessageID, discord.NewMessageUpdate().
+			AddEmbeds(Song.Embed(State)).
+			AddComponents(Song.Components(State)...))
+
+		if ErrorUpdating != nil {
+
+			Utils.Logger.Warn("VoteSkip", fmt.Sprintf("Failed to update now playing message for guild %s: %s", G.ID.String(), ErrorUpdating.Error()))
+
+		}
+
+	}()
+
+}
@@ -0,0 +1,147 @@
+// VoiceReplay runs a recorded voice command through the receive pipeline without Discord.
+//
+//	go run ./Tools/VoiceReplay -script play.txt capture.wav
+//
+// Run it from the repository root so the localizations and .env are found.
+package main
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Receive"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+
+	godotenv.Load(".env")
+
+	Script := flag.String("script", "", "transcript script of \"<seconds> <interim|final|speech> <text>\" lines, with \"---\" between captures; without it the configured STT backend is used")
+	Locale := flag.String("locale", "en-US", "guild locale the capture is parsed in")
+	Phrase := flag.String("wake", "", "wake phrase (defaults to Synthara)")
+	ListenAt := flag.Duration("listen", 0, "when to press push-to-talk; negative waits for the wake detector")
+	Fast := flag.Bool("fast", false, "replay on a virtual clock as fast as the pipeline allows instead of in real time; needs -script")
+	Expect := flag.String("expect", "", "exit with status 1 unless the first dispatched command is this (e.g. \"play\" or \"volume 50\")")
+
+	flag.Usage = func() {
+
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: VoiceReplay [flags] <capture.wav|capture.ogg>\n\n")
+		flag.PrintDefaults()
+
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+
+		flag.Usage()
+		os.Exit(2)
+
+	}
+
+	if LocalizationErr := Localizations.Initialize(); LocalizationErr != nil {
+
+		fmt.Fprintf(os.Stderr, "Failed to read localizations: %s\n", LocalizationErr.Error())
+		os.Exit(1)
+
+	}
+
+	Receive.SetGuildLocaleResolver(func(snowflake.ID) string {
+
+		return *Locale
+
+	})
+
+	Receive.SetWakeWordResolver(func(snowflake.ID) Receive.WakeWord {
+
+		return Receive.WakeWord{Phrase: *Phrase}
+
+	})
+
+	Report, ReplayErr := Receive.Replay(Receive.ReplayOptions{
+
+		Path: flag.Arg(0),
+		Script: *Script,
+
+		ListenAt: *ListenAt,
+		Virtual: *Fast,
+
+	})
+
+	if ReplayErr != nil {
+
+		fmt.Fprintf(os.Stderr, "Replay failed: %s\n", ReplayErr.Error())
+		os.Exit(1)
+
+	}
+
+	for _, Event := range Report.Events {
+
+		fmt.Printf("%8.3fs  %-12s %s\n", Event.At.Seconds(), Event.Stage, Event.Detail)
+
+	}
+
+	fmt.Println()
+
+	AverageDecode := time.Duration(0)
+
+	if Report.Frames > 0 {
+
+		AverageDecode = Report.DecodeTime / time.Duration(Report.Frames)
+
+	}
+
+	fmt.Printf("Frames:     %d (%d voiced), decoded in %s, %s per frame, %d errors\n", Report.Frames, Report.VoicedFrames, Report.DecodeTime.Round(time.Microsecond), AverageDecode.Round(time.Microsecond), Report.DecodeErrors)
+
+	if Report.Scripted {
+
+		fmt.Printf("Transcriber: %d ms of audio sent, %d ms of it silent\n", Report.SentBytes*1000/(Receive.TargetSampleRate*2), Report.GatedBytes*1000/(Receive.TargetSampleRate*2))
+
+	}
+
+	if len(Report.Commands) == 0 {
+
+		fmt.Println("Commands:   none")
+
+	}
+
+	for _, Dispatched := range Report.Commands {
+
+		fmt.Printf("Command:    %s %s (transcript %q)\n", Dispatched.Command.Command, Dispatched.Command.Args, Dispatched.Command.Transcript)
+
+	}
+
+	for _, Confirmation := range Report.Confirmations {
+
+		fmt.Printf("Confirmed:  %s %t at %.3fs\n", Confirmation.Command, Confirmation.Confirmed, Confirmation.At.Seconds())
+
+	}
+
+	if *Expect == "" {
+
+		return
+
+	}
+
+	Got := ""
+
+	if len(Report.Commands) > 0 {
+
+		Got = strings.TrimSpace(Report.Commands[0].Command.Command + " " + Report.Commands[0].Command.Args)
+
+	}
+
+	if !strings.EqualFold(Got, strings.TrimSpace(*Expect)) {
+
+		fmt.Fprintf(os.Stderr, "Expected %q, got %q\n", *Expect, Got)
+		os.Exit(1)
+
+	}
+
+}
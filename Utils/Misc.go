@@ -76,6 +76,45 @@ func GetVoiceState(GuildID snowflake.ID, UserID snowflake.ID) (*discord.VoiceSta
 
 }
 
+// CountListeners returns how many non-bot members are connected to the given voice channel, per the voice state cache
+func CountListeners(GuildID snowflake.ID, ChannelID snowflake.ID) int {
+
+	if ChannelID == 0 {
+
+		return 0
+
+	}
+
+	Count := 0
+
+	for VoiceState := range Globals.DiscordClient.Caches.VoiceStates(GuildID) {
+
+		if VoiceState.ChannelID == nil || *VoiceState.ChannelID != ChannelID {
+
+			continue
+
+		}
+
+		if VoiceState.UserID == Globals.DiscordClient.ApplicationID {
+
+			continue
+
+		}
+
+		if Member, Exists := Globals.DiscordClient.Caches.Member(GuildID, VoiceState.UserID); Exists && Member.User.Bot {
+
+			continue
+
+		}
+
+		Count++
+
+	}
+
+	return Count
+
+}
+
 func GetURI(Type string, ID string) string {
 
 	return fmt.Sprintf("Synthara-Redux:%s:%s", Type, ID)
@@ -98,4 +137,17 @@ func WaitFor(Done <-chan struct{}) {
 
 	<-Done
 
-}
\ No newline at end of file
+}
+
+// Truncate shortens text to at most Limit runes, ending with an ellipsis when cut
+func Truncate(Text string, Limit int) string {
+
+	if len([]rune(Text)) <= Limit {
+
+		return Text
+
+	}
+
+	return string([]rune(Text)[:Limit-1]) + "…"
+
+}
@@ -0,0 +1,86 @@
+package Validation
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ManageGuildError returns error embed if the member lacks the Manage Server permission
+func ManageGuildError(Event *events.ApplicationCommandInteractionCreate, Locale string) *discord.Embed {
+
+	Member := Event.Member()
+
+	if Member != nil && Member.Permissions.Has(discord.PermissionManageGuild) {
+
+		return nil
+
+	}
+
+	return &discord.Embed{
+
+		Title: Localizations.Get("Embeds.Errors.MissingPermissions.Title", Locale),
+		Author: &discord.EmbedAuthor{Name: Localizations.Get("Embeds.Categories.Error", Locale)},
+		Description: Localizations.Get("Embeds.Errors.MissingPermissions.Description", Locale),
+
+		Color: Utils.ERROR,
+
+	}
+
+}
+
+// SettingsSaveError returns error embed shown when guild settings could not be persisted
+func SettingsSaveError(Locale string) discord.Embed {
+
+	return discord.Embed{
+
+		Title: Localizations.Get("Embeds.Errors.SettingsNotSaved.Title", Locale),
+		Author: &discord.EmbedAuthor{Name: Localizations.Get("Embeds.Categories.Error", Locale)},
+		Description: Localizations.Get("Embeds.Errors.SettingsNotSaved.Description", Locale),
+
+		Color: Utils.ERROR,
+
+	}
+
+}
+
+// CapabilityError returns error embed if the guild's permission policy does not grant the user a capability
+func CapabilityError(GuildID snowflake.ID, UserID snowflake.ID, Capability string, Locale string) *discord.Embed {
+
+	if Structs.GuildSettingsFor(GuildID).Permissions.Allowed(GuildID, UserID, Capability) {
+
+		return nil
+
+	}
+
+	Embed := CapabilityDeniedError(Capability, Locale)
+
+	return &Embed
+
+}
+
+// CapabilityDeniedError returns the embed shown when a capability is denied
+func CapabilityDeniedError(Capability string, Locale string) discord.Embed {
+
+	return discord.Embed{
+
+		Title: Localizations.Get("Embeds.Errors.CapabilityDenied.Title", Locale),
+		Author: &discord.EmbedAuthor{Name: Localizations.Get("Embeds.Categories.Error", Locale)},
+		Description: Localizations.GetFormat("Embeds.Errors.CapabilityDenied.Description", Locale, CapabilityName(Capability, Locale)),
+
+		Color: Utils.ERROR,
+
+	}
+
+}
+
+// CapabilityName returns the localized display name of a capability
+func CapabilityName(Capability string, Locale string) string {
+
+	return Localizations.Get("Common.Capabilities."+Capability, Locale)
+
+}
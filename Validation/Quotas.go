@@ -0,0 +1,32 @@
+package Validation
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// QuotaError returns error embed if a song was rejected by the guild's quota policy
+func QuotaError(Error error, Locale string) *discord.Embed {
+
+	QuotaErr, IsQuota := Error.(*Structs.QuotaError)
+
+	if !IsQuota {
+
+		return nil
+
+	}
+
+	return &discord.Embed{
+
+		Title: Localizations.Get("Embeds.Errors.Quota.Title", Locale),
+		Author: &discord.EmbedAuthor{Name: Localizations.Get("Embeds.Categories.Error", Locale)},
+		Description: QuotaErr.Localized(Locale),
+
+		Color: Utils.ERROR,
+
+	}
+
+}
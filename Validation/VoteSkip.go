@@ -0,0 +1,74 @@
+package Validation
+
+import (
+	"Synthara-Redux/Globals/Localizations"
+	"Synthara-Redux/Structs"
+	"Synthara-Redux/Utils"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// SkipVoteError returns an embed describing a recorded (or rejected) skip vote, or nil if the skip should happen now
+func SkipVoteError(Result Structs.SkipVoteResult, Locale string) *discord.Embed {
+
+	if Result.Skip {
+
+		return nil
+
+	}
+
+	if Result.Denied {
+
+		Embed := CapabilityDeniedError(Structs.CapabilitySkip, Locale)
+
+		return &Embed
+
+	}
+
+	if Result.VoteRequired {
+
+		return &discord.Embed{
+
+			Title: Localizations.Get("Commands.Jump.Error.VoteSkip.Title", Locale),
+			Author: &discord.EmbedAuthor{Name: Localizations.Get("Embeds.Categories.Error", Locale)},
+			Description: Localizations.Get("Commands.Jump.Error.VoteSkip.Description", Locale),
+
+			Color: Utils.ERROR,
+
+		}
+
+	}
+
+	if Result.NotListening {
+
+		return &discord.Embed{
+
+			Title: Localizations.Get("Commands.Next.Vote.NotListening.Title", Locale),
+			Author: &discord.EmbedAuthor{Name: Localizations.Get("Embeds.Categories.Error", Locale)},
+			Description: Localizations.Get("Commands.Next.Vote.NotListening.Description", Locale),
+
+			Color: Utils.ERROR,
+
+		}
+
+	}
+
+	Description := Localizations.GetFormat("Commands.Next.Vote.Registered.Description", Locale, Result.Votes, Result.Needed)
+
+	if Result.AlreadyVoted {
+
+		Description = Localizations.GetFormat("Commands.Next.Vote.AlreadyVoted.Description", Locale, Result.Votes, Result.Needed)
+
+	}
+
+	return &discord.Embed{
+
+		Title: Localizations.Get("Commands.Next.Vote.Registered.Title", Locale),
+		Author: &discord.EmbedAuthor{Name: Localizations.Get("Embeds.Categories.Playback", Locale)},
+		Description: Description,
+
+		Color: Utils.PRIMARY,
+
+	}
+
+}
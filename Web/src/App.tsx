@@ -1,12 +1,14 @@
 import { useEffect, useState, useRef } from 'react';
-import { Play, Pause, SkipBack, SkipForward, Trash2, CornerDownRight, RefreshCw } from 'lucide-react';
+import { Play, Pause, SkipBack, SkipForward, Trash2, CornerDownRight, RefreshCw, Heart } from 'lucide-react';
 
-import { Song, PlayerState, WSEvents, WSMessage, Operation, LyricsResponse, InitialStateData, AuthState } from './Types';
+import { Song, PlayerState, WSEvents, WSMessage, Operation, LyricsResponse, InitialStateData, AuthState, SkipVotesData, ShuffleMode } from './Types';
 import { NormalizeCoverURL, FormatTime, SendOperation, FetchLyrics, FormatWS, FetchAPI } from './Utils/Misc';
 
 import DetailsView from './Views/Details';
 import LyricsView from './Views/Lyrics';
 import QueueView from './Views/Queue';
+import StatsView from './Views/Stats';
+import PlaylistsView from './Views/Playlists';
 import SearchBar from './Components/Search';
 
 function App() {
@@ -27,6 +29,9 @@ function App() {
     const [Auth, SetAuth] = useState<AuthState>({ OAuthEnabled: false, Authenticated: false });
     const [ControlsLocked, SetControlsLocked] = useState(false);
     const [GuildLocked, SetGuildLocked] = useState(false);
+    const [SkipVotes, SetSkipVotes] = useState<SkipVotesData | null>(null);
+    const [FairShare, SetFairShare] = useState(false);
+    const [LikedIDs, SetLikedIDs] = useState<Set<number>>(new Set());
 
     // Close context menu on click outside or scroll
 
@@ -96,13 +101,28 @@ function App() {
 
     }, []);
 
-    const [ActiveView, SetActiveView] = useState<'Details' | 'Queue' | 'Lyrics'>(() => {
+    // Load the signed in user's favorites so the heart reflects them
+
+    useEffect(() => {
+
+        if (!Auth.Authenticated) return;
+
+        FetchAPI('/API/Favorites')
+            .then((Res) => Res.json())
+            .then((Data: { Liked?: number[] }) => SetLikedIDs(new Set(Data.Liked ?? [])))
+            .catch(() => {});
+
+    }, [Auth.Authenticated]);
+
+    const [ActiveView, SetActiveView] = useState<'Details' | 'Queue' | 'Lyrics' | 'Stats' | 'Playlists'>(() => {
 
         const Params = new URLSearchParams(window.location.search);
         const View = Params.get('View');
 
         if (View == 'Lyrics') return 'Lyrics';
         if (View == 'Queue') return 'Queue';
+        if (View == 'Stats') return 'Stats';
+        if (View == 'Playlists') return 'Playlists';
 
         return 'Details';
 
@@ -111,7 +131,7 @@ function App() {
     const [BackgroundImage, SetBackgroundImage] = useState<string>('');
 
     const CurrentSongIdRef = useRef<number | null>(null);
-    const ActiveViewRef = useRef<'Details' | 'Queue' | 'Lyrics'>(ActiveView);
+    const ActiveViewRef = useRef<'Details' | 'Queue' | 'Lyrics' | 'Stats' | 'Playlists'>(ActiveView);
     const UpcomingSongsLengthRef = useRef<number>(0);
 
     const [Lyrics, SetLyrics] = useState<LyricsResponse | null>(null);
@@ -202,6 +222,9 @@ function App() {
                         SetGuildLocked(!!Initial.GuildLocked);
                         SetControlsLocked(!!Initial.ControlsLocked);
 
+                        SetSkipVotes(Initial.SkipVotesNeeded ? { Votes: Initial.SkipVotes || 0, Needed: Initial.SkipVotesNeeded } : null);
+                        SetFairShare(!!Initial.FairShare);
+
                     break;
 
                     case WSEvents.Event_StateChanged:
@@ -216,10 +239,17 @@ function App() {
 
                     break;
 
+                    case WSEvents.Event_SkipVotes:
+
+                        SetSkipVotes(Message.Data as SkipVotesData);
+
+                    break;
+
                     case WSEvents.Event_QueueUpdated:
 
                         SetPreviousSongs(Message.Data.Previous || []);
                         SetUpcomingSongs(Message.Data.Upcoming || []);
+                        SetFairShare(!!Message.Data.FairShare);
 
                         const NewSong = Message.Data.Current as Song | null;
                         const SongChanged = NewSong && NewSong.tidal_id != CurrentSongIdRef.current;
@@ -229,6 +259,7 @@ function App() {
                             SetCurrentSong(NewSong);
                             SetCurrentTime(0);
                             SetLyrics(null);
+                            SetSkipVotes(null);
 
                             if (ActiveViewRef.current != 'Queue') {
 
@@ -426,9 +457,49 @@ function App() {
 
     };
 
-    const HandleEnqueue = (TidalID: number) => {
+    const HandleShuffle = (Mode: ShuffleMode) => {
+
+        SendOperation(Socket, Operation.Shuffle, { Mode }, ControlsLocked);
+
+    };
+
+    const HandleFavorite = async (TidalID: number) => {
+
+        try {
+
+            const Response = await FetchAPI('/API/Favorites', {
+
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ ID: GuildID, TidalID }),
+
+            });
+
+            if (!Response.ok) return;
+
+            const Data: { Liked: boolean } = await Response.json();
+
+            SetLikedIDs((Prev) => {
+
+                const Next = new Set(Prev);
+
+                if (Data.Liked) Next.add(TidalID); else Next.delete(TidalID);
+
+                return Next;
+
+            });
+
+        } catch {
+
+            // Leave the heart as it was
+
+        }
+
+    };
+
+    const HandleEnqueue = (TidalID: number, Position?: string) => {
 
-        SendOperation(Socket, Operation.Enqueue, { TidalID }, ControlsLocked);
+        SendOperation(Socket, Operation.Enqueue, { TidalID, Position }, ControlsLocked);
         SetActiveView('Queue');
 
     };
@@ -500,12 +571,20 @@ function App() {
 
                         <div className="min-h-[200px] max-h-[500px] overflow-y-auto">
 
-                            <QueueView key={CurrentSong ? CurrentSong.tidal_id.toString() : 'none'} Current={CurrentSong} PreviousSongs={PreviousSongs} UpcomingSongs={UpcomingSongs} ActiveContextMenu={ActiveContextMenu} SetActiveContextMenu={SetActiveContextMenu} OnMove={HandleMove} ControlsLocked={ControlsLocked} />
+                            <QueueView key={CurrentSong ? CurrentSong.tidal_id.toString() : 'none'} Current={CurrentSong} PreviousSongs={PreviousSongs} UpcomingSongs={UpcomingSongs} ActiveContextMenu={ActiveContextMenu} SetActiveContextMenu={SetActiveContextMenu} OnMove={HandleMove} OnShuffle={HandleShuffle} ControlsLocked={ControlsLocked} FairShare={FairShare} />
 
                         </div>
 
                     )}
 
+                    {/* Stats View */}
+
+                    {ActiveView == 'Stats' && (<StatsView GuildID={GuildID} Authenticated={Auth.Authenticated} />)}
+
+                    {/* Playlists View */}
+
+                    {ActiveView == 'Playlists' && (<PlaylistsView GuildID={GuildID} CurrentSong={CurrentSong} Authenticated={Auth.Authenticated} />)}
+
                 </div>
 
                 {/* Progress Bar */}
@@ -525,6 +604,15 @@ function App() {
                     <div className="flex justify-between text-sm text-zinc-500 mt-2">
 
                         <span>{FormatTime(CurrentTime / 1000)}</span>
+
+                        {Auth.Authenticated && (
+
+                            <button onClick={() => HandleFavorite(CurrentSong.tidal_id)} className="text-white transition-colors hover:text-zinc-400" aria-label={LikedIDs.has(CurrentSong.tidal_id) ? 'Remove from favorites' : 'Add to favorites'} >
+                                <Heart size={20} fill={LikedIDs.has(CurrentSong.tidal_id) ? 'currentColor' : 'none'} />
+                            </button>
+
+                        )}
+
                         <span>{CurrentSong.duration.formatted}</span>
 
                     </div>
@@ -559,6 +647,14 @@ function App() {
 
                 </div>
 
+                {SkipVotes && (
+
+                    <p className="-mt-8 mb-8 text-center text-sm text-zinc-400">
+                        {SkipVotes.Votes}/{SkipVotes.Needed} votes to skip
+                    </p>
+
+                )}
+
                 {/* Bottom Buttons */}
 
                 <div className="flex justify-center gap-4">
@@ -575,6 +671,14 @@ function App() {
                         Queue
                     </button>
 
+                    <button onClick={() => SetActiveView('Stats')} className={`px-6 py-2 rounded-md border transition-colors ${ActiveView == 'Stats' ? 'bg-white text-zinc-950 border-white' : 'bg-transparent text-white border-zinc-600 hover:border-white' }`} >
+                        Stats
+                    </button>
+
+                    <button onClick={() => SetActiveView('Playlists')} className={`px-6 py-2 rounded-md border transition-colors ${ActiveView == 'Playlists' ? 'bg-white text-zinc-950 border-white' : 'bg-transparent text-white border-zinc-600 hover:border-white' }`} >
+                        Playlists
+                    </button>
+
                 </div>
 
             </div>
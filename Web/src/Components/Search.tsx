@@ -1,5 +1,5 @@
 import { useState, useRef } from 'react';
-import { Search, Music, LogIn, LogOut } from 'lucide-react';
+import { Search, Music, LogIn, LogOut, ListStart } from 'lucide-react';
 
 import { AuthState, SuggestionItem } from '../Types';
 
@@ -9,7 +9,7 @@ interface SearchBarProps {
 
     GuildID: string;
 
-    OnEnqueue: (TidalID: number) => void;
+    OnEnqueue: (TidalID: number, Position?: string) => void;
 
     Auth: AuthState;
     ControlsLocked: boolean;
@@ -72,12 +72,12 @@ function SearchBar({ GuildID, OnEnqueue, Auth, ControlsLocked }: SearchBarProps)
 
     };
 
-    const EnqueueTrack = (TidalID: number) => {
+    const EnqueueTrack = (TidalID: number, Position?: string) => {
 
         SetQuery('');
         SetSuggestions([]);
         SetShowDropdown(false);
-        OnEnqueue(TidalID);
+        OnEnqueue(TidalID, Position);
 
     };
 
@@ -151,14 +151,24 @@ function SearchBar({ GuildID, OnEnqueue, Auth, ControlsLocked }: SearchBarProps)
 
                         S.type === 'Track' ? (
 
-                            <button key={`track-${S.tidal_id}`} onMouseDown={() => EnqueueTrack(S.tidal_id!)} className="flex w-full items-center gap-2 px-4 py-2.5 text-left text-sm transition-colors hover:bg-white/10" >
+                            <div key={`track-${S.tidal_id}`} className="flex w-full items-center transition-colors hover:bg-white/10">
 
-                              <Music size={12} className="shrink-0 text-zinc-500" />
+                                <button onMouseDown={() => EnqueueTrack(S.tidal_id!)} className="flex min-w-0 flex-1 items-center gap-2 px-4 py-2.5 text-left text-sm" >
 
-                                <span className="truncate text-white">{S.title}</span>
-                                <span className="shrink-0 text-xs text-zinc-400">{S.subtitle}</span>
+                                    <Music size={12} className="shrink-0 text-zinc-500" />
 
-                            </button>
+                                    <span className="truncate text-white">{S.title}</span>
+                                    <span className="shrink-0 text-xs text-zinc-400">{S.subtitle}</span>
+
+                                </button>
+
+                                <button onMouseDown={() => EnqueueTrack(S.tidal_id!, 'next')} title="Play next" className="shrink-0 px-3 py-2.5 text-zinc-400 transition-colors hover:text-white" aria-label="Play next">
+
+                                    <ListStart size={14} />
+
+                                </button>
+
+                            </div>
 
                         ) : (
 
@@ -38,6 +38,8 @@ export enum WSEvents {
 
     Event_ProgressUpdate = "PROGRESS_UPDATE",
 
+    Event_SkipVotes = "SKIP_VOTES",
+
     Event_Error = "ERROR",
 
 }
@@ -55,6 +57,14 @@ export enum Operation {
     Move = "Move",
     Replay = "Replay",
     Enqueue = "Enqueue",
+    Shuffle = "Shuffle",
+
+}
+
+export enum ShuffleMode {
+
+    Random = "random",
+    Smart = "smart",
 
 }
 
@@ -92,6 +102,18 @@ export interface InitialStateData {
     ControlsLocked?: boolean;
     GuildLocked?: boolean;
 
+    SkipVotes?: number;
+    SkipVotesNeeded?: number;
+
+    FairShare?: boolean;
+
+}
+
+export interface SkipVotesData {
+
+    Votes: number;
+    Needed: number;
+
 }
 
 export interface AuthState {
@@ -102,6 +124,73 @@ export interface AuthState {
 
 }
 
+// Stats Types
+
+export enum StatsPeriod {
+
+    Week = 'week',
+    Month = 'month',
+    Year = 'year',
+    All = 'all',
+
+}
+
+export interface StatsEntry {
+
+    id: string;
+    name: string;
+    detail?: string;
+    cover?: string;
+    plays: number;
+    seconds: number;
+
+}
+
+export interface PlayStats {
+
+    since: string;
+    until: string;
+
+    plays: number;
+    skips: number;
+    unique_songs: number;
+    listening_seconds: number;
+
+    top_songs: StatsEntry[];
+    top_artists: StatsEntry[];
+    top_albums: StatsEntry[];
+    top_requestors: StatsEntry[];
+
+    busiest_hours: number[];
+
+}
+
+export interface StatsResponse {
+
+    scope: 'server' | 'me';
+    period?: StatsPeriod;
+    year?: number;
+    stats: PlayStats;
+
+}
+
+// Playlist Types
+
+export interface UserPlaylist {
+
+    id: string;
+    owner_id: string;
+    name: string;
+
+    songs: Song[];
+
+    share_code?: string;
+
+    created_at: string;
+    updated_at: string;
+
+}
+
 // Lyrics Types
 
 export interface LyricsSyllabus {
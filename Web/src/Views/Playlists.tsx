@@ -0,0 +1,237 @@
+import { useEffect, useState } from 'react';
+import { ArrowDown, ArrowUp, X } from 'lucide-react';
+
+import { Song, SuggestionItem, UserPlaylist } from '../Types';
+import { FetchAPI, NormalizeCoverURL } from '../Utils/Misc';
+
+interface PlaylistsProps {
+
+    GuildID: string;
+    CurrentSong: Song | null;
+    Authenticated: boolean;
+
+}
+
+const ButtonClass = 'rounded-md border border-zinc-600 px-3 py-1 text-sm transition-colors hover:border-white disabled:opacity-40';
+const InputClass = 'min-w-0 flex-1 rounded-md border border-zinc-600 bg-transparent px-3 py-1 text-sm outline-none focus:border-white';
+
+function Playlists({ GuildID, CurrentSong, Authenticated }: PlaylistsProps) {
+
+    const [Playlists, SetPlaylists] = useState<UserPlaylist[]>([]);
+    const [Selected, SetSelected] = useState<string | null>(null);
+
+    const [NewName, SetNewName] = useState('');
+    const [ImportCode, SetImportCode] = useState('');
+    const [Query, SetQuery] = useState('');
+    const [Results, SetResults] = useState<SuggestionItem[]>([]);
+
+    const [Message, SetMessage] = useState<string | null>(null);
+
+    const Load = () => {
+
+        FetchAPI('/API/Playlists')
+            .then(async (Response) => {
+
+                if (!Response.ok) throw new Error(`Status ${Response.status}`);
+
+                const Data: { Playlists: UserPlaylist[] } = await Response.json();
+
+                SetPlaylists(Data.Playlists ?? []);
+
+            })
+            .catch(() => SetMessage('Playlists are unavailable right now.'));
+
+    };
+
+    useEffect(() => {
+
+        if (Authenticated) Load();
+
+    }, [Authenticated]);
+
+    // Search Tidal for songs to add to the selected playlist
+
+    useEffect(() => {
+
+        if (Query.trim().length < 3) {
+
+            SetResults([]);
+            return;
+
+        }
+
+        const Timeout = setTimeout(() => {
+
+            FetchAPI(`/API/Suggestions?ID=${GuildID}&q=${encodeURIComponent(Query)}`)
+                .then(async (Response) => Response.ok ? SetResults((await Response.json() as SuggestionItem[]).filter((Item) => Item.type == 'Track')) : SetResults([]))
+                .catch(() => SetResults([]));
+
+        }, 300);
+
+        return () => clearTimeout(Timeout);
+
+    }, [Query, GuildID]);
+
+    const Act = async (Action: string, Body: Record<string, unknown> = {}) => {
+
+        SetMessage(null);
+
+        try {
+
+            const Response = await FetchAPI('/API/Playlists', {
+
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ Action, ID: GuildID, ...Body }),
+
+            });
+
+            const Data: { Playlist?: UserPlaylist, Error?: string } = await Response.json();
+
+            if (!Response.ok) {
+
+                SetMessage(Data.Error ?? 'Something went wrong.');
+                return;
+
+            }
+
+            if (Action == 'Load' || Action == 'Append') SetMessage('Added to the queue.');
+
+            if (Action == 'Delete') SetSelected(null);
+            else if (Data.Playlist) SetSelected(Data.Playlist.id);
+
+            Load();
+
+        } catch {
+
+            SetMessage('Something went wrong.');
+
+        }
+
+    };
+
+    if (!Authenticated) {
+
+        return <p className="min-h-[200px] text-center text-zinc-400">Sign in with Discord to manage your playlists.</p>;
+
+    }
+
+    const Playlist = Playlists.find((Entry) => Entry.id == Selected) ?? null;
+
+    return (
+
+        <div className="min-h-[200px] max-h-[500px] overflow-y-auto">
+
+            {Message && <p className="mb-3 text-center text-sm text-zinc-400">{Message}</p>}
+
+            {!Playlist && (
+
+                <>
+
+                    <div className="mb-4 flex gap-2">
+
+                        <input value={NewName} onChange={(Event) => SetNewName(Event.target.value)} placeholder="New playlist name" maxLength={32} className={InputClass} />
+                        <button onClick={() => { Act('Create', { Name: NewName }); SetNewName(''); }} disabled={NewName.trim() == ''} className={ButtonClass}>Create</button>
+
+                    </div>
+
+                    <div className="mb-5 flex gap-2">
+
+                        <input value={ImportCode} onChange={(Event) => SetImportCode(Event.target.value.toUpperCase())} placeholder="Share code" maxLength={8} className={InputClass} />
+                        <button onClick={() => { Act('Import', { Code: ImportCode }); SetImportCode(''); }} disabled={ImportCode.trim() == ''} className={ButtonClass}>Import</button>
+
+                    </div>
+
+                    {Playlists.length == 0 && <p className="text-center text-zinc-400">You have no playlists yet.</p>}
+
+                    {Playlists.map((Entry) => (
+
+                        <button key={Entry.id} onClick={() => SetSelected(Entry.id)} className="flex w-full items-center justify-between rounded-md px-3 py-2 text-left transition-colors hover:bg-white/5">
+
+                            <span className="truncate">{Entry.name}</span>
+                            <span className="text-sm text-zinc-400">{Entry.songs.length} songs{Entry.share_code ? ` • ${Entry.share_code}` : ''}</span>
+
+                        </button>
+
+                    ))}
+
+                </>
+
+            )}
+
+            {Playlist && (
+
+                <>
+
+                    <div className="mb-4 flex flex-wrap items-center gap-2">
+
+                        <button onClick={() => SetSelected(null)} className={ButtonClass}>Back</button>
+
+                        <h3 className="mr-auto truncate text-lg font-semibold">{Playlist.name}</h3>
+
+                        <button onClick={() => Act('Append', { Name: Playlist.name })} disabled={Playlist.songs.length == 0} className={ButtonClass}>Add to Queue</button>
+                        <button onClick={() => Act('Load', { Name: Playlist.name })} disabled={Playlist.songs.length == 0} className={ButtonClass}>Replace Queue</button>
+
+                    </div>
+
+                    <div className="mb-4 flex flex-wrap gap-2">
+
+                        <button onClick={() => { const Name = window.prompt('Rename playlist', Playlist.name); if (Name) Act('Rename', { Name: Playlist.name, NewName: Name }); }} className={ButtonClass}>Rename</button>
+                        <button onClick={() => Act(Playlist.share_code ? 'Unshare' : 'Share', { Name: Playlist.name })} className={ButtonClass}>{Playlist.share_code ? `Unshare (${Playlist.share_code})` : 'Share'}</button>
+                        <button onClick={() => { if (window.confirm(`Delete ${Playlist.name}?`)) Act('Delete', { Name: Playlist.name }); }} className={ButtonClass}>Delete</button>
+                        {CurrentSong && <button onClick={() => Act('Add', { Name: Playlist.name, TidalID: CurrentSong.tidal_id })} className={ButtonClass}>Add Current Song</button>}
+
+                    </div>
+
+                    <div className="mb-4">
+
+                        <input value={Query} onChange={(Event) => SetQuery(Event.target.value)} placeholder="Search songs to add" className={`${InputClass} w-full`} />
+
+                        {Results.map((Item) => (
+
+                            <button key={Item.tidal_id} onClick={() => { Act('Add', { Name: Playlist.name, TidalID: Item.tidal_id }); SetQuery(''); }} className="block w-full truncate rounded-md px-3 py-1.5 text-left text-sm transition-colors hover:bg-white/5">
+
+                                {Item.title} <span className="text-zinc-500">• {Item.subtitle}</span>
+
+                            </button>
+
+                        ))}
+
+                    </div>
+
+                    {Playlist.songs.length == 0 && <p className="text-center text-zinc-400">This playlist is empty.</p>}
+
+                    {Playlist.songs.map((Entry, Index) => (
+
+                        <div key={`${Entry.tidal_id}-${Index}`} className="flex items-center gap-3 py-1.5">
+
+                            <span className="w-5 text-right text-sm text-zinc-500">{Index + 1}</span>
+
+                            {Entry.cover && <img src={NormalizeCoverURL(Entry.cover)} alt={Entry.title} referrerPolicy="no-referrer" className="h-9 w-9 rounded object-cover" />}
+
+                            <div className="min-w-0 flex-1">
+
+                                <p className="truncate">{Entry.title}</p>
+                                <p className="truncate text-sm text-zinc-500">{Entry.artists.join(', ')}</p>
+
+                            </div>
+
+                            <button onClick={() => Act('Move', { Name: Playlist.name, Index, To: Index - 1 })} disabled={Index == 0} className="text-zinc-400 hover:text-white disabled:opacity-30" aria-label="Move up"><ArrowUp size={16} /></button>
+                            <button onClick={() => Act('Move', { Name: Playlist.name, Index, To: Index + 1 })} disabled={Index == Playlist.songs.length - 1} className="text-zinc-400 hover:text-white disabled:opacity-30" aria-label="Move down"><ArrowDown size={16} /></button>
+                            <button onClick={() => Act('Remove', { Name: Playlist.name, Index })} className="text-zinc-400 hover:text-white" aria-label="Remove"><X size={16} /></button>
+
+                        </div>
+
+                    ))}
+
+                </>
+
+            )}
+
+        </div>
+
+    );
+
+}
+
+export default Playlists;
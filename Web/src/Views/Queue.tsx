@@ -4,9 +4,9 @@ import { DndContext, DragOverlay, KeyboardSensor, PointerSensor, closestCenter,
 import { SortableContext, sortableKeyboardCoordinates, useSortable, verticalListSortingStrategy, } from '@dnd-kit/sortable';
 import { CSS } from '@dnd-kit/utilities';
 
-import { MoreHorizontal, ChevronDown, ChevronUp, GripVertical } from 'lucide-react';
+import { MoreHorizontal, ChevronDown, ChevronUp, GripVertical, Shuffle, Sparkles } from 'lucide-react';
 
-import { Song } from '../Types';
+import { Song, ShuffleMode } from '../Types';
 
 interface QueueProps {
 
@@ -19,9 +19,12 @@ interface QueueProps {
     SetActiveContextMenu: (Menu: { type: 'Previous' | 'Upcoming', index: number, x: number, y: number } | null) => void;
 
     OnMove: (FromIndex: number, ToIndex: number) => void;
+    OnShuffle: (Mode: ShuffleMode) => void;
 
     ControlsLocked?: boolean;
 
+    FairShare?: boolean;
+
 }
 
 const UpcomingID = (Index: number) => `upcoming-${Index}`;
@@ -177,7 +180,7 @@ function SortableUpcomingRow({ Song, Index, ControlsLocked, ActiveContextMenu, S
 
 }
 
-function Queue({ Current, PreviousSongs, UpcomingSongs, ActiveContextMenu, SetActiveContextMenu, OnMove, ControlsLocked = false }: QueueProps) {
+function Queue({ Current, PreviousSongs, UpcomingSongs, ActiveContextMenu, SetActiveContextMenu, OnMove, OnShuffle, ControlsLocked = false, FairShare = false }: QueueProps) {
 
     const [ShowPrevious, SetShowPrevious] = useState(false);
     const [ActiveDragIndex, SetActiveDragIndex] = useState<number | null>(null);
@@ -315,7 +318,41 @@ function Queue({ Current, PreviousSongs, UpcomingSongs, ActiveContextMenu, SetAc
 
                 <div>
 
-                    <h2 className="mb-4 text-xs font-bold uppercase tracking-wider text-zinc-500">Next Up</h2>
+                    <div className="mb-4 flex items-center justify-between">
+
+                        <h2 className="text-xs font-bold uppercase tracking-wider text-zinc-500">
+
+                            Next Up
+
+                            {FairShare && (
+
+                                <span className="ml-2 rounded bg-zinc-800 px-1.5 py-0.5 text-zinc-400" title="Songs are interleaved so each requester takes a turn">Fair Share</span>
+
+                            )}
+
+                        </h2>
+
+                        {!ControlsLocked && UpcomingSongs.length > 1 && (
+
+                            <div className="flex items-center gap-3 text-zinc-400">
+
+                                <button type="button" onClick={() => OnShuffle(ShuffleMode.Random)} className="transition-colors hover:text-white" title="Shuffle">
+
+                                    <Shuffle size={16} />
+
+                                </button>
+
+                                <button type="button" onClick={() => OnShuffle(ShuffleMode.Smart)} className="transition-colors hover:text-white" title="Smart shuffle: spread out artists, albums and requesters">
+
+                                    <Sparkles size={16} />
+
+                                </button>
+
+                            </div>
+
+                        )}
+
+                    </div>
 
                     <DndContext sensors={Sensors} collisionDetection={closestCenter} onDragStart={HandleDragStart} onDragEnd={HandleDragEnd} onDragCancel={HandleDragCancel} >
 
@@ -0,0 +1,163 @@
+import { useEffect, useState } from 'react';
+
+import { StatsEntry, StatsPeriod, StatsResponse } from '../Types';
+import { FetchAPI, NormalizeCoverURL } from '../Utils/Misc';
+
+interface StatsProps {
+
+    GuildID: string;
+    Authenticated: boolean;
+
+}
+
+const Periods: { Value: StatsPeriod, Label: string }[] = [
+
+    { Value: StatsPeriod.Week, Label: 'Week' },
+    { Value: StatsPeriod.Month, Label: 'Month' },
+    { Value: StatsPeriod.Year, Label: 'Year' },
+    { Value: StatsPeriod.All, Label: 'All Time' },
+
+];
+
+// Formats listening time from seconds as hours or minutes
+const FormatListening = (Seconds: number): string => {
+
+    if (Seconds >= 3600) return `${Math.floor(Seconds / 3600)}h ${Math.floor((Seconds % 3600) / 60)}m`;
+
+    return `${Math.floor(Seconds / 60)}m`;
+
+};
+
+function TopList({ Title, Entries }: { Title: string, Entries: StatsEntry[] }) {
+
+    if (Entries.length == 0) return null;
+
+    return (
+
+        <div className="mb-5">
+
+            <h3 className="mb-2 text-sm font-semibold uppercase tracking-wide text-zinc-400">{Title}</h3>
+
+            {Entries.map((Entry, Index) => (
+
+                <div key={Entry.id} className="flex items-center gap-3 py-1.5">
+
+                    <span className="w-5 text-right text-sm text-zinc-500">{Index + 1}</span>
+
+                    {Entry.cover && <img src={NormalizeCoverURL(Entry.cover)} alt={Entry.name} referrerPolicy="no-referrer" className="h-9 w-9 rounded object-cover" />}
+
+                    <div className="min-w-0 flex-1">
+
+                        <p className="truncate">{Entry.name}</p>
+                        {Entry.detail && <p className="truncate text-sm text-zinc-500">{Entry.detail}</p>}
+
+                    </div>
+
+                    <span className="text-sm text-zinc-400">{Entry.plays} plays</span>
+
+                </div>
+
+            ))}
+
+        </div>
+
+    );
+
+}
+
+function Stats({ GuildID, Authenticated }: StatsProps) {
+
+    const [Scope, SetScope] = useState<'server' | 'me'>('server');
+    const [Period, SetPeriod] = useState<StatsPeriod>(StatsPeriod.Month);
+
+    const [Data, SetData] = useState<StatsResponse | null>(null);
+    const [LoadError, SetLoadError] = useState(false);
+
+    useEffect(() => {
+
+        SetLoadError(false);
+
+        FetchAPI(`/API/Stats?ID=${GuildID}&Period=${Period}&Scope=${Scope}`)
+            .then(async (Response) => {
+
+                if (!Response.ok) throw new Error(`Status ${Response.status}`);
+
+                SetData(await Response.json());
+
+            })
+            .catch(() => SetLoadError(true));
+
+    }, [GuildID, Period, Scope]);
+
+    const Hours = Data?.stats.busiest_hours ?? [];
+    const PeakPlays = Math.max(1, ...Hours);
+
+    return (
+
+        <div className="min-h-[200px] max-h-[500px] overflow-y-auto">
+
+            <div className="mb-5 flex flex-wrap items-center justify-between gap-2">
+
+                <div className="flex gap-2">
+
+                    <button onClick={() => SetScope('server')} className={`rounded-md border px-3 py-1 text-sm transition-colors ${Scope == 'server' ? 'border-white bg-white text-zinc-950' : 'border-zinc-600 hover:border-white'}`}>Server</button>
+                    {Authenticated && <button onClick={() => SetScope('me')} className={`rounded-md border px-3 py-1 text-sm transition-colors ${Scope == 'me' ? 'border-white bg-white text-zinc-950' : 'border-zinc-600 hover:border-white'}`}>Me</button>}
+
+                </div>
+
+                <div className="flex gap-2">
+
+                    {Periods.map((Option) => (
+
+                        <button key={Option.Value} onClick={() => SetPeriod(Option.Value)} className={`rounded-md border px-3 py-1 text-sm transition-colors ${Period == Option.Value ? 'border-white bg-white text-zinc-950' : 'border-zinc-600 hover:border-white'}`}>{Option.Label}</button>
+
+                    ))}
+
+                </div>
+
+            </div>
+
+            {LoadError && <p className="text-center text-zinc-400">Stats are unavailable right now.</p>}
+
+            {!LoadError && Data && Data.stats.plays == 0 && <p className="text-center text-zinc-400">Nothing has been played in this period yet.</p>}
+
+            {!LoadError && Data && Data.stats.plays > 0 && (
+
+                <>
+
+                    <div className="mb-6 grid grid-cols-3 gap-3 text-center">
+
+                        <div className="rounded-lg bg-white/5 p-3"><p className="text-2xl font-bold">{FormatListening(Data.stats.listening_seconds)}</p><p className="text-xs text-zinc-400">Listened</p></div>
+                        <div className="rounded-lg bg-white/5 p-3"><p className="text-2xl font-bold">{Data.stats.plays}</p><p className="text-xs text-zinc-400">Plays</p></div>
+                        <div className="rounded-lg bg-white/5 p-3"><p className="text-2xl font-bold">{Data.stats.unique_songs}</p><p className="text-xs text-zinc-400">Unique Songs</p></div>
+
+                    </div>
+
+                    <TopList Title="Top Songs" Entries={Data.stats.top_songs} />
+                    <TopList Title="Top Artists" Entries={Data.stats.top_artists} />
+                    <TopList Title="Top Albums" Entries={Data.stats.top_albums} />
+                    {Scope == 'server' && <TopList Title="Top Requestors" Entries={Data.stats.top_requestors} />}
+
+                    <h3 className="mb-2 text-sm font-semibold uppercase tracking-wide text-zinc-400">Busiest Hours (UTC)</h3>
+
+                    <div className="flex h-24 items-end gap-0.5">
+
+                        {Hours.map((Plays, Hour) => (
+
+                            <div key={Hour} title={`${Hour.toString().padStart(2, '0')}:00 • ${Plays} plays`} className="flex-1 rounded-t bg-white/60" style={{ height: `${Math.max(2, (Plays / PeakPlays) * 100)}%` }} />
+
+                        ))}
+
+                    </div>
+
+                </>
+
+            )}
+
+        </div>
+
+    );
+
+}
+
+export default Stats;
@@ -10,10 +10,13 @@ import (
 	"Synthara-Redux/Globals/Localizations"
 	"Synthara-Redux/Handlers"
 	"Synthara-Redux/Server"
+	"Synthara-Redux/Structs"
 	"Synthara-Redux/Utils"
 	"fmt"
 	"os"
 
+	_ "time/tzdata" // schedules accept IANA timezones even on hosts without zoneinfo
+
 	"github.com/joho/godotenv"
 )
 
@@ -66,6 +69,8 @@ func main() {
 
 	Utils.Logger.Info("Database", "Connected to MongoDB.")
 
+	Structs.EnsureIndexes()
+
 	InitErr := Globals.InitDiscordClient()
 
 	if InitErr != nil {
@@ -144,6 +149,12 @@ func main() {
 
 	Utils.Logger.Info("API", "YouTube client initialized.")
 
+	// Scheduled playback, started last so due jobs can resolve songs
+
+	Structs.StartScheduler()
+
+	Utils.Logger.Info("Scheduler", "Scheduled playback started.")
+
 	// Done with setup; now we wait for events
 
 	Utils.Hang()